@@ -37,6 +37,7 @@ type Supervisor struct {
 	Agent        Agent        `mapstructure:"agent"`
 	Capabilities Capabilities `mapstructure:"capabilities"`
 	Storage      Storage      `mapstructure:"storage"`
+	Packages     Packages     `mapstructure:"packages"`
 	Telemetry    Telemetry    `mapstructure:"telemetry"`
 	HealthCheck  HealthCheck  `mapstructure:"healthcheck"`
 }
@@ -92,6 +93,10 @@ func (s Supervisor) Validate() error {
 		return err
 	}
 
+	if s.Capabilities.AcceptsPackages && s.Packages.PublicKeyFile == "" {
+		return errors.New("packages::public_key_file must be specified when capabilities::accepts_packages is enabled")
+	}
+
 	return nil
 }
 
@@ -102,11 +107,21 @@ type Storage struct {
 	_ struct{}
 }
 
+// Packages configures the installation of the Collector packages offered by the OpAMP server.
+type Packages struct {
+	// PublicKeyFile is the path to the PEM encoded Ed25519 public key used to verify the
+	// signature of the packages. The signature is computed over the SHA-256 hash of the package.
+	PublicKeyFile string `mapstructure:"public_key_file"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
 // Capabilities is the set of capabilities that the Supervisor supports.
 type Capabilities struct {
 	AcceptsRemoteConfig            bool `mapstructure:"accepts_remote_config"`
 	AcceptsRestartCommand          bool `mapstructure:"accepts_restart_command"`
 	AcceptsOpAMPConnectionSettings bool `mapstructure:"accepts_opamp_connection_settings"`
+	AcceptsPackages                bool `mapstructure:"accepts_packages"`
 	ReportsEffectiveConfig         bool `mapstructure:"reports_effective_config"`
 	ReportsOwnMetrics              bool `mapstructure:"reports_own_metrics"`
 	ReportsOwnLogs                 bool `mapstructure:"reports_own_logs"`
@@ -156,6 +171,12 @@ func (c Capabilities) SupportedCapabilities() protobufs.AgentCapabilities {
 		supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_AcceptsOpAMPConnectionSettings
 	}
 
+	if c.AcceptsPackages {
+		// Package statuses are always reported for the packages that are accepted.
+		supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_AcceptsPackages |
+			protobufs.AgentCapabilities_AgentCapabilities_ReportsPackageStatuses
+	}
+
 	if c.ReportsAvailableComponents {
 		supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsAvailableComponents
 	}
@@ -220,6 +241,7 @@ type Agent struct {
 	OpAMPServerPort         int               `mapstructure:"opamp_server_port"`
 	PassthroughLogs         bool              `mapstructure:"passthrough_logs"`
 	UseHUPConfigReload      bool              `mapstructure:"use_hup_config_reload"`
+	RevertUnhealthyConfig   bool              `mapstructure:"revert_unhealthy_config"`
 	ConfigFiles             []string          `mapstructure:"config_files"`
 	Arguments               []string          `mapstructure:"args"`
 	Env                     map[string]string `mapstructure:"env"`
@@ -362,6 +384,7 @@ func DefaultSupervisor() Supervisor {
 			AcceptsRemoteConfig:            false,
 			AcceptsRestartCommand:          false,
 			AcceptsOpAMPConnectionSettings: false,
+			AcceptsPackages:                false,
 			ReportsEffectiveConfig:         true,
 			ReportsOwnMetrics:              true,
 			ReportsOwnLogs:                 false,
@@ -379,6 +402,7 @@ func DefaultSupervisor() Supervisor {
 			ConfigApplyTimeout:      5 * time.Second,
 			BootstrapTimeout:        3 * time.Second,
 			PassthroughLogs:         false,
+			RevertUnhealthyConfig:   false,
 		},
 		Telemetry: Telemetry{
 			Logs: Logs{
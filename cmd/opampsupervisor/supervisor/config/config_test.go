@@ -473,6 +473,28 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErrorFunc: simpleError("healthcheck::endpoint must contain a valid port number, got -1"),
 		},
+		{
+			name: "Packages accepted without public key",
+			config: Supervisor{
+				Server: OpAMPServer{
+					Endpoint: "wss://localhost:9090/opamp",
+					TLS:      tlsConfig,
+				},
+				Agent: Agent{
+					Executable:              "${file_path}",
+					OrphanDetectionInterval: 5 * time.Second,
+					ConfigApplyTimeout:      2 * time.Second,
+					BootstrapTimeout:        5 * time.Second,
+				},
+				Capabilities: Capabilities{
+					AcceptsPackages: true,
+				},
+				Storage: Storage{
+					Directory: "/etc/opamp-supervisor/storage",
+				},
+			},
+			expectedErrorFunc: simpleError("packages::public_key_file must be specified when capabilities::accepts_packages is enabled"),
+		},
 	}
 
 	// create some fake files for validating agent config
@@ -579,6 +601,7 @@ func TestCapabilities_SupportedCapabilities(t *testing.T) {
 				AcceptsRemoteConfig:            true,
 				AcceptsRestartCommand:          true,
 				AcceptsOpAMPConnectionSettings: true,
+				AcceptsPackages:                true,
 				ReportsEffectiveConfig:         true,
 				ReportsOwnMetrics:              true,
 				ReportsOwnLogs:                 true,
@@ -598,6 +621,8 @@ func TestCapabilities_SupportedCapabilities(t *testing.T) {
 				protobufs.AgentCapabilities_AgentCapabilities_ReportsRemoteConfig |
 				protobufs.AgentCapabilities_AgentCapabilities_AcceptsRestartCommand |
 				protobufs.AgentCapabilities_AgentCapabilities_AcceptsOpAMPConnectionSettings |
+				protobufs.AgentCapabilities_AgentCapabilities_AcceptsPackages |
+				protobufs.AgentCapabilities_AgentCapabilities_ReportsPackageStatuses |
 				protobufs.AgentCapabilities_AgentCapabilities_ReportsAvailableComponents |
 				protobufs.AgentCapabilities_AgentCapabilities_ReportsHeartbeat,
 		},
@@ -649,6 +674,7 @@ agent:
 						OrphanDetectionInterval: DefaultSupervisor().Agent.OrphanDetectionInterval,
 						ConfigApplyTimeout:      DefaultSupervisor().Agent.ConfigApplyTimeout,
 						BootstrapTimeout:        DefaultSupervisor().Agent.BootstrapTimeout,
+						RevertUnhealthyConfig:   DefaultSupervisor().Agent.RevertUnhealthyConfig,
 					},
 					Telemetry: DefaultSupervisor().Telemetry,
 				}
@@ -691,6 +717,7 @@ agent:
   bootstrap_timeout: 8s
   opamp_server_port: 8090
   passthrough_logs: true
+  revert_unhealthy_config: true
 
 telemetry:
   logs:
@@ -737,6 +764,7 @@ telemetry:
 						BootstrapTimeout:        8 * time.Second,
 						OpAMPServerPort:         8090,
 						PassthroughLogs:         true,
+						RevertUnhealthyConfig:   true,
 					},
 					Telemetry: Telemetry{
 						Logs: Logs{
@@ -772,6 +800,7 @@ agent:
 						OrphanDetectionInterval: DefaultSupervisor().Agent.OrphanDetectionInterval,
 						ConfigApplyTimeout:      DefaultSupervisor().Agent.ConfigApplyTimeout,
 						BootstrapTimeout:        DefaultSupervisor().Agent.BootstrapTimeout,
+						RevertUnhealthyConfig:   DefaultSupervisor().Agent.RevertUnhealthyConfig,
 					},
 					Telemetry: DefaultSupervisor().Telemetry,
 				}
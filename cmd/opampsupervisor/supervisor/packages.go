@@ -0,0 +1,393 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	packagesDirName                 = "packages"
+	packagesStateFileName           = "packages.yaml"
+	lastReportedPackageStatusesFile = "last_reported_package_statuses.dat"
+	agentBackupFileName             = "agent.backup"
+)
+
+// errAgentPackageRolledBack is returned when the Collector installed from a package did not
+// become healthy, and the previous executable was restored.
+var errAgentPackageRolledBack = errors.New("the agent did not become healthy after the update and was rolled back")
+
+// localPackage is the state of a package installed by the Supervisor. The hashes are hex encoded
+// for human readability.
+type localPackage struct {
+	Type        protobufs.PackageType `yaml:"type"`
+	Hash        string                `yaml:"hash"`
+	Version     string                `yaml:"version"`
+	ContentHash string                `yaml:"content_hash"`
+}
+
+// packagesState is the state of the packages, persisted in the packages directory.
+type packagesState struct {
+	AllPackagesHash string                   `yaml:"all_packages_hash"`
+	Packages        map[string]*localPackage `yaml:"packages"`
+	// BadContentHashes are the content hashes of the packages that were rolled back. They are not
+	// installed again, even when offered by the server.
+	BadContentHashes []string `yaml:"bad_content_hashes"`
+}
+
+// packageInstaller installs the verified Collector executable staged at the given path.
+type packageInstaller func(ctx context.Context, stagedPath string) error
+
+// packageManager implements the PackagesStateProvider used by the OpAMP client to sync the
+// packages offered by the server. Only the top-level package, the Collector itself, is supported:
+// its content is downloaded to the packages directory, verified, and then handed to the installer.
+type packageManager struct {
+	dir       string
+	publicKey ed25519.PublicKey
+	install   packageInstaller
+	logger    *zap.Logger
+
+	mu    sync.Mutex
+	state packagesState
+}
+
+var _ types.PackagesStateProvider = (*packageManager)(nil)
+
+func newPackageManager(dir, publicKeyFile string, install packageInstaller, logger *zap.Logger) (*packageManager, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("error creating packages dir: %w", err)
+	}
+
+	publicKey, err := loadPackagePublicKey(publicKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &packageManager{
+		dir:       dir,
+		publicKey: publicKey,
+		install:   install,
+		logger:    logger,
+	}
+
+	by, err := os.ReadFile(m.statePath())
+	switch {
+	case err == nil:
+		if err := yaml.Unmarshal(by, &m.state); err != nil {
+			return nil, fmt.Errorf("could not parse packages state: %w", err)
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("could not read packages state: %w", err)
+	}
+	if m.state.Packages == nil {
+		m.state.Packages = map[string]*localPackage{}
+	}
+
+	return m, nil
+}
+
+// loadPackagePublicKey reads the PEM encoded Ed25519 public key used to verify the package signatures.
+func loadPackagePublicKey(file string) (ed25519.PublicKey, error) {
+	by, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not read packages public key: %w", err)
+	}
+
+	block, _ := pem.Decode(by)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in packages public key %s", file)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse packages public key: %w", err)
+	}
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("packages public key must be an Ed25519 key, got %T", key)
+	}
+	return publicKey, nil
+}
+
+func (m *packageManager) statePath() string {
+	return filepath.Join(m.dir, packagesStateFileName)
+}
+
+// writeState persists the state, the caller must hold the lock.
+func (m *packageManager) writeState() error {
+	by, err := yaml.Marshal(&m.state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(m.statePath(), by, 0o600)
+}
+
+func (m *packageManager) AllPackagesHash() ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return hex.DecodeString(m.state.AllPackagesHash)
+}
+
+func (m *packageManager) SetAllPackagesHash(hash []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.AllPackagesHash = hex.EncodeToString(hash)
+	return m.writeState()
+}
+
+func (m *packageManager) Packages() ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.state.Packages))
+	for name := range m.state.Packages {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+func (m *packageManager) PackageState(packageName string) (types.PackageState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pkg, ok := m.state.Packages[packageName]
+	if !ok {
+		return types.PackageState{Exists: false}, nil
+	}
+	hash, err := hex.DecodeString(pkg.Hash)
+	if err != nil {
+		return types.PackageState{}, fmt.Errorf("invalid hash of package %q: %w", packageName, err)
+	}
+	return types.PackageState{
+		Exists:  true,
+		Type:    pkg.Type,
+		Hash:    hash,
+		Version: pkg.Version,
+	}, nil
+}
+
+func (m *packageManager) SetPackageState(packageName string, state types.PackageState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pkg, ok := m.state.Packages[packageName]
+	if !ok {
+		return fmt.Errorf("package %q does not exist", packageName)
+	}
+	if pkg.Type != state.Type {
+		return fmt.Errorf("package %q is of type %s, not %s", packageName, pkg.Type, state.Type)
+	}
+	pkg.Hash = hex.EncodeToString(state.Hash)
+	pkg.Version = state.Version
+	return m.writeState()
+}
+
+func (m *packageManager) CreatePackage(packageName string, typ protobufs.PackageType) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.state.Packages[packageName]; ok {
+		return fmt.Errorf("package %q already exists", packageName)
+	}
+	if typ != protobufs.PackageType_PackageType_TopLevel {
+		return fmt.Errorf("package %q is of type %s, only the top-level package is supported", packageName, typ)
+	}
+	for name, pkg := range m.state.Packages {
+		if pkg.Type == protobufs.PackageType_PackageType_TopLevel {
+			return fmt.Errorf("package %q cannot be created, %q is already the top-level package", packageName, name)
+		}
+	}
+
+	m.state.Packages[packageName] = &localPackage{Type: typ}
+	return m.writeState()
+}
+
+func (m *packageManager) FileContentHash(packageName string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pkg, ok := m.state.Packages[packageName]
+	if !ok || pkg.ContentHash == "" {
+		return nil, nil
+	}
+	return hex.DecodeString(pkg.ContentHash)
+}
+
+// UpdateContent stages the package content under the packages directory, verifies its hash and
+// signature, and installs it.
+func (m *packageManager) UpdateContent(ctx context.Context, packageName string, data io.Reader, contentHash, signature []byte) error {
+	m.mu.Lock()
+	_, ok := m.state.Packages[packageName]
+	bad := slices.Contains(m.state.BadContentHashes, hex.EncodeToString(contentHash))
+	m.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("package %q does not exist", packageName)
+	}
+	if bad {
+		return fmt.Errorf("package %q with content hash %x was previously rolled back", packageName, contentHash)
+	}
+
+	stagedPath, err := m.stage(ctx, data, contentHash, signature)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(stagedPath)
+
+	m.logger.Info("Installing agent package", zap.String("package", packageName), zap.String("content_hash", hex.EncodeToString(contentHash)))
+	if err := m.install(ctx, stagedPath); err != nil {
+		if errors.Is(err, errAgentPackageRolledBack) {
+			m.mu.Lock()
+			m.state.BadContentHashes = append(m.state.BadContentHashes, hex.EncodeToString(contentHash))
+			if writeErr := m.writeState(); writeErr != nil {
+				m.logger.Error("Could not save the rolled back package", zap.Error(writeErr))
+			}
+			m.mu.Unlock()
+		}
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if pkg, ok := m.state.Packages[packageName]; ok {
+		pkg.ContentHash = hex.EncodeToString(contentHash)
+	}
+	return m.writeState()
+}
+
+// stage writes the package content to a file of the packages directory, and returns its path once
+// the SHA-256 hash of the content and its Ed25519 signature have been verified.
+func (m *packageManager) stage(ctx context.Context, data io.Reader, contentHash, signature []byte) (string, error) {
+	if len(signature) == 0 {
+		return "", errors.New("package is not signed")
+	}
+
+	f, err := os.CreateTemp(m.dir, "agent-*.staged")
+	if err != nil {
+		return "", fmt.Errorf("could not stage package: %w", err)
+	}
+	stagedPath := f.Name()
+
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, h), &contextReader{ctx: ctx, r: data})
+	if err = errors.Join(err, f.Close()); err != nil {
+		os.Remove(stagedPath)
+		return "", fmt.Errorf("could not stage package: %w", err)
+	}
+
+	sum := h.Sum(nil)
+	if !bytes.Equal(sum, contentHash) {
+		os.Remove(stagedPath)
+		return "", fmt.Errorf("package content hash mismatch, expected %x but got %x", contentHash, sum)
+	}
+	if !ed25519.Verify(m.publicKey, sum, signature) {
+		os.Remove(stagedPath)
+		return "", errors.New("package signature verification failed")
+	}
+	return stagedPath, nil
+}
+
+func (m *packageManager) DeletePackage(packageName string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// The Collector executable is left in place, as the Supervisor cannot run without it.
+	delete(m.state.Packages, packageName)
+	return m.writeState()
+}
+
+func (m *packageManager) LastReportedStatuses() (*protobufs.PackageStatuses, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	by, err := os.ReadFile(filepath.Join(m.dir, lastReportedPackageStatusesFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := &protobufs.PackageStatuses{}
+	if err := proto.Unmarshal(by, statuses); err != nil {
+		return nil, fmt.Errorf("could not parse last reported package statuses: %w", err)
+	}
+	return statuses, nil
+}
+
+func (m *packageManager) SetLastReportedStatuses(statuses *protobufs.PackageStatuses) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	by, err := proto.Marshal(statuses)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(m.dir, lastReportedPackageStatusesFile), by, 0o600)
+}
+
+// contextReader stops reading once its context is done, so that a download is aborted when
+// the package sync is cancelled.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c *contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
+
+// replaceFile atomically replaces dst with a copy of src. The copy is first written next to dst,
+// so that it can be renamed over dst even when src is on another file system.
+func replaceFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), filepath.Base(dst)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	_, err = io.Copy(tmp, in)
+	if err = errors.Join(err, tmp.Sync(), tmp.Close()); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, dst); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,352 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/commander"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/config"
+)
+
+// writePackagePublicKey generates a signing key, and writes its public key to a PEM file.
+func writePackagePublicKey(t *testing.T, dir string) (ed25519.PrivateKey, string) {
+	t.Helper()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, "package.pub")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+	return privateKey, path
+}
+
+func TestPackageManager_State(t *testing.T) {
+	tmpDir := t.TempDir()
+	_, publicKeyFile := writePackagePublicKey(t, tmpDir)
+	dir := filepath.Join(tmpDir, packagesDirName)
+
+	m, err := newPackageManager(dir, publicKeyFile, nil, zap.NewNop())
+	require.NoError(t, err)
+
+	state, err := m.PackageState("")
+	require.NoError(t, err)
+	assert.False(t, state.Exists)
+
+	require.ErrorContains(t, m.CreatePackage("addon", protobufs.PackageType_PackageType_Addon), "only the top-level package is supported")
+	require.NoError(t, m.CreatePackage("", protobufs.PackageType_PackageType_TopLevel))
+	require.ErrorContains(t, m.CreatePackage("", protobufs.PackageType_PackageType_TopLevel), "already exists")
+	require.ErrorContains(t, m.CreatePackage("other", protobufs.PackageType_PackageType_TopLevel), "already the top-level package")
+
+	require.NoError(t, m.SetPackageState("", types.PackageState{
+		Exists:  true,
+		Type:    protobufs.PackageType_PackageType_TopLevel,
+		Hash:    []byte{0x01, 0x02},
+		Version: "v0.140.0",
+	}))
+	require.NoError(t, m.SetAllPackagesHash([]byte{0x03}))
+	require.NoError(t, m.SetLastReportedStatuses(&protobufs.PackageStatuses{
+		ServerProvidedAllPackagesHash: []byte{0x03},
+		Packages: map[string]*protobufs.PackageStatus{
+			"": {Name: "", AgentHasVersion: "v0.140.0"},
+		},
+	}))
+
+	// The state is restored from the packages directory
+	m, err = newPackageManager(dir, publicKeyFile, nil, zap.NewNop())
+	require.NoError(t, err)
+
+	names, err := m.Packages()
+	require.NoError(t, err)
+	assert.Equal(t, []string{""}, names)
+
+	state, err = m.PackageState("")
+	require.NoError(t, err)
+	assert.Equal(t, types.PackageState{
+		Exists:  true,
+		Type:    protobufs.PackageType_PackageType_TopLevel,
+		Hash:    []byte{0x01, 0x02},
+		Version: "v0.140.0",
+	}, state)
+
+	hash, err := m.AllPackagesHash()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x03}, hash)
+
+	statuses, err := m.LastReportedStatuses()
+	require.NoError(t, err)
+	assert.Equal(t, "v0.140.0", statuses.Packages[""].AgentHasVersion)
+
+	require.NoError(t, m.DeletePackage(""))
+	names, err = m.Packages()
+	require.NoError(t, err)
+	assert.Empty(t, names)
+}
+
+func TestPackageManager_InvalidPublicKey(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "package.pub")
+	require.NoError(t, os.WriteFile(path, []byte("not a key"), 0o600))
+
+	_, err := newPackageManager(filepath.Join(tmpDir, packagesDirName), path, nil, zap.NewNop())
+	require.ErrorContains(t, err, "no PEM block found")
+}
+
+func TestPackageManager_UpdateContent(t *testing.T) {
+	content := []byte("new collector")
+	sum := sha256.Sum256(content)
+
+	newManager := func(t *testing.T, install packageInstaller) (*packageManager, ed25519.PrivateKey) {
+		tmpDir := t.TempDir()
+		privateKey, publicKeyFile := writePackagePublicKey(t, tmpDir)
+		m, err := newPackageManager(filepath.Join(tmpDir, packagesDirName), publicKeyFile, install, zap.NewNop())
+		require.NoError(t, err)
+		require.NoError(t, m.CreatePackage("", protobufs.PackageType_PackageType_TopLevel))
+		return m, privateKey
+	}
+
+	t.Run("Valid package is installed", func(t *testing.T) {
+		var installed []byte
+		var stagedPath string
+		m, privateKey := newManager(t, func(_ context.Context, path string) error {
+			stagedPath = path
+			var err error
+			installed, err = os.ReadFile(path)
+			return err
+		})
+
+		err := m.UpdateContent(t.Context(), "", bytes.NewReader(content), sum[:], ed25519.Sign(privateKey, sum[:]))
+		require.NoError(t, err)
+		assert.Equal(t, content, installed)
+		assert.NoFileExists(t, stagedPath)
+
+		contentHash, err := m.FileContentHash("")
+		require.NoError(t, err)
+		assert.Equal(t, sum[:], contentHash)
+	})
+
+	t.Run("Content hash mismatch", func(t *testing.T) {
+		m, privateKey := newManager(t, func(context.Context, string) error {
+			return errors.New("should not be installed")
+		})
+
+		other := sha256.Sum256([]byte("other collector"))
+		err := m.UpdateContent(t.Context(), "", bytes.NewReader(content), other[:], ed25519.Sign(privateKey, other[:]))
+		require.ErrorContains(t, err, "package content hash mismatch")
+	})
+
+	t.Run("Invalid signature", func(t *testing.T) {
+		m, _ := newManager(t, func(context.Context, string) error {
+			return errors.New("should not be installed")
+		})
+		_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		err = m.UpdateContent(t.Context(), "", bytes.NewReader(content), sum[:], ed25519.Sign(otherKey, sum[:]))
+		require.ErrorContains(t, err, "package signature verification failed")
+
+		err = m.UpdateContent(t.Context(), "", bytes.NewReader(content), sum[:], nil)
+		require.ErrorContains(t, err, "package is not signed")
+	})
+
+	t.Run("Rolled back package is not installed again", func(t *testing.T) {
+		var installs atomic.Int32
+		m, privateKey := newManager(t, func(context.Context, string) error {
+			installs.Add(1)
+			return errAgentPackageRolledBack
+		})
+
+		signature := ed25519.Sign(privateKey, sum[:])
+		err := m.UpdateContent(t.Context(), "", bytes.NewReader(content), sum[:], signature)
+		require.ErrorIs(t, err, errAgentPackageRolledBack)
+
+		err = m.UpdateContent(t.Context(), "", bytes.NewReader(content), sum[:], signature)
+		require.ErrorContains(t, err, "was previously rolled back")
+		assert.Equal(t, int32(1), installs.Load())
+
+		contentHash, err := m.FileContentHash("")
+		require.NoError(t, err)
+		assert.Nil(t, contentHash)
+	})
+}
+
+func TestSupervisor_installAgentPackage(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping test on Windows because the fake agents are shell scripts.")
+	}
+
+	const (
+		runningAgent = "#!/bin/sh\nexec sleep 30\n"
+		failingAgent = "#!/bin/sh\nexit 1\n"
+	)
+
+	newSupervisor := func(t *testing.T, configMapIsEmpty bool) *Supervisor {
+		tmpDir := t.TempDir()
+		executable := filepath.Join(tmpDir, "otelcol")
+		require.NoError(t, os.WriteFile(executable, []byte(runningAgent), 0o700))
+		storageDir := filepath.Join(tmpDir, "storage")
+		require.NoError(t, os.MkdirAll(filepath.Join(storageDir, packagesDirName), 0o700))
+
+		agentCfg := config.Agent{
+			Executable:       executable,
+			BootstrapTimeout: 100 * time.Millisecond,
+		}
+		cmd, err := commander.NewCommander(zap.NewNop(), storageDir, agentCfg)
+		require.NoError(t, err)
+
+		s := &Supervisor{
+			telemetrySettings: newNopTelemetrySettings(),
+			config: config.Supervisor{
+				Agent:   agentCfg,
+				Storage: config.Storage{Directory: storageDir},
+			},
+			commander:      cmd,
+			cfgState:       &atomic.Value{},
+			agentReadyChan: make(chan struct{}, 1),
+		}
+		s.runCtx, s.runCtxCancel = context.WithCancel(t.Context())
+		s.cfgState.Store(&configState{mergedConfig: "receivers:\n", configMapIsEmpty: configMapIsEmpty})
+		t.Cleanup(func() {
+			require.NoError(t, s.commander.Stop(s.runCtx))
+			s.runCtxCancel()
+		})
+		return s
+	}
+
+	stage := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "staged")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("Failing agent is rolled back", func(t *testing.T) {
+		s := newSupervisor(t, false)
+		require.NoError(t, s.commander.Start(s.runCtx))
+
+		err := s.installAgentPackage(stage(t, failingAgent))
+		require.ErrorIs(t, err, errAgentPackageRolledBack)
+
+		by, err := os.ReadFile(s.config.Agent.Executable)
+		require.NoError(t, err)
+		assert.Equal(t, runningAgent, string(by))
+		assert.True(t, s.commander.IsRunning())
+	})
+
+	t.Run("Unhealthy agent is rolled back", func(t *testing.T) {
+		s := newSupervisor(t, false)
+		require.NoError(t, s.commander.Start(s.runCtx))
+
+		// The updated agent becomes ready, but reports an error before its health is checked
+		updatedAgent := runningAgent + "# updated\n"
+		reported := make(chan struct{})
+		go func() {
+			defer close(reported)
+			assert.Eventually(t, func() bool {
+				by, err := os.ReadFile(s.config.Agent.Executable)
+				return err == nil && string(by) == updatedAgent && s.commander.IsRunning()
+			}, 5*time.Second, 5*time.Millisecond)
+			s.lastHealthFromClient.Store(&protobufs.ComponentHealth{Healthy: false, LastError: "exporter failed"})
+			s.markAgentReady()
+		}()
+		s.config.Agent.BootstrapTimeout = 2 * time.Second
+
+		err := s.installAgentPackage(stage(t, updatedAgent))
+		<-reported
+		require.ErrorIs(t, err, errAgentPackageRolledBack)
+		require.ErrorContains(t, err, "agent is unhealthy: exporter failed")
+
+		by, err := os.ReadFile(s.config.Agent.Executable)
+		require.NoError(t, err)
+		assert.Equal(t, runningAgent, string(by))
+		assert.True(t, s.commander.IsRunning())
+	})
+
+	t.Run("Healthy agent is kept", func(t *testing.T) {
+		s := newSupervisor(t, false)
+		require.NoError(t, s.commander.Start(s.runCtx))
+
+		updatedAgent := runningAgent + "# updated\n"
+		go func() {
+			assert.Eventually(t, func() bool {
+				by, err := os.ReadFile(s.config.Agent.Executable)
+				return err == nil && string(by) == updatedAgent && s.commander.IsRunning()
+			}, 5*time.Second, 5*time.Millisecond)
+			s.lastHealthFromClient.Store(&protobufs.ComponentHealth{Healthy: true})
+			s.markAgentReady()
+		}()
+		s.config.Agent.BootstrapTimeout = 2 * time.Second
+
+		require.NoError(t, s.installAgentPackage(stage(t, updatedAgent)))
+
+		by, err := os.ReadFile(s.config.Agent.Executable)
+		require.NoError(t, err)
+		assert.Equal(t, updatedAgent, string(by))
+		assert.True(t, s.commander.IsRunning())
+	})
+
+	t.Run("Executable is replaced without config", func(t *testing.T) {
+		s := newSupervisor(t, true)
+
+		require.NoError(t, s.installAgentPackage(stage(t, failingAgent)))
+
+		by, err := os.ReadFile(s.config.Agent.Executable)
+		require.NoError(t, err)
+		assert.Equal(t, failingAgent, string(by))
+		info, err := os.Stat(s.config.Agent.Executable)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+		assert.False(t, s.commander.IsRunning())
+
+		backup, err := os.ReadFile(filepath.Join(s.packagesDirPath(), agentBackupFileName))
+		require.NoError(t, err)
+		assert.Equal(t, runningAgent, string(backup))
+	})
+}
+
+func TestSupervisor_processPackagesAvailableMessage(t *testing.T) {
+	s := &Supervisor{telemetrySettings: newNopTelemetrySettings(), runCtx: t.Context()}
+	syncer := &fakePackagesSyncer{}
+
+	// Packages are ignored when they are not accepted
+	s.processPackagesAvailableMessage(syncer)
+	assert.False(t, syncer.synced)
+
+	s.packageManager = &packageManager{}
+	s.processPackagesAvailableMessage(syncer)
+	assert.True(t, syncer.synced)
+}
+
+type fakePackagesSyncer struct {
+	synced bool
+}
+
+func (f *fakePackagesSyncer) Sync(context.Context) error {
+	f.synced = true
+	return nil
+}
+
+func (*fakePackagesSyncer) Done() <-chan struct{} {
+	return nil
+}
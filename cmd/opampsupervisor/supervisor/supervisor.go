@@ -71,6 +71,8 @@ var (
 
 	lastRecvRemoteConfigFile       = "last_recv_remote_config.dat"
 	lastRecvOwnTelemetryConfigFile = "last_recv_own_telemetry_config.dat"
+	lastGoodRemoteConfigFile       = "last_good_remote_config.dat"
+	failedRemoteConfigStatusFile   = "failed_remote_config_status.dat"
 
 	errNonMatchingInstanceUID = errors.New("received collector instance UID does not match expected UID set by the supervisor")
 )
@@ -101,6 +103,13 @@ var (
 	agentNotStarting agentStartStatus = "notStarting"
 )
 
+// packageInstall is a request to install the Collector executable staged at stagedPath, sent to
+// the agent process loop.
+type packageInstall struct {
+	stagedPath string
+	done       chan error
+}
+
 type telemetrySettings struct {
 	component.TelemetrySettings
 	loggerProvider log.LoggerProvider
@@ -145,9 +154,21 @@ type Supervisor struct {
 	// Final effective config of the Collector.
 	effectiveConfig *atomic.Value
 
+	// remoteConfigMu guards remoteConfig, lastGoodRemoteConfig and failedRemoteConfigStatus, which are
+	// updated both when a remote config is received and when the agent process loop reverts it.
+	remoteConfigMu sync.Mutex
+
 	// Last received remote config.
 	remoteConfig *protobufs.AgentRemoteConfig
 
+	// Last remote config the agent became healthy with, which is reverted to when a new remote
+	// config makes the agent unhealthy.
+	lastGoodRemoteConfig *protobufs.AgentRemoteConfig
+
+	// Status of the last remote config that was reverted, which is reported again instead of
+	// applying the config when the server offers it again.
+	failedRemoteConfigStatus *protobufs.RemoteConfigStatus
+
 	// A channel to indicate there is a new config to apply.
 	hasNewConfig chan struct{}
 	// configApplyTimeout is the maximum time to wait for the agent to apply a new config.
@@ -156,6 +177,11 @@ type Supervisor struct {
 	// lastHealthFromClient is the last health status of the agent received from the client.
 	lastHealthFromClient atomic.Pointer[protobufs.ComponentHealth]
 
+	// packageManager syncs the packages offered by the server. It is nil when packages are not accepted.
+	packageManager *packageManager
+	// A channel to request the agent process loop to install a new Collector executable.
+	packageInstalls chan packageInstall
+
 	// The OpAMP client to connect to the OpAMP Server.
 	opampClient client.OpAMPClient
 
@@ -196,6 +222,7 @@ func NewSupervisor(ctx context.Context, logger *zap.Logger, cfg config.Superviso
 	s := &Supervisor{
 		pidProvider:                    defaultPIDProvider{},
 		hasNewConfig:                   make(chan struct{}, 1),
+		packageInstalls:                make(chan packageInstall),
 		agentConfigOwnTelemetrySection: &atomic.Value{},
 		cfgState:                       &atomic.Value{},
 		effectiveConfig:                &atomic.Value{},
@@ -240,6 +267,18 @@ func NewSupervisor(ctx context.Context, logger *zap.Logger, cfg config.Superviso
 
 	s.configApplyTimeout = s.config.Agent.ConfigApplyTimeout
 
+	if s.config.Capabilities.AcceptsPackages {
+		s.packageManager, err = newPackageManager(
+			s.packagesDirPath(),
+			s.config.Packages.PublicKeyFile,
+			s.requestPackageInstall,
+			s.telemetrySettings.Logger,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return s, nil
 }
 
@@ -718,6 +757,11 @@ func (s *Supervisor) startOpAMPClient() error {
 		return err
 	}
 
+	// The provider is only set when packages are accepted, the OpAMP client rejects it otherwise
+	if s.packageManager != nil {
+		settings.PackagesStateProvider = s.packageManager
+	}
+
 	// Set heartbeat interval if the agent supports it
 	if s.config.Capabilities.ReportsHeartbeat {
 		d := time.Duration(s.heartbeatIntervalSeconds) * time.Second
@@ -1282,6 +1326,12 @@ func (s *Supervisor) loadAndWriteInitialMergedConfig() error {
 	// load the last received remote config
 	s.loadRemoteConfig()
 
+	// load the last remote config the agent was healthy with
+	s.loadLastGoodRemoteConfig()
+
+	// load the status of the last reverted remote config
+	s.loadFailedRemoteConfigStatus()
+
 	// load the last received own telemetry config
 	s.loadLastReceivedOwnTelemetryConfig()
 
@@ -1327,6 +1377,52 @@ func (s *Supervisor) loadRemoteConfig() {
 	}
 }
 
+// loadLastGoodRemoteConfig loads the last remote config the agent was healthy with, if reverting
+// unhealthy configs is enabled.
+func (s *Supervisor) loadLastGoodRemoteConfig() {
+	if !s.config.Capabilities.AcceptsRemoteConfig || !s.config.Agent.RevertUnhealthyConfig {
+		return
+	}
+
+	lastGoodRemoteConfig, err := os.ReadFile(filepath.Join(s.config.Storage.Directory, lastGoodRemoteConfigFile))
+	switch {
+	case err == nil:
+		config := &protobufs.AgentRemoteConfig{}
+		if err = proto.Unmarshal(lastGoodRemoteConfig, config); err != nil {
+			s.telemetrySettings.Logger.Error("Cannot parse last good remote config", zap.Error(err))
+		} else {
+			s.lastGoodRemoteConfig = config
+		}
+	case errors.Is(err, os.ErrNotExist):
+		s.telemetrySettings.Logger.Debug("No last good remote config found")
+	default:
+		s.telemetrySettings.Logger.Error("error while reading last good config", zap.Error(err))
+	}
+}
+
+// loadFailedRemoteConfigStatus loads the status of the last reverted remote config, if reverting
+// unhealthy configs is enabled.
+func (s *Supervisor) loadFailedRemoteConfigStatus() {
+	if !s.config.Capabilities.AcceptsRemoteConfig || !s.config.Agent.RevertUnhealthyConfig {
+		return
+	}
+
+	failedRemoteConfigStatus, err := os.ReadFile(filepath.Join(s.config.Storage.Directory, failedRemoteConfigStatusFile))
+	switch {
+	case err == nil:
+		status := &protobufs.RemoteConfigStatus{}
+		if err = proto.Unmarshal(failedRemoteConfigStatus, status); err != nil {
+			s.telemetrySettings.Logger.Error("Cannot parse failed remote config status", zap.Error(err))
+		} else {
+			s.failedRemoteConfigStatus = status
+		}
+	case errors.Is(err, os.ErrNotExist):
+		s.telemetrySettings.Logger.Debug("No failed remote config status found")
+	default:
+		s.telemetrySettings.Logger.Error("error while reading failed remote config status", zap.Error(err))
+	}
+}
+
 // loadLastReceivedOwnTelemetryConfig loads the last received own telemetry config from file if the capability is supported.
 func (s *Supervisor) loadLastReceivedOwnTelemetryConfig() {
 	// If none of the own telemetry capabilities are supported, do nothing.
@@ -1406,7 +1502,9 @@ func (s *Supervisor) setupOwnTelemetry(_ context.Context, settings *protobufs.Co
 	s.agentConfigOwnTelemetrySection.Store(cfg.String())
 
 	// Need to recalculate the Agent config so that the metric config is included in it.
+	s.remoteConfigMu.Lock()
 	configChanged, err := s.composeMergedConfig(s.remoteConfig)
+	s.remoteConfigMu.Unlock()
 	if err != nil {
 		s.telemetrySettings.Logger.Error("Error composing merged config for own metrics. Ignoring agent self metrics config", zap.Error(err))
 		return configChanged
@@ -1526,9 +1624,16 @@ func (s *Supervisor) runAgentProcess() {
 	configApplyTimeoutTimer := time.NewTimer(0)
 	configApplyTimeoutTimer.Stop()
 
+	// Hash of the remote config being applied, the config apply timeout is ignored if a newer remote
+	// config was received since, as it is about to be applied.
+	var applyingConfigHash []byte
+
 	for {
 		select {
 		case <-s.hasNewConfig:
+			s.remoteConfigMu.Lock()
+			applyingConfigHash = s.remoteConfig.GetConfigHash()
+			s.remoteConfigMu.Unlock()
 			s.lastHealthFromClient.Store(nil)
 			s.telemetrySettings.Logger.Debug("agent has new config", zap.String("previous_health", s.lastHealthFromClient.Load().String()))
 			if !configApplyTimeoutTimer.Stop() {
@@ -1564,7 +1669,7 @@ func (s *Supervisor) runAgentProcess() {
 				// not starting agent because of nop config: clear timer, report applied status, report healthy status
 				s.telemetrySettings.Logger.Debug("No config present, nothing to apply")
 				configApplyTimeoutTimer.Stop()
-				s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, "")
+				s.reportRemoteConfigApplied(applyingConfigHash)
 				if err := s.opampClient.SetHealth(&protobufs.ComponentHealth{Healthy: true, LastError: ""}); err != nil {
 					s.telemetrySettings.Logger.Error("Could not report healthy status to OpAMP server", zap.Error(err))
 				}
@@ -1614,12 +1719,19 @@ func (s *Supervisor) runAgentProcess() {
 				s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, err.Error())
 			}
 
+		case req := <-s.packageInstalls:
+			req.done <- s.installAgentPackage(req.stagedPath)
+
 		case <-configApplyTimeoutTimer.C:
 			lastHealth := s.lastHealthFromClient.Load()
 			if lastHealth == nil || !lastHealth.Healthy {
-				s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, "Config apply timeout exceeded")
+				errMsg := "Config apply timeout exceeded"
+				if lastHealth.GetLastError() != "" {
+					errMsg = fmt.Sprintf("%s: %s", errMsg, lastHealth.GetLastError())
+				}
+				s.reportRemoteConfigFailed(applyingConfigHash, errMsg)
 			} else {
-				s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, "")
+				s.reportRemoteConfigApplied(applyingConfigHash)
 			}
 
 		case <-s.doneChan:
@@ -1632,6 +1744,215 @@ func (s *Supervisor) runAgentProcess() {
 	}
 }
 
+// requestPackageInstall asks the agent process loop to install the Collector executable staged at
+// stagedPath, and waits for the installation to complete.
+func (s *Supervisor) requestPackageInstall(ctx context.Context, stagedPath string) error {
+	req := packageInstall{stagedPath: stagedPath, done: make(chan error, 1)}
+	select {
+	case s.packageInstalls <- req:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.doneChan:
+		return errors.New("supervisor is shutting down")
+	}
+	return <-req.done
+}
+
+// installAgentPackage replaces the Collector executable with the staged one and restarts the
+// Collector. The previous executable is saved beforehand, and restored if the new Collector
+// doesn't become healthy within the bootstrap timeout.
+func (s *Supervisor) installAgentPackage(stagedPath string) error {
+	executable := s.config.Agent.Executable
+	info, err := os.Stat(executable)
+	if err != nil {
+		return fmt.Errorf("could not stat the agent executable: %w", err)
+	}
+	perm := info.Mode().Perm()
+
+	backupPath := filepath.Join(s.packagesDirPath(), agentBackupFileName)
+	if err = replaceFile(executable, backupPath, perm); err != nil {
+		return fmt.Errorf("could not back up the agent executable: %w", err)
+	}
+
+	if err = s.commander.Stop(s.runCtx); err != nil {
+		return fmt.Errorf("could not stop the agent: %w", err)
+	}
+
+	if err = replaceFile(stagedPath, executable, perm); err != nil {
+		if _, startErr := s.startAgent(); startErr != nil {
+			s.telemetrySettings.Logger.Error("Could not restart the agent", zap.Error(startErr))
+		}
+		return fmt.Errorf("could not replace the agent executable: %w", err)
+	}
+
+	// The agent is not run without a config, its executable is only replaced.
+	if s.cfgState.Load().(*configState).configMapIsEmpty {
+		s.telemetrySettings.Logger.Info("Agent executable updated")
+		return nil
+	}
+
+	err = s.startUpdatedAgent()
+	if err == nil {
+		s.telemetrySettings.Logger.Info("Agent executable updated and restarted")
+		return nil
+	}
+
+	s.telemetrySettings.Logger.Error("The updated agent did not become healthy, rolling back", zap.Error(err))
+	if stopErr := s.commander.Stop(s.runCtx); stopErr != nil {
+		s.telemetrySettings.Logger.Error("Could not stop the updated agent", zap.Error(stopErr))
+	}
+	if restoreErr := replaceFile(backupPath, executable, perm); restoreErr != nil {
+		return fmt.Errorf("could not restore the agent executable after a failed update (%w): %w", err, restoreErr)
+	}
+	if startErr := s.startUpdatedAgent(); startErr != nil {
+		s.telemetrySettings.Logger.Error("The restored agent did not become healthy", zap.Error(startErr))
+	}
+	return fmt.Errorf("%w: %w", errAgentPackageRolledBack, err)
+}
+
+// startUpdatedAgent starts the agent and waits for it to report a healthy status within the
+// bootstrap timeout. The agent is considered unhealthy if it reported an error since.
+func (s *Supervisor) startUpdatedAgent() error {
+	s.resetAgentReady()
+	// The health reported by the previous agent doesn't tell anything about the updated one.
+	s.lastHealthFromClient.Store(nil)
+	if err := s.commander.Start(s.runCtx); err != nil {
+		return err
+	}
+	if err := s.waitForAgentReady(); err != nil {
+		return err
+	}
+	if health := s.lastHealthFromClient.Load(); !health.GetHealthy() {
+		return fmt.Errorf("agent is unhealthy: %s", health.GetLastError())
+	}
+	return nil
+}
+
+// reportRemoteConfigApplied reports the remote config with the given hash as applied, and remembers it
+// as the last good one. Nothing is reported if a newer remote config was received since.
+func (s *Supervisor) reportRemoteConfigApplied(hash []byte) {
+	s.remoteConfigMu.Lock()
+	defer s.remoteConfigMu.Unlock()
+
+	if !bytes.Equal(s.remoteConfig.GetConfigHash(), hash) {
+		s.telemetrySettings.Logger.Debug("Newer remote config received, not reporting the applied one")
+		return
+	}
+	s.saveAndReportConfigStatusLocked(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, "")
+	s.saveLastGoodRemoteConfig()
+}
+
+// reportRemoteConfigFailed reports the remote config with the given hash as failed, and reverts it if
+// reverting unhealthy configs is enabled. Nothing is reported if a newer remote config was received
+// since. The lock is held during the revert, so that a remote config received meanwhile is composed
+// on top of the reverted one, and then applied.
+func (s *Supervisor) reportRemoteConfigFailed(hash []byte, errMsg string) {
+	s.remoteConfigMu.Lock()
+	defer s.remoteConfigMu.Unlock()
+
+	if !bytes.Equal(s.remoteConfig.GetConfigHash(), hash) {
+		s.telemetrySettings.Logger.Debug("Newer remote config received, not reporting the failed one")
+		return
+	}
+	rcs := &protobufs.RemoteConfigStatus{
+		LastRemoteConfigHash: hash,
+		Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
+		ErrorMessage:         errMsg,
+	}
+	s.saveAndReportRemoteConfigStatus(rcs)
+	if s.config.Agent.RevertUnhealthyConfig {
+		s.saveFailedRemoteConfigStatus(rcs)
+		s.revertToLastGoodRemoteConfig()
+	}
+}
+
+// saveLastGoodRemoteConfig remembers the current remote config as the last one the agent was
+// healthy with, if reverting unhealthy configs is enabled. It must be called with remoteConfigMu held.
+func (s *Supervisor) saveLastGoodRemoteConfig() {
+	if !s.config.Agent.RevertUnhealthyConfig || s.remoteConfig == nil {
+		return
+	}
+
+	s.lastGoodRemoteConfig = s.remoteConfig
+	cfg, err := proto.Marshal(s.remoteConfig)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(s.config.Storage.Directory, lastGoodRemoteConfigFile), cfg, 0o600)
+	}
+	if err != nil {
+		s.telemetrySettings.Logger.Error("Could not save last good remote config", zap.Error(err))
+	}
+}
+
+// saveFailedRemoteConfigStatus remembers the status of a remote config that made the agent
+// unhealthy, so that the config is not applied again while the server keeps offering it.
+// It must be called with remoteConfigMu held.
+func (s *Supervisor) saveFailedRemoteConfigStatus(rcs *protobufs.RemoteConfigStatus) {
+	s.failedRemoteConfigStatus = rcs
+	status, err := proto.Marshal(rcs)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(s.config.Storage.Directory, failedRemoteConfigStatusFile), status, 0o600)
+	}
+	if err != nil {
+		s.telemetrySettings.Logger.Error("Could not save failed remote config status", zap.Error(err))
+	}
+}
+
+// clearFailedRemoteConfigStatus forgets the status of the last reverted remote config, once the
+// server offers a different one. It must be called with remoteConfigMu held.
+func (s *Supervisor) clearFailedRemoteConfigStatus() {
+	if s.failedRemoteConfigStatus == nil {
+		return
+	}
+
+	s.failedRemoteConfigStatus = nil
+	err := os.Remove(filepath.Join(s.config.Storage.Directory, failedRemoteConfigStatusFile))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		s.telemetrySettings.Logger.Error("Could not remove failed remote config status", zap.Error(err))
+	}
+}
+
+// revertToLastGoodRemoteConfig restarts the agent with the last remote config it was healthy
+// with. The failed status of the reverted config is kept, so that the server knows why it is
+// not running. It must be called with remoteConfigMu held.
+func (s *Supervisor) revertToLastGoodRemoteConfig() {
+	lastGood := s.lastGoodRemoteConfig
+	if lastGood == nil || bytes.Equal(lastGood.GetConfigHash(), s.remoteConfig.GetConfigHash()) {
+		s.telemetrySettings.Logger.Warn("No last good remote config to revert to")
+		return
+	}
+
+	s.telemetrySettings.Logger.Warn("Reverting to the last good remote config",
+		zap.String("failed_hash", fmt.Sprintf("%x", s.remoteConfig.GetConfigHash())),
+		zap.String("hash", fmt.Sprintf("%x", lastGood.GetConfigHash())))
+
+	// The reverted config is also the one to start with after a restart of the Supervisor.
+	s.remoteConfig = lastGood
+	if err := s.saveLastReceivedConfig(lastGood); err != nil {
+		s.telemetrySettings.Logger.Error("Could not save last received remote config", zap.Error(err))
+	}
+
+	if _, err := s.composeMergedConfig(lastGood); err != nil {
+		s.telemetrySettings.Logger.Error("Error composing merged config with the last good remote config", zap.Error(err))
+		return
+	}
+	if err := s.opampClient.UpdateEffectiveConfig(s.runCtx); err != nil {
+		s.telemetrySettings.Logger.Error("The OpAMP client failed to update the effective config", zap.Error(err))
+	}
+
+	if s.config.Agent.UseHUPConfigReload {
+		if err := s.hupReloadAgent(); err != nil {
+			s.telemetrySettings.Logger.Error("Failed to HUP restart agent", zap.Error(err))
+			return
+		}
+	} else {
+		s.stopAgentApplyConfig()
+	}
+
+	if _, err := s.startAgent(); err != nil {
+		s.telemetrySettings.Logger.Error("starting agent with the last good remote config failed", zap.Error(err))
+	}
+}
+
 // markAgentReady marks the agent as ready and sends a signal to
 // [agentReadyChan].
 func (s *Supervisor) markAgentReady() {
@@ -1652,7 +1973,7 @@ func (s *Supervisor) resetAgentReady() {
 }
 
 // waitForAgentReady waits for the agent to be ready. The agent is considered to
-// be ready when its first health report is received by the Supervisor's opamp
+// be ready when its first healthy report is received by the Supervisor's opamp
 // server.
 // WARNING: this is not thread-safe! If there are two goroutines waiting for
 // the agent to be ready, only one of them will be able to proceed.
@@ -1856,13 +2177,23 @@ func (s *Supervisor) saveLastReceivedOwnTelemetrySettings(set *protobufs.Connect
 
 // saveAndReportConfigStatus saves the config status to the persistent state and reports it to the server.
 func (s *Supervisor) saveAndReportConfigStatus(status protobufs.RemoteConfigStatuses, errorMessage string) {
-	if !s.config.Capabilities.ReportsRemoteConfig {
-		s.telemetrySettings.Logger.Debug("supervisor is not configured to report remote config status")
-	}
-	rcs := &protobufs.RemoteConfigStatus{
+	s.remoteConfigMu.Lock()
+	defer s.remoteConfigMu.Unlock()
+	s.saveAndReportConfigStatusLocked(status, errorMessage)
+}
+
+// saveAndReportConfigStatusLocked is saveAndReportConfigStatus, called with remoteConfigMu held.
+func (s *Supervisor) saveAndReportConfigStatusLocked(status protobufs.RemoteConfigStatuses, errorMessage string) {
+	s.saveAndReportRemoteConfigStatus(&protobufs.RemoteConfigStatus{
 		LastRemoteConfigHash: s.remoteConfig.GetConfigHash(),
 		Status:               status,
 		ErrorMessage:         errorMessage,
+	})
+}
+
+func (s *Supervisor) saveAndReportRemoteConfigStatus(rcs *protobufs.RemoteConfigStatus) {
+	if !s.config.Capabilities.ReportsRemoteConfig {
+		s.telemetrySettings.Logger.Debug("supervisor is not configured to report remote config status")
 	}
 
 	// save status to persistent state
@@ -1927,6 +2258,10 @@ func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
 		}
 	}
 
+	if msg.PackageSyncer != nil {
+		s.processPackagesAvailableMessage(msg.PackageSyncer)
+	}
+
 	messageToAgent := &protobufs.ServerToAgent{
 		InstanceUid: s.persistentState.InstanceID[:],
 	}
@@ -1966,6 +2301,20 @@ func (s *Supervisor) processRemoteConfigMessage(ctx context.Context, msg *protob
 		return false
 	}
 
+	s.remoteConfigMu.Lock()
+	defer s.remoteConfigMu.Unlock()
+
+	if failed := s.failedRemoteConfigStatus; failed != nil {
+		if len(msg.GetConfigHash()) > 0 && bytes.Equal(failed.GetLastRemoteConfigHash(), msg.GetConfigHash()) {
+			// The config was reverted because the agent was unhealthy with it, so its failure is reported again
+			s.telemetrySettings.Logger.Warn("Got remote config that was reverted before. Ignoring remote config.",
+				zap.String("hash", fmt.Sprintf("%x", msg.GetConfigHash())))
+			s.saveAndReportRemoteConfigStatus(failed)
+			return false
+		}
+		s.clearFailedRemoteConfigStatus()
+	}
+
 	if err := s.saveLastReceivedConfig(msg); err != nil {
 		span.SetStatus(codes.Error, fmt.Sprintf("Could not save last received remote config: %s", err.Error()))
 		s.telemetrySettings.Logger.Error("Could not save last received remote config", zap.Error(err))
@@ -1979,21 +2328,34 @@ func (s *Supervisor) processRemoteConfigMessage(ctx context.Context, msg *protob
 	if err != nil {
 		span.SetStatus(codes.Error, fmt.Sprintf("Error composing merged config. Reporting failed remote config status: %s", err.Error()))
 		s.telemetrySettings.Logger.Error("Error composing merged config. Reporting failed remote config status.", zap.Error(err))
-		s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, err.Error())
+		s.saveAndReportConfigStatusLocked(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, err.Error())
 		return false
 	}
 	if configChanged {
 		// only report applying if the config has changed and will run agent with new config
-		s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING, "")
+		s.saveAndReportConfigStatusLocked(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING, "")
 	} else {
 		// if the config has not changed report applied status, we should still report a status to the server in this case
-		s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, "")
+		s.saveAndReportConfigStatusLocked(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, "")
 	}
 
 	span.SetStatus(codes.Ok, "")
 	return configChanged
 }
 
+// processPackagesAvailableMessage starts syncing the packages offered by the server. The sync runs in the
+// background, and the package statuses are reported by the OpAMP client as it progresses.
+func (s *Supervisor) processPackagesAvailableMessage(syncer types.PackagesSyncer) {
+	if s.packageManager == nil {
+		s.telemetrySettings.Logger.Warn("Got packages available message, but the agent does not accept packages. Ignoring packages.")
+		return
+	}
+
+	if err := syncer.Sync(s.runCtx); err != nil {
+		s.telemetrySettings.Logger.Error("Could not sync the packages offered by the server", zap.Error(err))
+	}
+}
+
 // processOwnTelemetryConnSettingsMessage processes a TelemetryConnectionSettings message, returning true if the agent config has changed.
 func (s *Supervisor) processOwnTelemetryConnSettingsMessage(ctx context.Context, msg *protobufs.ConnectionSettingsOffers) bool {
 	if err := s.saveLastReceivedOwnTelemetrySettings(msg, lastRecvOwnTelemetryConfigFile); err != nil {
@@ -2025,7 +2387,9 @@ func (s *Supervisor) processAgentIdentificationMessage(msg *protobufs.AgentIdent
 	}
 
 	// Need to recalculate the Agent config so that the new agent identification is included in it.
+	s.remoteConfigMu.Lock()
 	configChanged, err := s.composeMergedConfig(s.remoteConfig)
+	s.remoteConfigMu.Unlock()
 	if err != nil {
 		s.telemetrySettings.Logger.Error("Error composing merged config with new instance ID", zap.Error(err))
 		return false
@@ -2042,6 +2406,10 @@ func (s *Supervisor) agentConfigFilePath() string {
 	return filepath.Join(s.config.Storage.Directory, agentConfigFileName)
 }
 
+func (s *Supervisor) packagesDirPath() string {
+	return filepath.Join(s.config.Storage.Directory, packagesDirName)
+}
+
 func (s *Supervisor) getSupervisorOpAMPServerPort() (int, error) {
 	if s.config.Agent.OpAMPServerPort != 0 {
 		return s.config.Agent.OpAMPServerPort, nil
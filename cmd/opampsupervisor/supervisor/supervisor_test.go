@@ -16,6 +16,7 @@ import (
 	"regexp"
 	"runtime"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -38,6 +39,7 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/commander"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/config"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/telemetry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/testbed/testbed"
@@ -2219,3 +2221,249 @@ func TestSupervisor_HealthCheckServer(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestSupervisor_revertToLastGoodRemoteConfig(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping test on Windows because the fake agent is a shell script.")
+	}
+
+	newRemoteConfig := func(name string) *protobufs.AgentRemoteConfig {
+		return &protobufs.AgentRemoteConfig{
+			Config: &protobufs.AgentConfigMap{
+				ConfigMap: map[string]*protobufs.AgentConfigFile{
+					"": {Body: []byte("receivers:\n  debug/" + name + ":\n")},
+				},
+			},
+			ConfigHash: []byte(name),
+		}
+	}
+
+	newSupervisor := func(t *testing.T) (*Supervisor, chan *protobufs.RemoteConfigStatus) {
+		tmpDir := t.TempDir()
+		executable := filepath.Join(tmpDir, "otelcol")
+		require.NoError(t, os.WriteFile(executable, []byte("#!/bin/sh\nexec sleep 30\n"), 0o700))
+
+		agentCfg := config.Agent{
+			Executable:            executable,
+			ConfigApplyTimeout:    200 * time.Millisecond,
+			RevertUnhealthyConfig: true,
+		}
+		cmd, err := commander.NewCommander(zap.NewNop(), tmpDir, agentCfg)
+		require.NoError(t, err)
+
+		agentDesc := &atomic.Value{}
+		agentDesc.Store(&protobufs.AgentDescription{})
+		var effectiveConfigUpdates atomic.Int32
+		remoteConfigStatuses := make(chan *protobufs.RemoteConfigStatus, 10)
+		s := &Supervisor{
+			telemetrySettings: newNopTelemetrySettings(),
+			config: config.Supervisor{
+				Capabilities: config.Capabilities{
+					AcceptsRemoteConfig: true,
+				},
+				Agent:   agentCfg,
+				Storage: config.Storage{Directory: tmpDir},
+			},
+			commander:                      cmd,
+			agentDescription:               agentDesc,
+			agentConfigOwnTelemetrySection: &atomic.Value{},
+			cfgState:                       &atomic.Value{},
+			persistentState: &persistentState{
+				InstanceID: uuid.New(),
+				configPath: filepath.Join(tmpDir, persistentStateFileName),
+			},
+			pidProvider:  staticPIDProvider(1234),
+			hasNewConfig: make(chan struct{}, 1),
+			doneChan:     make(chan struct{}),
+			opampClient: &mockOpAMPClient{
+				updateEffectiveConfigFunc: func(context.Context) error {
+					effectiveConfigUpdates.Add(1)
+					return nil
+				},
+				setRemoteConfigStatusFunc: func(rcs *protobufs.RemoteConfigStatus) error {
+					remoteConfigStatuses <- rcs
+					return nil
+				},
+			},
+		}
+		s.runCtx, s.runCtxCancel = context.WithCancel(t.Context())
+		require.NoError(t, s.createTemplates())
+		t.Cleanup(func() {
+			require.NoError(t, s.commander.Stop(s.runCtx))
+			s.runCtxCancel()
+		})
+		return s, remoteConfigStatuses
+	}
+
+	t.Run("Agent is restarted with the last good config", func(t *testing.T) {
+		s, _ := newSupervisor(t)
+
+		good := newRemoteConfig("good")
+		s.remoteConfig = good
+		s.saveLastGoodRemoteConfig()
+
+		bad := newRemoteConfig("bad")
+		s.remoteConfig = bad
+		_, err := s.composeMergedConfig(bad)
+		require.NoError(t, err)
+		require.NoError(t, s.commander.Start(s.runCtx))
+
+		s.revertToLastGoodRemoteConfig()
+
+		assert.Equal(t, good.String(), s.remoteConfig.String())
+		assert.Contains(t, s.cfgState.Load().(*configState).mergedConfig, "debug/good")
+		assert.True(t, s.commander.IsRunning())
+
+		// The last good config is also the one loaded after a restart of the Supervisor
+		s.remoteConfig = nil
+		s.lastGoodRemoteConfig = nil
+		s.loadRemoteConfig()
+		s.loadLastGoodRemoteConfig()
+		assert.Equal(t, good.String(), s.remoteConfig.String())
+		assert.Equal(t, good.String(), s.lastGoodRemoteConfig.String())
+	})
+
+	t.Run("Nothing is reverted without a last good config", func(t *testing.T) {
+		s, _ := newSupervisor(t)
+
+		bad := newRemoteConfig("bad")
+		s.remoteConfig = bad
+		_, err := s.composeMergedConfig(bad)
+		require.NoError(t, err)
+
+		s.revertToLastGoodRemoteConfig()
+
+		assert.Equal(t, bad.String(), s.remoteConfig.String())
+		assert.Contains(t, s.cfgState.Load().(*configState).mergedConfig, "debug/bad")
+		assert.False(t, s.commander.IsRunning())
+	})
+
+	t.Run("Unhealthy config is reverted when the config apply timeout is exceeded", func(t *testing.T) {
+		s, remoteConfigStatuses := newSupervisor(t)
+
+		good := newRemoteConfig("good")
+		s.remoteConfig = good
+		s.saveLastGoodRemoteConfig()
+		_, err := s.composeMergedConfig(good)
+		require.NoError(t, err)
+
+		agentDone := make(chan struct{})
+		go func() {
+			defer close(agentDone)
+			s.runAgentProcess()
+		}()
+
+		bad := newRemoteConfig("bad")
+		require.True(t, s.processRemoteConfigMessage(t.Context(), bad))
+		applying := <-remoteConfigStatuses
+		assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING, applying.Status)
+		s.hasNewConfig <- struct{}{}
+
+		// The agent reports that it is unhealthy once it runs with the bad config
+		require.Eventually(t, func() bool {
+			cfg, err := os.ReadFile(s.agentConfigFilePath())
+			return err == nil && strings.Contains(string(cfg), "debug/bad") && s.commander.IsRunning()
+		}, 5*time.Second, 10*time.Millisecond)
+		s.lastHealthFromClient.Store(&protobufs.ComponentHealth{Healthy: false, LastError: "receiver failed to start"})
+
+		select {
+		case failed := <-remoteConfigStatuses:
+			assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, failed.Status)
+			assert.Equal(t, []byte("bad"), failed.LastRemoteConfigHash)
+			assert.Equal(t, "Config apply timeout exceeded: receiver failed to start", failed.ErrorMessage)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "the failed remote config status was not reported")
+		}
+
+		require.Eventually(t, func() bool {
+			cfg, err := os.ReadFile(s.agentConfigFilePath())
+			return err == nil && strings.Contains(string(cfg), "debug/good") && s.commander.IsRunning()
+		}, 5*time.Second, 10*time.Millisecond)
+
+		close(s.doneChan)
+		<-agentDone
+		assert.Equal(t, good.String(), s.remoteConfig.String())
+
+		// The reverted config is not applied again when the server offers it again, even after a restart of the Supervisor
+		s.failedRemoteConfigStatus = nil
+		s.loadFailedRemoteConfigStatus()
+		assert.False(t, s.processRemoteConfigMessage(t.Context(), bad))
+		failedAgain := <-remoteConfigStatuses
+		assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, failedAgain.Status)
+		assert.Equal(t, []byte("bad"), failedAgain.LastRemoteConfigHash)
+		assert.Equal(t, "Config apply timeout exceeded: receiver failed to start", failedAgain.ErrorMessage)
+		assert.Equal(t, good.String(), s.remoteConfig.String())
+		assert.Contains(t, s.cfgState.Load().(*configState).mergedConfig, "debug/good")
+
+		// A different config is applied
+		assert.True(t, s.processRemoteConfigMessage(t.Context(), newRemoteConfig("fixed")))
+		assert.Nil(t, s.failedRemoteConfigStatus)
+		assert.NoFileExists(t, filepath.Join(s.config.Storage.Directory, failedRemoteConfigStatusFile))
+	})
+
+	t.Run("Remote config received during a revert is applied", func(t *testing.T) {
+		s, _ := newSupervisor(t)
+
+		good := newRemoteConfig("good")
+		s.remoteConfig = good
+		s.saveLastGoodRemoteConfig()
+		_, err := s.composeMergedConfig(good)
+		require.NoError(t, err)
+
+		// The newer config is received by the OpAMP client once the bad config is reported as failed, before it is reverted
+		newer := newRemoteConfig("newer")
+		received := make(chan struct{})
+		var reverting sync.Once
+		s.opampClient.(*mockOpAMPClient).setRemoteConfigStatusFunc = func(rcs *protobufs.RemoteConfigStatus) error {
+			if rcs.Status != protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED {
+				return nil
+			}
+			reverting.Do(func() {
+				// The newer config must not time out during the test
+				s.config.Agent.ConfigApplyTimeout = time.Hour
+				go func() {
+					defer close(received)
+					if s.processRemoteConfigMessage(t.Context(), newer) {
+						s.hasNewConfig <- struct{}{}
+					}
+				}()
+				time.Sleep(50 * time.Millisecond)
+			})
+			return nil
+		}
+
+		agentDone := make(chan struct{})
+		go func() {
+			defer close(agentDone)
+			s.runAgentProcess()
+		}()
+		defer func() {
+			close(s.doneChan)
+			<-agentDone
+		}()
+
+		bad := newRemoteConfig("bad")
+		require.True(t, s.processRemoteConfigMessage(t.Context(), bad))
+		s.hasNewConfig <- struct{}{}
+		require.Eventually(t, func() bool {
+			cfg, err := os.ReadFile(s.agentConfigFilePath())
+			return err == nil && strings.Contains(string(cfg), "debug/bad") && s.commander.IsRunning()
+		}, 5*time.Second, 10*time.Millisecond)
+		s.lastHealthFromClient.Store(&protobufs.ComponentHealth{Healthy: false, LastError: "receiver failed to start"})
+
+		select {
+		case <-received:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "the newer remote config was not received")
+		}
+		require.Eventually(t, func() bool {
+			cfg, err := os.ReadFile(s.agentConfigFilePath())
+			return err == nil && strings.Contains(string(cfg), "debug/newer") && s.commander.IsRunning()
+		}, 5*time.Second, 10*time.Millisecond)
+
+		s.remoteConfigMu.Lock()
+		defer s.remoteConfigMu.Unlock()
+		assert.Equal(t, newer.String(), s.remoteConfig.String())
+		assert.Contains(t, s.cfgState.Load().(*configState).mergedConfig, "debug/newer")
+	})
+}
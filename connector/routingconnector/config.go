@@ -64,7 +64,7 @@ func (c *Config) Validate() error {
 		}
 
 		switch item.Context {
-		case "", "resource", "span", "metric", "datapoint", "log": // ok
+		case "", "resource", "span", "metric", "datapoint", "log", "profile": // ok
 		case "request":
 			if item.Statement != "" || item.Condition == "" {
 				return fmt.Errorf("%q context requires a 'condition'", item.Context)
@@ -81,7 +81,7 @@ func (c *Config) Validate() error {
 
 // RoutingTableItem specifies how data should be routed to the different pipelines
 type RoutingTableItem struct {
-	// One of "request", "resource", "log", "span", "metric", "datapoint".
+	// One of "request", "resource", "log", "span", "metric", "datapoint", "profile".
 	// Optional. Default "resource".
 	Context string `mapstructure:"context"`
 
@@ -10,20 +10,23 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/xconnector"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
 // NewFactory returns a ConnectorFactory.
-func NewFactory() connector.Factory {
-	return connector.NewFactory(
+func NewFactory() xconnector.Factory {
+	return xconnector.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
-		connector.WithTracesToTraces(createTracesToTraces, metadata.TracesToTracesStability),
-		connector.WithMetricsToMetrics(createMetricsToMetrics, metadata.MetricsToMetricsStability),
-		connector.WithLogsToLogs(createLogsToLogs, metadata.LogsToLogsStability),
+		xconnector.WithTracesToTraces(createTracesToTraces, metadata.TracesToTracesStability),
+		xconnector.WithMetricsToMetrics(createMetricsToMetrics, metadata.MetricsToMetricsStability),
+		xconnector.WithLogsToLogs(createLogsToLogs, metadata.LogsToLogsStability),
+		xconnector.WithProfilesToProfiles(createProfilesToProfiles, metadata.ProfilesToProfilesStability),
 	)
 }
 
@@ -63,3 +66,13 @@ func createLogsToLogs(
 ) (connector.Logs, error) {
 	return newLogsConnector(set, cfg, logs)
 }
+
+// createProfilesToProfiles creates a profiles to profiles connector based on provided config.
+func createProfilesToProfiles(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	profiles xconsumer.Profiles,
+) (xconnector.Profiles, error) {
+	return newProfilesConnector(set, cfg, profiles)
+}
@@ -13,10 +13,14 @@ require (
 	go.opentelemetry.io/collector/confmap/xconfmap v0.140.0
 	go.opentelemetry.io/collector/connector v0.140.0
 	go.opentelemetry.io/collector/connector/connectortest v0.140.0
+	go.opentelemetry.io/collector/connector/xconnector v0.140.0
 	go.opentelemetry.io/collector/consumer v1.46.0
 	go.opentelemetry.io/collector/consumer/consumertest v0.140.0
+	go.opentelemetry.io/collector/consumer/xconsumer v0.140.0
 	go.opentelemetry.io/collector/pdata v1.46.0
+	go.opentelemetry.io/collector/pdata/pprofile v0.140.0
 	go.opentelemetry.io/collector/pipeline v1.46.0
+	go.opentelemetry.io/collector/pipeline/xpipeline v0.140.0
 	go.uber.org/goleak v1.3.0
 	go.uber.org/zap v1.27.0
 	google.golang.org/grpc v1.77.0
@@ -57,12 +61,8 @@ require (
 	github.com/ua-parser/uap-go v0.0.0-20240611065828-3a4781585db6 // indirect
 	github.com/zeebo/xxh3 v1.0.2 // indirect
 	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
-	go.opentelemetry.io/collector/connector/xconnector v0.140.0 // indirect
-	go.opentelemetry.io/collector/consumer/xconsumer v0.140.0 // indirect
 	go.opentelemetry.io/collector/featuregate v1.46.0 // indirect
 	go.opentelemetry.io/collector/internal/fanoutconsumer v0.140.0 // indirect
-	go.opentelemetry.io/collector/pdata/pprofile v0.140.0 // indirect
-	go.opentelemetry.io/collector/pipeline/xpipeline v0.140.0 // indirect
 	go.opentelemetry.io/otel v1.38.0 // indirect
 	go.opentelemetry.io/otel/metric v1.38.0 // indirect
 	go.opentelemetry.io/otel/sdk v1.38.0 // indirect
@@ -12,7 +12,8 @@ var (
 )
 
 const (
-	TracesToTracesStability   = component.StabilityLevelAlpha
-	MetricsToMetricsStability = component.StabilityLevelAlpha
-	LogsToLogsStability       = component.StabilityLevelAlpha
+	ProfilesToProfilesStability = component.StabilityLevelDevelopment
+	TracesToTracesStability     = component.StabilityLevelAlpha
+	MetricsToMetricsStability   = component.StabilityLevelAlpha
+	LogsToLogsStability         = component.StabilityLevelAlpha
 )
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofileutil // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/pprofileutil"
+
+import "go.opentelemetry.io/collector/pdata/pprofile"
+
+// MoveResourcesIf calls f sequentially for each ResourceProfiles present in the first pprofile.Profiles.
+// If f returns true, the element is removed from the first pprofile.Profiles and added to the second pprofile.Profiles.
+// The moved elements keep referring to the dictionary of the first pprofile.Profiles, so the second
+// pprofile.Profiles is expected to hold a copy of it.
+func MoveResourcesIf(from, to pprofile.Profiles, f func(pprofile.ResourceProfiles) bool) {
+	from.ResourceProfiles().RemoveIf(func(rp pprofile.ResourceProfiles) bool {
+		if !f(rp) {
+			return false
+		}
+		rp.MoveTo(to.ResourceProfiles().AppendEmpty())
+		return true
+	})
+}
+
+// MoveProfilesWithContextIf calls f sequentially for each Profile present in the first pprofile.Profiles.
+// If f returns true, the element is removed from the first pprofile.Profiles and added to the second pprofile.Profiles.
+// Notably, the Resource and Scope associated with the Profile are created in the second pprofile.Profiles only once.
+// Resources or Scopes are removed from the original if they become empty. All ordering is preserved.
+// The moved elements keep referring to the dictionary of the first pprofile.Profiles, so the second
+// pprofile.Profiles is expected to hold a copy of it.
+func MoveProfilesWithContextIf(from, to pprofile.Profiles, f func(pprofile.ResourceProfiles, pprofile.ScopeProfiles, pprofile.Profile) bool) {
+	rps := from.ResourceProfiles()
+	rps.RemoveIf(func(rp pprofile.ResourceProfiles) bool {
+		sps := rp.ScopeProfiles()
+		var rpCopy *pprofile.ResourceProfiles
+		sps.RemoveIf(func(sp pprofile.ScopeProfiles) bool {
+			ps := sp.Profiles()
+			var spCopy *pprofile.ScopeProfiles
+			ps.RemoveIf(func(p pprofile.Profile) bool {
+				if !f(rp, sp, p) {
+					return false
+				}
+				if rpCopy == nil {
+					rpc := to.ResourceProfiles().AppendEmpty()
+					rpCopy = &rpc
+					rp.Resource().CopyTo(rpCopy.Resource())
+					rpCopy.SetSchemaUrl(rp.SchemaUrl())
+				}
+				if spCopy == nil {
+					spc := rpCopy.ScopeProfiles().AppendEmpty()
+					spCopy = &spc
+					sp.Scope().CopyTo(spCopy.Scope())
+					spCopy.SetSchemaUrl(sp.SchemaUrl())
+				}
+				p.MoveTo(spCopy.Profiles().AppendEmpty())
+				return true
+			})
+			return sp.Profiles().Len() == 0
+		})
+		return rp.ScopeProfiles().Len() == 0
+	})
+}
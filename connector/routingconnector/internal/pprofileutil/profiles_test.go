@@ -0,0 +1,248 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofileutil_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/pprofileutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/pprofileutiltest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pprofiletest"
+)
+
+func TestMoveResourcesIf(t *testing.T) {
+	testCases := []struct {
+		from       pprofile.Profiles
+		to         pprofile.Profiles
+		expectFrom pprofile.Profiles
+		expectTo   pprofile.Profiles
+		moveIf     func(pprofile.ResourceProfiles) bool
+		name       string
+	}{
+		{
+			name: "move_none",
+			moveIf: func(pprofile.ResourceProfiles) bool {
+				return false
+			},
+			from:       pprofileutiltest.NewProfiles("AB", "CD", "EF"),
+			to:         pprofileutiltest.NewEmptyProfiles(),
+			expectFrom: pprofileutiltest.NewProfiles("AB", "CD", "EF"),
+			expectTo:   pprofileutiltest.NewEmptyProfiles(),
+		},
+		{
+			name: "move_all",
+			moveIf: func(pprofile.ResourceProfiles) bool {
+				return true
+			},
+			from:       pprofileutiltest.NewProfiles("AB", "CD", "EF"),
+			to:         pprofileutiltest.NewEmptyProfiles(),
+			expectFrom: pprofileutiltest.NewEmptyProfiles(),
+			expectTo:   pprofileutiltest.NewProfiles("AB", "CD", "EF"),
+		},
+		{
+			name: "move_one",
+			moveIf: func(rp pprofile.ResourceProfiles) bool {
+				rname, ok := rp.Resource().Attributes().Get("resourceName")
+				return ok && rname.AsString() == "resourceA"
+			},
+			from:       pprofileutiltest.NewProfiles("AB", "CD", "EF"),
+			to:         pprofileutiltest.NewEmptyProfiles(),
+			expectFrom: pprofileutiltest.NewProfiles("B", "CD", "EF"),
+			expectTo:   pprofileutiltest.NewProfiles("A", "CD", "EF"),
+		},
+		{
+			name: "move_to_preexisting",
+			moveIf: func(rp pprofile.ResourceProfiles) bool {
+				rname, ok := rp.Resource().Attributes().Get("resourceName")
+				return ok && rname.AsString() == "resourceB"
+			},
+			from:       pprofileutiltest.NewProfiles("AB", "CD", "EF"),
+			to:         pprofileutiltest.NewProfiles("1", "2", "3"),
+			expectFrom: pprofileutiltest.NewProfiles("A", "CD", "EF"),
+			expectTo: pprofileutiltest.NewProfilesFromOpts(
+				pprofileutiltest.Resource("1",
+					pprofileutiltest.Scope("2", pprofileutiltest.Profile("3")),
+				),
+				pprofileutiltest.Resource("B",
+					pprofileutiltest.Scope("C", pprofileutiltest.Profile("E"), pprofileutiltest.Profile("F")),
+					pprofileutiltest.Scope("D", pprofileutiltest.Profile("E"), pprofileutiltest.Profile("F")),
+				),
+			),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			pprofileutil.MoveResourcesIf(tt.from, tt.to, tt.moveIf)
+			assert.NoError(t, pprofiletest.CompareProfiles(tt.expectFrom, tt.from), "from not modified as expected")
+			assert.NoError(t, pprofiletest.CompareProfiles(tt.expectTo, tt.to), "to not as expected")
+		})
+	}
+}
+
+func TestMoveProfilesWithContextIf(t *testing.T) {
+	testCases := []struct {
+		from       pprofile.Profiles
+		to         pprofile.Profiles
+		expectFrom pprofile.Profiles
+		expectTo   pprofile.Profiles
+		moveIf     func(pprofile.ResourceProfiles, pprofile.ScopeProfiles, pprofile.Profile) bool
+		name       string
+	}{
+		{
+			name: "move_none",
+			moveIf: func(pprofile.ResourceProfiles, pprofile.ScopeProfiles, pprofile.Profile) bool {
+				return false
+			},
+			from:       pprofileutiltest.NewProfiles("AB", "CD", "EF"),
+			to:         pprofileutiltest.NewEmptyProfiles(),
+			expectFrom: pprofileutiltest.NewProfiles("AB", "CD", "EF"),
+			expectTo:   pprofileutiltest.NewEmptyProfiles(),
+		},
+		{
+			name: "move_all",
+			moveIf: func(pprofile.ResourceProfiles, pprofile.ScopeProfiles, pprofile.Profile) bool {
+				return true
+			},
+			from:       pprofileutiltest.NewProfiles("AB", "CD", "EF"),
+			to:         pprofileutiltest.NewEmptyProfiles(),
+			expectFrom: pprofileutiltest.NewEmptyProfiles(),
+			expectTo:   pprofileutiltest.NewProfiles("AB", "CD", "EF"),
+		},
+		{
+			name: "move_all_from_one_resource",
+			moveIf: func(rp pprofile.ResourceProfiles, _ pprofile.ScopeProfiles, _ pprofile.Profile) bool {
+				rname, ok := rp.Resource().Attributes().Get("resourceName")
+				return ok && rname.AsString() == "resourceB"
+			},
+			from:       pprofileutiltest.NewProfiles("AB", "CD", "EF"),
+			to:         pprofileutiltest.NewEmptyProfiles(),
+			expectFrom: pprofileutiltest.NewProfiles("A", "CD", "EF"),
+			expectTo:   pprofileutiltest.NewProfiles("B", "CD", "EF"),
+		},
+		{
+			name: "move_all_from_one_scope",
+			moveIf: func(rp pprofile.ResourceProfiles, sp pprofile.ScopeProfiles, _ pprofile.Profile) bool {
+				rname, ok := rp.Resource().Attributes().Get("resourceName")
+				return ok && rname.AsString() == "resourceB" && sp.Scope().Name() == "scopeC"
+			},
+			from: pprofileutiltest.NewProfiles("AB", "CD", "EF"),
+			to:   pprofileutiltest.NewEmptyProfiles(),
+			expectFrom: pprofileutiltest.NewProfilesFromOpts(
+				pprofileutiltest.Resource("A",
+					pprofileutiltest.Scope("C", pprofileutiltest.Profile("E"), pprofileutiltest.Profile("F")),
+					pprofileutiltest.Scope("D", pprofileutiltest.Profile("E"), pprofileutiltest.Profile("F")),
+				),
+				pprofileutiltest.Resource("B",
+					pprofileutiltest.Scope("D", pprofileutiltest.Profile("E"), pprofileutiltest.Profile("F")),
+				),
+			),
+			expectTo: pprofileutiltest.NewProfiles("B", "C", "EF"),
+		},
+		{
+			name: "move_all_from_one_scope_in_each_resource",
+			moveIf: func(_ pprofile.ResourceProfiles, sp pprofile.ScopeProfiles, _ pprofile.Profile) bool {
+				return sp.Scope().Name() == "scopeD"
+			},
+			from:       pprofileutiltest.NewProfiles("AB", "CD", "EF"),
+			to:         pprofileutiltest.NewEmptyProfiles(),
+			expectFrom: pprofileutiltest.NewProfiles("AB", "C", "EF"),
+			expectTo:   pprofileutiltest.NewProfiles("AB", "D", "EF"),
+		},
+		{
+			name: "move_one",
+			moveIf: func(rp pprofile.ResourceProfiles, sp pprofile.ScopeProfiles, p pprofile.Profile) bool {
+				rname, ok := rp.Resource().Attributes().Get("resourceName")
+				return ok && rname.AsString() == "resourceA" && sp.Scope().Name() == "scopeD" && p.ProfileID() == pprofile.ProfileID{'F'}
+			},
+			from: pprofileutiltest.NewProfiles("AB", "CD", "EF"),
+			to:   pprofileutiltest.NewEmptyProfiles(),
+			expectFrom: pprofileutiltest.NewProfilesFromOpts(
+				pprofileutiltest.Resource("A",
+					pprofileutiltest.Scope("C", pprofileutiltest.Profile("E"), pprofileutiltest.Profile("F")),
+					pprofileutiltest.Scope("D", pprofileutiltest.Profile("E")),
+				),
+				pprofileutiltest.Resource("B",
+					pprofileutiltest.Scope("C", pprofileutiltest.Profile("E"), pprofileutiltest.Profile("F")),
+					pprofileutiltest.Scope("D", pprofileutiltest.Profile("E"), pprofileutiltest.Profile("F")),
+				),
+			),
+			expectTo: pprofileutiltest.NewProfiles("A", "D", "F"),
+		},
+		{
+			name: "move_one_from_each_scope",
+			moveIf: func(_ pprofile.ResourceProfiles, _ pprofile.ScopeProfiles, p pprofile.Profile) bool {
+				return p.ProfileID() == pprofile.ProfileID{'E'}
+			},
+			from:       pprofileutiltest.NewProfiles("AB", "CD", "EF"),
+			to:         pprofileutiltest.NewEmptyProfiles(),
+			expectFrom: pprofileutiltest.NewProfiles("AB", "CD", "F"),
+			expectTo:   pprofileutiltest.NewProfiles("AB", "CD", "E"),
+		},
+		{
+			name: "move_one_from_each_scope_in_one_resource",
+			moveIf: func(rp pprofile.ResourceProfiles, _ pprofile.ScopeProfiles, p pprofile.Profile) bool {
+				rname, ok := rp.Resource().Attributes().Get("resourceName")
+				return ok && rname.AsString() == "resourceB" && p.ProfileID() == pprofile.ProfileID{'E'}
+			},
+			from: pprofileutiltest.NewProfiles("AB", "CD", "EF"),
+			to:   pprofileutiltest.NewEmptyProfiles(),
+			expectFrom: pprofileutiltest.NewProfilesFromOpts(
+				pprofileutiltest.Resource("A",
+					pprofileutiltest.Scope("C", pprofileutiltest.Profile("E"), pprofileutiltest.Profile("F")),
+					pprofileutiltest.Scope("D", pprofileutiltest.Profile("E"), pprofileutiltest.Profile("F")),
+				),
+				pprofileutiltest.Resource("B",
+					pprofileutiltest.Scope("C", pprofileutiltest.Profile("F")),
+					pprofileutiltest.Scope("D", pprofileutiltest.Profile("F")),
+				),
+			),
+			expectTo: pprofileutiltest.NewProfiles("B", "CD", "E"),
+		},
+		{
+			name: "move_some_to_preexisting",
+			moveIf: func(_ pprofile.ResourceProfiles, sp pprofile.ScopeProfiles, _ pprofile.Profile) bool {
+				return sp.Scope().Name() == "scopeD"
+			},
+			from:       pprofileutiltest.NewProfiles("AB", "CD", "EF"),
+			to:         pprofileutiltest.NewProfiles("1", "2", "3"),
+			expectFrom: pprofileutiltest.NewProfiles("AB", "C", "EF"),
+			expectTo: pprofileutiltest.NewProfilesFromOpts(
+				pprofileutiltest.Resource("1",
+					pprofileutiltest.Scope("2", pprofileutiltest.Profile("3")),
+				),
+				pprofileutiltest.Resource("A",
+					pprofileutiltest.Scope("D", pprofileutiltest.Profile("E"), pprofileutiltest.Profile("F")),
+				),
+				pprofileutiltest.Resource("B",
+					pprofileutiltest.Scope("D", pprofileutiltest.Profile("E"), pprofileutiltest.Profile("F")),
+				),
+			),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			pprofileutil.MoveProfilesWithContextIf(tt.from, tt.to, tt.moveIf)
+			assert.NoError(t, pprofiletest.CompareProfiles(tt.expectFrom, tt.from), "from not modified as expected")
+			assert.NoError(t, pprofiletest.CompareProfiles(tt.expectTo, tt.to), "to not as expected")
+		})
+	}
+}
+
+func BenchmarkMoveResourcesIfProfiles(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		from := pprofileutiltest.NewProfiles("AB", "CD", "EF")
+		to := pprofileutiltest.NewEmptyProfiles()
+		pprofileutil.MoveResourcesIf(from, to, func(pprofile.ResourceProfiles) bool {
+			return true
+		})
+		assert.Equal(b, 0, from.ResourceProfiles().Len())
+		assert.Equal(b, 2, to.ResourceProfiles().Len())
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofileutiltest // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/pprofileutiltest"
+
+import "go.opentelemetry.io/collector/pdata/pprofile"
+
+// NewProfiles returns a pprofile.Profiles with a uniform structure where resources, scopes, and
+// profiles are identical across all instances, except for one identifying field.
+//
+// Identifying fields:
+// - Resources have an attribute called "resourceName" with a value of "resourceN".
+// - Scopes have a name with a value of "scopeN".
+// - Profiles have a profile ID whose first byte is N.
+//
+// Example: NewProfiles("AB", "XYZ", "1234") returns:
+//
+//	resourceA, resourceB
+//	    each with scopeX, scopeY, scopeZ
+//	        each with profile1, profile2, profile3, profile4
+//
+// Each byte in the input string is a unique ID for the corresponding element.
+// The dictionary only holds the empty string, which every dictionary must hold.
+func NewProfiles(resourceIDs, scopeIDs, profileIDs string) pprofile.Profiles {
+	pd := NewEmptyProfiles()
+	for resourceN := 0; resourceN < len(resourceIDs); resourceN++ {
+		rp := pd.ResourceProfiles().AppendEmpty()
+		rp.Resource().Attributes().PutStr("resourceName", "resource"+string(resourceIDs[resourceN]))
+		for scopeN := 0; scopeN < len(scopeIDs); scopeN++ {
+			sp := rp.ScopeProfiles().AppendEmpty()
+			sp.Scope().SetName("scope" + string(scopeIDs[scopeN]))
+			for profileN := 0; profileN < len(profileIDs); profileN++ {
+				p := sp.Profiles().AppendEmpty()
+				p.SetProfileID(pprofile.ProfileID{profileIDs[profileN]})
+			}
+		}
+	}
+	return pd
+}
+
+// NewEmptyProfiles returns a pprofile.Profiles without any resource, with a valid dictionary.
+func NewEmptyProfiles() pprofile.Profiles {
+	pd := pprofile.NewProfiles()
+	pd.Dictionary().StringTable().Append("")
+	return pd
+}
+
+func NewProfilesFromOpts(resources ...pprofile.ResourceProfiles) pprofile.Profiles {
+	pd := NewEmptyProfiles()
+	for _, resource := range resources {
+		resource.CopyTo(pd.ResourceProfiles().AppendEmpty())
+	}
+	return pd
+}
+
+func Resource(id string, scopes ...pprofile.ScopeProfiles) pprofile.ResourceProfiles {
+	rp := pprofile.NewResourceProfiles()
+	rp.Resource().Attributes().PutStr("resourceName", "resource"+id)
+	for _, scope := range scopes {
+		scope.CopyTo(rp.ScopeProfiles().AppendEmpty())
+	}
+	return rp
+}
+
+func Scope(id string, profiles ...pprofile.Profile) pprofile.ScopeProfiles {
+	s := pprofile.NewScopeProfiles()
+	s.Scope().SetName("scope" + id)
+	for _, profile := range profiles {
+		profile.CopyTo(s.Profiles().AppendEmpty())
+	}
+	return s
+}
+
+func Profile(id string) pprofile.Profile {
+	p := pprofile.NewProfile()
+	p.SetProfileID(pprofile.ProfileID{id[0]})
+	return p
+}
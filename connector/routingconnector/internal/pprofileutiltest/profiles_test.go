@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofileutiltest_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/pprofileutiltest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pprofiletest"
+)
+
+func TestNewProfiles(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		expected := pprofileutiltest.NewEmptyProfiles()
+		assert.NoError(t, pprofiletest.CompareProfiles(expected, pprofileutiltest.NewProfiles("", "", "")))
+		assert.NoError(t, pprofiletest.CompareProfiles(expected, pprofileutiltest.NewProfilesFromOpts()))
+	})
+
+	t.Run("simple", func(t *testing.T) {
+		expected := func() pprofile.Profiles {
+			pd := pprofileutiltest.NewEmptyProfiles()
+			r := pd.ResourceProfiles().AppendEmpty()
+			r.Resource().Attributes().PutStr("resourceName", "resourceA") // resourceA
+			s := r.ScopeProfiles().AppendEmpty()
+			s.Scope().SetName("scopeB") // resourceA.scopeB
+			p := s.Profiles().AppendEmpty()
+			p.SetProfileID(pprofile.ProfileID{'C'}) // resourceA.scopeB.profileC
+			return pd
+		}()
+		assert.NoError(t, pprofiletest.CompareProfiles(expected, pprofileutiltest.NewProfiles("A", "B", "C")))
+		assert.NoError(t, pprofiletest.CompareProfiles(expected, pprofileutiltest.NewProfilesFromOpts(
+			pprofileutiltest.Resource("A", pprofileutiltest.Scope("B", pprofileutiltest.Profile("C"))),
+		)))
+	})
+
+	t.Run("two_resources", func(t *testing.T) {
+		expected := func() pprofile.Profiles {
+			pd := pprofileutiltest.NewEmptyProfiles()
+			r := pd.ResourceProfiles().AppendEmpty()
+			r.Resource().Attributes().PutStr("resourceName", "resourceA") // resourceA
+			s := r.ScopeProfiles().AppendEmpty()
+			s.Scope().SetName("scopeC") // resourceA.scopeC
+			p := s.Profiles().AppendEmpty()
+			p.SetProfileID(pprofile.ProfileID{'D'}) // resourceA.scopeC.profileD
+			r = pd.ResourceProfiles().AppendEmpty()
+			r.Resource().Attributes().PutStr("resourceName", "resourceB") // resourceB
+			s = r.ScopeProfiles().AppendEmpty()
+			s.Scope().SetName("scopeC") // resourceB.scopeC
+			p = s.Profiles().AppendEmpty()
+			p.SetProfileID(pprofile.ProfileID{'D'}) // resourceB.scopeC.profileD
+			return pd
+		}()
+		assert.NoError(t, pprofiletest.CompareProfiles(expected, pprofileutiltest.NewProfiles("AB", "C", "D")))
+		assert.NoError(t, pprofiletest.CompareProfiles(expected, pprofileutiltest.NewProfilesFromOpts(
+			pprofileutiltest.Resource("A", pprofileutiltest.Scope("C", pprofileutiltest.Profile("D"))),
+			pprofileutiltest.Resource("B", pprofileutiltest.Scope("C", pprofileutiltest.Profile("D"))),
+		)))
+	})
+
+	t.Run("two_scopes", func(t *testing.T) {
+		expected := func() pprofile.Profiles {
+			pd := pprofileutiltest.NewEmptyProfiles()
+			r := pd.ResourceProfiles().AppendEmpty()
+			r.Resource().Attributes().PutStr("resourceName", "resourceA") // resourceA
+			s := r.ScopeProfiles().AppendEmpty()
+			s.Scope().SetName("scopeB") // resourceA.scopeB
+			p := s.Profiles().AppendEmpty()
+			p.SetProfileID(pprofile.ProfileID{'D'}) // resourceA.scopeB.profileD
+			s = r.ScopeProfiles().AppendEmpty()
+			s.Scope().SetName("scopeC") // resourceA.scopeC
+			p = s.Profiles().AppendEmpty()
+			p.SetProfileID(pprofile.ProfileID{'D'}) // resourceA.scopeC.profileD
+			return pd
+		}()
+		assert.NoError(t, pprofiletest.CompareProfiles(expected, pprofileutiltest.NewProfiles("A", "BC", "D")))
+		assert.NoError(t, pprofiletest.CompareProfiles(expected, pprofileutiltest.NewProfilesFromOpts(
+			pprofileutiltest.Resource("A",
+				pprofileutiltest.Scope("B", pprofileutiltest.Profile("D")),
+				pprofileutiltest.Scope("C", pprofileutiltest.Profile("D")),
+			),
+		)))
+	})
+
+	t.Run("two_records", func(t *testing.T) {
+		expected := func() pprofile.Profiles {
+			pd := pprofileutiltest.NewEmptyProfiles()
+			r := pd.ResourceProfiles().AppendEmpty()
+			r.Resource().Attributes().PutStr("resourceName", "resourceA") // resourceA
+			s := r.ScopeProfiles().AppendEmpty()
+			s.Scope().SetName("scopeB") // resourceA.scopeB
+			p := s.Profiles().AppendEmpty()
+			p.SetProfileID(pprofile.ProfileID{'C'}) // resourceA.scopeB.profileC
+			p = s.Profiles().AppendEmpty()
+			p.SetProfileID(pprofile.ProfileID{'D'}) // resourceA.scopeB.profileD
+			return pd
+		}()
+		assert.NoError(t, pprofiletest.CompareProfiles(expected, pprofileutiltest.NewProfiles("A", "B", "CD")))
+		assert.NoError(t, pprofiletest.CompareProfiles(expected, pprofileutiltest.NewProfilesFromOpts(
+			pprofileutiltest.Resource("A", pprofileutiltest.Scope("B", pprofileutiltest.Profile("C"), pprofileutiltest.Profile("D"))),
+		)))
+	})
+
+	t.Run("asymmetrical_scopes", func(t *testing.T) {
+		expected := func() pprofile.Profiles {
+			pd := pprofileutiltest.NewEmptyProfiles()
+			r := pd.ResourceProfiles().AppendEmpty()
+			r.Resource().Attributes().PutStr("resourceName", "resourceA") // resourceA
+			s := r.ScopeProfiles().AppendEmpty()
+			s.Scope().SetName("scopeC") // resourceA.scopeC
+			p := s.Profiles().AppendEmpty()
+			p.SetProfileID(pprofile.ProfileID{'E'}) // resourceA.scopeC.profileE
+			s = r.ScopeProfiles().AppendEmpty()
+			s.Scope().SetName("scopeD") // resourceA.scopeD
+			p = s.Profiles().AppendEmpty()
+			p.SetProfileID(pprofile.ProfileID{'E'}) // resourceA.scopeD.profileE
+			r = pd.ResourceProfiles().AppendEmpty()
+			r.Resource().Attributes().PutStr("resourceName", "resourceB") // resourceB
+			s = r.ScopeProfiles().AppendEmpty()
+			s.Scope().SetName("scopeD") // resourceB.scopeD
+			p = s.Profiles().AppendEmpty()
+			p.SetProfileID(pprofile.ProfileID{'F'}) // resourceB.scopeD.profileF
+			p = s.Profiles().AppendEmpty()
+			p.SetProfileID(pprofile.ProfileID{'G'}) // resourceB.scopeD.profileG
+			return pd
+		}()
+		assert.NoError(t, pprofiletest.CompareProfiles(expected, pprofileutiltest.NewProfilesFromOpts(
+			pprofileutiltest.Resource("A",
+				pprofileutiltest.Scope("C", pprofileutiltest.Profile("E")),
+				pprofileutiltest.Scope("D", pprofileutiltest.Profile("E")),
+			),
+			pprofileutiltest.Resource("B",
+				pprofileutiltest.Scope("D", pprofileutiltest.Profile("F"), pprofileutiltest.Profile("G")),
+			),
+		)))
+	})
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/xconnector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/pprofileutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+)
+
+type profilesConnector struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	logger *zap.Logger
+	config *Config
+	router *router[xconsumer.Profiles]
+}
+
+func newProfilesConnector(
+	set connector.Settings,
+	config component.Config,
+	profiles xconsumer.Profiles,
+) (*profilesConnector, error) {
+	cfg := config.(*Config)
+	pr, ok := profiles.(xconnector.ProfilesRouterAndConsumer)
+	if !ok {
+		return nil, errUnexpectedConsumer
+	}
+
+	r, err := newRouter(
+		cfg.Table,
+		cfg.DefaultPipelines,
+		pr.Consumer,
+		set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &profilesConnector{
+		logger: set.Logger,
+		config: cfg,
+		router: r,
+	}, nil
+}
+
+func (*profilesConnector) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (c *profilesConnector) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	// the profiles refer to the dictionary by index, so every group holds a copy of it
+	dictionary := pprofile.NewProfilesDictionary()
+	pd.Dictionary().CopyTo(dictionary)
+
+	groups := make(map[xconsumer.Profiles]pprofile.Profiles)
+	matched := newProfilesWithDictionary(dictionary)
+	for i := 0; i < len(c.router.routeSlice) && pd.ResourceProfiles().Len() > 0; i++ {
+		var errs error
+		route := c.router.routeSlice[i]
+		switch route.statementContext {
+		case "request":
+			if route.requestCondition.matchRequest(ctx) {
+				// all profiles are routed
+				pprofileutil.MoveResourcesIf(pd, matched,
+					func(pprofile.ResourceProfiles) bool {
+						return true
+					},
+				)
+			}
+		case "", "resource":
+			pprofileutil.MoveResourcesIf(pd, matched,
+				func(rp pprofile.ResourceProfiles) bool {
+					rtx := ottlresource.NewTransformContext(rp.Resource(), rp)
+					_, isMatch, err := route.resourceStatement.Execute(ctx, rtx)
+					// If error during statement evaluation consider it as not a match.
+					if err != nil {
+						errs = errors.Join(errs, err)
+						return false
+					}
+					return isMatch
+				},
+			)
+		case "profile":
+			pprofileutil.MoveProfilesWithContextIf(pd, matched,
+				func(rp pprofile.ResourceProfiles, sp pprofile.ScopeProfiles, p pprofile.Profile) bool {
+					ptx := ottlprofile.NewTransformContext(p, pd.Dictionary(), sp.Scope(), rp.Resource(), sp, rp)
+					_, isMatch, err := route.profileStatement.Execute(ctx, ptx)
+					// If error during statement evaluation consider it as not a match.
+					if err != nil {
+						errs = errors.Join(errs, err)
+						return false
+					}
+					return isMatch
+				},
+			)
+		}
+		if errs != nil && c.config.ErrorMode == ottl.PropagateError {
+			return errs
+		}
+		groupAllProfiles(groups, route.consumer, matched, dictionary)
+	}
+	// anything left wasn't matched by any route. Send to default consumer
+	groupAllProfiles(groups, c.router.defaultConsumer, pd, dictionary)
+	var errs error
+	for consumer, group := range groups {
+		err := consumer.ConsumeProfiles(ctx, group)
+		if err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+	return errs
+}
+
+func groupAllProfiles(
+	groups map[xconsumer.Profiles]pprofile.Profiles,
+	cons xconsumer.Profiles,
+	profiles pprofile.Profiles,
+	dictionary pprofile.ProfilesDictionary,
+) {
+	if cons == nil {
+		return
+	}
+	if profiles.ResourceProfiles().Len() == 0 {
+		return
+	}
+	group, ok := groups[cons]
+	if !ok {
+		group = newProfilesWithDictionary(dictionary)
+		groups[cons] = group
+	}
+	profiles.ResourceProfiles().MoveAndAppendTo(group.ResourceProfiles())
+}
+
+func newProfilesWithDictionary(dictionary pprofile.ProfilesDictionary) pprofile.Profiles {
+	pd := pprofile.NewProfiles()
+	dictionary.CopyTo(pd.Dictionary())
+	return pd
+}
@@ -0,0 +1,352 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/connector/xconnector"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/pprofileutiltest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pprofiletest"
+)
+
+func TestProfilesRegisterConsumersForValidRoute(t *testing.T) {
+	profilesDefault := pipeline.NewIDWithName(xpipeline.SignalProfiles, "default")
+	profiles0 := pipeline.NewIDWithName(xpipeline.SignalProfiles, "0")
+	profiles1 := pipeline.NewIDWithName(xpipeline.SignalProfiles, "1")
+
+	cfg := &Config{
+		DefaultPipelines: []pipeline.ID{profilesDefault},
+		Table: []RoutingTableItem{
+			{
+				Statement: `route() where attributes["X-Tenant"] == "acme"`,
+				Pipelines: []pipeline.ID{profiles0},
+			},
+			{
+				Condition: `attributes["X-Tenant"] == "*"`,
+				Pipelines: []pipeline.ID{profiles0, profiles1},
+			},
+		},
+	}
+
+	require.NoError(t, cfg.Validate())
+
+	var defaultSink, sink0, sink1 consumertest.ProfilesSink
+
+	router := xconnector.NewProfilesRouter(map[pipeline.ID]xconsumer.Profiles{
+		profilesDefault: &defaultSink,
+		profiles0:       &sink0,
+		profiles1:       &sink1,
+	})
+
+	conn, err := NewFactory().CreateProfilesToProfiles(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, router.(xconsumer.Profiles))
+
+	require.NoError(t, err)
+	require.NotNil(t, conn)
+	assert.True(t, conn.Capabilities().MutatesData)
+
+	rtConn := conn.(*profilesConnector)
+	require.Same(t, &defaultSink, rtConn.router.defaultConsumer)
+
+	route, ok := rtConn.router.routes[rtConn.router.table[0].Statement]
+	assert.True(t, ok)
+	require.Same(t, &sink0, route.consumer)
+
+	route, ok = rtConn.router.routes[rtConn.router.table[1].Statement]
+	assert.True(t, ok)
+
+	routeConsumer, err := router.Consumer(profiles0, profiles1)
+	require.NoError(t, err)
+	require.Equal(t, routeConsumer, route.consumer)
+
+	require.NoError(t, conn.Start(t.Context(), componenttest.NewNopHost()))
+
+	assert.NoError(t, conn.Shutdown(t.Context()))
+}
+
+func TestProfilesAreCorrectlySplitPerResourceAttributeWithOTTL(t *testing.T) {
+	profilesDefault := pipeline.NewIDWithName(xpipeline.SignalProfiles, "default")
+	profiles0 := pipeline.NewIDWithName(xpipeline.SignalProfiles, "0")
+	profiles1 := pipeline.NewIDWithName(xpipeline.SignalProfiles, "1")
+
+	cfg := &Config{
+		DefaultPipelines: []pipeline.ID{profilesDefault},
+		Table: []RoutingTableItem{
+			{
+				Condition: `attributes["X-Tenant"] == "acme"`,
+				Pipelines: []pipeline.ID{profiles0},
+			},
+			{
+				Statement: `route() where attributes["X-Tenant"] == "ecorp"`,
+				Pipelines: []pipeline.ID{profiles0, profiles1},
+			},
+		},
+	}
+
+	var defaultSink, sink0, sink1 consumertest.ProfilesSink
+
+	router := xconnector.NewProfilesRouter(map[pipeline.ID]xconsumer.Profiles{
+		profilesDefault: &defaultSink,
+		profiles0:       &sink0,
+		profiles1:       &sink1,
+	})
+
+	resetSinks := func() {
+		defaultSink.Reset()
+		sink0.Reset()
+		sink1.Reset()
+	}
+
+	conn, err := NewFactory().CreateProfilesToProfiles(
+		t.Context(),
+		connectortest.NewNopSettings(metadata.Type),
+		cfg,
+		router.(xconsumer.Profiles),
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, conn)
+	require.NoError(t, conn.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, conn.Shutdown(t.Context()))
+	}()
+
+	newProfiles := func(tenants ...string) pprofile.Profiles {
+		pd := pprofileutiltest.NewEmptyProfiles()
+		for _, tenant := range tenants {
+			rp := pd.ResourceProfiles().AppendEmpty()
+			rp.Resource().Attributes().PutStr("X-Tenant", tenant)
+			rp.ScopeProfiles().AppendEmpty().Profiles().AppendEmpty()
+		}
+		return pd
+	}
+
+	t.Run("profiles matched by no expressions", func(t *testing.T) {
+		resetSinks()
+
+		require.NoError(t, conn.ConsumeProfiles(t.Context(), newProfiles("something-else")))
+
+		assert.Len(t, defaultSink.AllProfiles(), 1)
+		assert.Empty(t, sink0.AllProfiles())
+		assert.Empty(t, sink1.AllProfiles())
+	})
+
+	t.Run("profiles matched one expression", func(t *testing.T) {
+		resetSinks()
+
+		require.NoError(t, conn.ConsumeProfiles(t.Context(), newProfiles("acme")))
+
+		assert.Empty(t, defaultSink.AllProfiles())
+		assert.Len(t, sink0.AllProfiles(), 1)
+		assert.Empty(t, sink1.AllProfiles())
+	})
+
+	t.Run("profiles matched by two expressions with two pipelines", func(t *testing.T) {
+		resetSinks()
+
+		require.NoError(t, conn.ConsumeProfiles(t.Context(), newProfiles("ecorp")))
+
+		assert.Empty(t, defaultSink.AllProfiles())
+		assert.Len(t, sink0.AllProfiles(), 1)
+		assert.Len(t, sink1.AllProfiles(), 1)
+	})
+
+	t.Run("profiles split between routes", func(t *testing.T) {
+		resetSinks()
+
+		require.NoError(t, conn.ConsumeProfiles(t.Context(), newProfiles("acme", "something-else", "ecorp")))
+
+		// the second route fans out to its own pipelines, so the first pipeline receives two batches
+		require.Len(t, defaultSink.AllProfiles(), 1)
+		require.Len(t, sink0.AllProfiles(), 2)
+		require.Len(t, sink1.AllProfiles(), 1)
+		assert.NoError(t, pprofiletest.CompareProfiles(newProfiles("something-else"), defaultSink.AllProfiles()[0]))
+		assert.NoError(t, pprofiletest.CompareProfiles(newProfiles("ecorp"), sink1.AllProfiles()[0]))
+	})
+}
+
+func TestProfilesRoutedByProfileContext(t *testing.T) {
+	profilesDefault := pipeline.NewIDWithName(xpipeline.SignalProfiles, "default")
+	profiles0 := pipeline.NewIDWithName(xpipeline.SignalProfiles, "0")
+
+	cfg := &Config{
+		DefaultPipelines: []pipeline.ID{profilesDefault},
+		Table: []RoutingTableItem{
+			{
+				Context:   "profile",
+				Condition: `attributes["tenant"] == "acme"`,
+				Pipelines: []pipeline.ID{profiles0},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var defaultSink, sink0 consumertest.ProfilesSink
+	conn, err := NewFactory().CreateProfilesToProfiles(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, xconnector.NewProfilesRouter(map[pipeline.ID]xconsumer.Profiles{
+			profilesDefault: &defaultSink,
+			profiles0:       &sink0,
+		}))
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(t.Context(), componenttest.NewNopHost()))
+
+	pd := pprofileutiltest.NewProfiles("A", "B", "CDE")
+	profiles := pd.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles()
+	putProfileAttribute(t, pd.Dictionary(), profiles.At(0), "tenant", "acme")
+	putProfileAttribute(t, pd.Dictionary(), profiles.At(1), "tenant", "ecorp")
+	putProfileAttribute(t, pd.Dictionary(), profiles.At(2), "tenant", "acme")
+
+	require.NoError(t, conn.ConsumeProfiles(t.Context(), pd))
+	require.NoError(t, conn.Shutdown(t.Context()))
+
+	// The routed profiles keep referring to the attributes of the dictionary
+	require.Len(t, sink0.AllProfiles(), 1)
+	routed := sink0.AllProfiles()[0]
+	require.Equal(t, 1, routed.ResourceProfiles().Len())
+	routedProfiles := routed.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles()
+	require.Equal(t, 2, routedProfiles.Len())
+	for _, p := range routedProfiles.All() {
+		attrs := pprofile.FromAttributeIndices(routed.Dictionary().AttributeTable(), p, routed.Dictionary())
+		assert.Equal(t, map[string]any{"tenant": "acme"}, attrs.AsRaw())
+	}
+	assert.Equal(t, pprofile.ProfileID{'C'}, routedProfiles.At(0).ProfileID())
+	assert.Equal(t, pprofile.ProfileID{'E'}, routedProfiles.At(1).ProfileID())
+
+	require.Len(t, defaultSink.AllProfiles(), 1)
+	unmatched := defaultSink.AllProfiles()[0]
+	unmatchedProfiles := unmatched.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles()
+	require.Equal(t, 1, unmatchedProfiles.Len())
+	attrs := pprofile.FromAttributeIndices(unmatched.Dictionary().AttributeTable(), unmatchedProfiles.At(0), unmatched.Dictionary())
+	assert.Equal(t, map[string]any{"tenant": "ecorp"}, attrs.AsRaw())
+}
+
+func TestProfilesRoutedByRequestContext(t *testing.T) {
+	profilesDefault := pipeline.NewIDWithName(xpipeline.SignalProfiles, "default")
+	profiles0 := pipeline.NewIDWithName(xpipeline.SignalProfiles, "0")
+
+	cfg := &Config{
+		DefaultPipelines: []pipeline.ID{profilesDefault},
+		Table: []RoutingTableItem{
+			{
+				Context:   "request",
+				Condition: `request["X-Tenant"] == "acme"`,
+				Pipelines: []pipeline.ID{profiles0},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var defaultSink, sink0 consumertest.ProfilesSink
+	conn, err := NewFactory().CreateProfilesToProfiles(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, xconnector.NewProfilesRouter(map[pipeline.ID]xconsumer.Profiles{
+			profilesDefault: &defaultSink,
+			profiles0:       &sink0,
+		}))
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, conn.Shutdown(t.Context()))
+	}()
+
+	ctx := client.NewContext(t.Context(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"X-Tenant": {"acme"}}),
+	})
+	require.NoError(t, conn.ConsumeProfiles(ctx, pprofileutiltest.NewProfiles("AB", "C", "D")))
+	require.Len(t, sink0.AllProfiles(), 1)
+	assert.NoError(t, pprofiletest.CompareProfiles(pprofileutiltest.NewProfiles("AB", "C", "D"), sink0.AllProfiles()[0]))
+	assert.Empty(t, defaultSink.AllProfiles())
+
+	require.NoError(t, conn.ConsumeProfiles(t.Context(), pprofileutiltest.NewProfiles("AB", "C", "D")))
+	assert.Len(t, sink0.AllProfiles(), 1)
+	assert.Len(t, defaultSink.AllProfiles(), 1)
+}
+
+func TestProfilesForPropagateError(t *testing.T) {
+	profilesDefault := pipeline.NewIDWithName(xpipeline.SignalProfiles, "default")
+	profiles0 := pipeline.NewIDWithName(xpipeline.SignalProfiles, "0")
+
+	cfg := &Config{
+		ErrorMode:        ottl.PropagateError,
+		DefaultPipelines: []pipeline.ID{profilesDefault},
+		Table: []RoutingTableItem{
+			{
+				Statement: `route() where ToLowerCase(attributes["unknown"]) == "acme"`,
+				Pipelines: []pipeline.ID{profiles0},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var defaultSink, sink0 consumertest.ProfilesSink
+	conn, err := NewFactory().CreateProfilesToProfiles(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, xconnector.NewProfilesRouter(map[pipeline.ID]xconsumer.Profiles{
+			profilesDefault: &defaultSink,
+			profiles0:       &sink0,
+		}))
+	require.NoError(t, err)
+
+	require.NoError(t, conn.Start(t.Context(), componenttest.NewNopHost()))
+	require.Error(t, conn.ConsumeProfiles(t.Context(), pprofileutiltest.NewProfiles("1", "2", "3")))
+	require.NoError(t, conn.Shutdown(t.Context()))
+
+	assert.Empty(t, sink0.AllProfiles())
+	assert.Empty(t, defaultSink.AllProfiles())
+}
+
+func TestProfilesForIgnoreError(t *testing.T) {
+	profilesDefault := pipeline.NewIDWithName(xpipeline.SignalProfiles, "default")
+	profiles0 := pipeline.NewIDWithName(xpipeline.SignalProfiles, "0")
+
+	cfg := &Config{
+		ErrorMode:        ottl.IgnoreError,
+		DefaultPipelines: []pipeline.ID{profilesDefault},
+		Table: []RoutingTableItem{
+			{
+				Statement: `route() where ToLowerCase(attributes["unknown"]) == "acme"`,
+				Pipelines: []pipeline.ID{profiles0},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var defaultSink, sink0 consumertest.ProfilesSink
+	conn, err := NewFactory().CreateProfilesToProfiles(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, xconnector.NewProfilesRouter(map[pipeline.ID]xconsumer.Profiles{
+			profilesDefault: &defaultSink,
+			profiles0:       &sink0,
+		}))
+	require.NoError(t, err)
+
+	require.NoError(t, conn.Start(t.Context(), componenttest.NewNopHost()))
+	require.NoError(t, conn.ConsumeProfiles(t.Context(), pprofileutiltest.NewProfiles("1", "2", "3")))
+	require.NoError(t, conn.Shutdown(t.Context()))
+
+	assert.Empty(t, sink0.AllProfiles())
+	require.Len(t, defaultSink.AllProfiles(), 1)
+	assert.NoError(t, pprofiletest.CompareProfiles(pprofileutiltest.NewProfiles("1", "2", "3"), defaultSink.AllProfiles()[0]))
+}
+
+func putProfileAttribute(t *testing.T, dic pprofile.ProfilesDictionary, p pprofile.Profile, key, value string) {
+	kv := pprofile.NewKeyValueAndUnit()
+	kv.SetKeyStrindex(int32(dic.StringTable().Len()))
+	dic.StringTable().Append(key)
+	kv.Value().SetStr(value)
+	idx, err := pprofile.SetAttribute(dic.AttributeTable(), kv)
+	require.NoError(t, err)
+	p.AttributeIndices().Append(idx)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 )
@@ -23,19 +24,20 @@ import (
 var errPipelineNotFound = errors.New("pipeline not found")
 
 // consumerProvider is a function with a type parameter C (expected to be one
-// of consumer.Traces, consumer.Metrics, or Consumer.Logs). returns a
+// of consumer.Traces, consumer.Metrics, consumer.Logs, or xconsumer.Profiles). returns a
 // consumer for the given component ID(s).
 type consumerProvider[C any] func(...pipeline.ID) (C, error)
 
 // router registers consumers and default consumers for a pipeline. the type
-// parameter C is expected to be one of: consumer.Traces, consumer.Metrics, or
-// consumer.Logs.
+// parameter C is expected to be one of: consumer.Traces, consumer.Metrics,
+// consumer.Logs, or xconsumer.Profiles.
 type router[C any] struct {
 	resourceParser   ottl.Parser[ottlresource.TransformContext]
 	spanParser       ottl.Parser[ottlspan.TransformContext]
 	metricParser     ottl.Parser[ottlmetric.TransformContext]
 	dataPointParser  ottl.Parser[ottldatapoint.TransformContext]
 	logParser        ottl.Parser[ottllog.TransformContext]
+	profileParser    ottl.Parser[ottlprofile.TransformContext]
 	defaultConsumer  C
 	logger           *zap.Logger
 	routes           map[string]routingItem[C]
@@ -78,11 +80,12 @@ type routingItem[C any] struct {
 	metricStatement    *ottl.Statement[ottlmetric.TransformContext]
 	dataPointStatement *ottl.Statement[ottldatapoint.TransformContext]
 	logStatement       *ottl.Statement[ottllog.TransformContext]
+	profileStatement   *ottl.Statement[ottlprofile.TransformContext]
 	statementContext   string
 }
 
 func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.TelemetrySettings) error {
-	var buildResource, buildSpan, buildMetric, buildDataPoint, buildLog bool
+	var buildResource, buildSpan, buildMetric, buildDataPoint, buildLog, buildProfile bool
 	for _, item := range table {
 		switch item.Context {
 		case "", "resource":
@@ -95,6 +98,8 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 			buildDataPoint = true
 		case "log":
 			buildLog = true
+		case "profile":
+			buildProfile = true
 		}
 	}
 
@@ -154,6 +159,17 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 			errs = errors.Join(errs, err)
 		}
 	}
+	if buildProfile {
+		parser, err := ottlprofile.NewParser(
+			standardFunctions[ottlprofile.TransformContext](),
+			settings,
+		)
+		if err == nil {
+			r.profileParser = parser
+		} else {
+			errs = errors.Join(errs, err)
+		}
+	}
 	return errs
 }
 
@@ -243,6 +259,12 @@ func (r *router[C]) registerRouteConsumers() (err error) {
 					return err
 				}
 				route.logStatement = statement
+			case "profile":
+				statement, err := r.profileParser.ParseStatement(item.Statement)
+				if err != nil {
+					return err
+				}
+				route.profileStatement = statement
 			}
 		} else {
 			var pipelineNames []string
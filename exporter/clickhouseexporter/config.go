@@ -68,6 +68,11 @@ type Config struct {
 	AsyncInsert bool `mapstructure:"async_insert"`
 	// MetricsTables defines the table names for metric types.
 	MetricsTables MetricTablesConfig `mapstructure:"metrics_tables"`
+	// ProfilesTables defines the table names for profiles.
+	ProfilesTables ProfilesTablesConfig `mapstructure:"profiles_tables"`
+	// SchemaMigrationsTableName is the table name recording the schema migrations applied to the tables.
+	// default is `otel_schema_migrations`.
+	SchemaMigrationsTableName string `mapstructure:"schema_migrations_table_name"`
 }
 
 type MetricTablesConfig struct {
@@ -83,6 +88,15 @@ type MetricTablesConfig struct {
 	ExponentialHistogram metrics.MetricTypeConfig `mapstructure:"exponential_histogram"`
 }
 
+type ProfilesTablesConfig struct {
+	// Samples is the table name for profile samples. default is `otel_profiles_samples`.
+	Samples string `mapstructure:"samples"`
+	// Locations is the table name for profile locations. default is `otel_profiles_locations`.
+	Locations string `mapstructure:"locations"`
+	// Functions is the table name for profile functions. default is `otel_profiles_functions`.
+	Functions string `mapstructure:"functions"`
+}
+
 // TableEngine defines the ENGINE string value when creating the table.
 type TableEngine struct {
 	Name   string `mapstructure:"name"`
@@ -126,6 +140,12 @@ func createDefaultConfig() component.Config {
 			Histogram:            metrics.MetricTypeConfig{Name: defaultMetricTableName + defaultHistogramSuffix},
 			ExponentialHistogram: metrics.MetricTypeConfig{Name: defaultMetricTableName + defaultExpHistogramSuffix},
 		},
+		ProfilesTables: ProfilesTablesConfig{
+			Samples:   "otel_profiles_samples",
+			Locations: "otel_profiles_locations",
+			Functions: "otel_profiles_functions",
+		},
+		SchemaMigrationsTableName: "otel_schema_migrations",
 	}
 }
 
@@ -310,3 +330,13 @@ func (cfg *Config) clusterString() string {
 
 	return fmt.Sprintf("ON CLUSTER %s", cfg.ClusterName)
 }
+
+// migrationsTable returns the table recording the schema migrations applied to the tables.
+func (cfg *Config) migrationsTable() internal.MigrationsTable {
+	return internal.MigrationsTable{
+		Database:   cfg.database(),
+		Name:       cfg.SchemaMigrationsTableName,
+		ClusterStr: cfg.clusterString(),
+		Engine:     cfg.tableEngineString(),
+	}
+}
@@ -77,7 +77,13 @@ func TestLoadConfig(t *testing.T) {
 					Histogram:            metrics.MetricTypeConfig{Name: "otel_metrics_custom_histogram"},
 					ExponentialHistogram: metrics.MetricTypeConfig{Name: "otel_metrics_custom_exp_histogram"},
 				},
-				ConnectionParams: map[string]string{},
+				ProfilesTables: ProfilesTablesConfig{
+					Samples:   "otel_profiles_custom_samples",
+					Locations: "otel_profiles_custom_locations",
+					Functions: "otel_profiles_custom_functions",
+				},
+				SchemaMigrationsTableName: "otel_custom_schema_migrations",
+				ConnectionParams:          map[string]string{},
 				QueueSettings: func() exporterhelper.QueueBatchConfig {
 					queue := exporterhelper.NewDefaultQueueConfig()
 					queue.NumConsumers = 10
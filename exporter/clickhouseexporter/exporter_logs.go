@@ -56,6 +56,10 @@ func (e *logsExporter) start(ctx context.Context, _ component.Host) error {
 		if createTableErr := createLogsTable(ctx, e.cfg, e.db); createTableErr != nil {
 			return createTableErr
 		}
+
+		if migrateErr := internal.RunMigrations(ctx, e.db, e.cfg.migrationsTable(), logsSchema, e.cfg.LogsTableName, logsMigrations); migrateErr != nil {
+			return fmt.Errorf("schema migration: %w", migrateErr)
+		}
 	}
 
 	err = e.detectSchemaFeatures(ctx)
@@ -68,6 +72,15 @@ func (e *logsExporter) start(ctx context.Context, _ component.Host) error {
 	return nil
 }
 
+// logsMigrations upgrade the logs tables created by previous versions of the exporter.
+// logsSchema identifies the schema of the table in the schema migrations table, as several schemas
+// may use the same table name.
+const logsSchema = "logs"
+
+var logsMigrations = []internal.Migration{
+	{Version: 1, Description: "add EventName column", Statement: sqltemplates.LogsMigrationV1},
+}
+
 const (
 	logsColumnEventName = "EventName"
 )
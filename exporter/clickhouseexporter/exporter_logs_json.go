@@ -63,6 +63,10 @@ func (e *logsJSONExporter) start(ctx context.Context, _ component.Host) error {
 		if createTableErr := createLogsJSONTable(ctx, e.cfg, e.db); createTableErr != nil {
 			return createTableErr
 		}
+
+		if migrateErr := internal.RunMigrations(ctx, e.db, e.cfg.migrationsTable(), logsJSONSchema, e.cfg.LogsTableName, logsJSONMigrations); migrateErr != nil {
+			return fmt.Errorf("schema migration: %w", migrateErr)
+		}
 	}
 
 	err = e.detectSchemaFeatures(ctx)
@@ -75,6 +79,16 @@ func (e *logsJSONExporter) start(ctx context.Context, _ component.Host) error {
 	return nil
 }
 
+// logsJSONMigrations upgrade the JSON logs tables created by previous versions of the exporter.
+// logsJSONSchema identifies the schema of the table in the schema migrations table, as several schemas
+// may use the same table name.
+const logsJSONSchema = "logs_json"
+
+var logsJSONMigrations = []internal.Migration{
+	{Version: 1, Description: "add attribute keys columns", Statement: sqltemplates.LogsJSONMigrationV1},
+	{Version: 2, Description: "add EventName column", Statement: sqltemplates.LogsJSONMigrationV2},
+}
+
 const (
 	logsJSONColumnResourceAttributesKeys = "ResourceAttributesKeys"
 	logsJSONColumnScopeAttributesKeys    = "ScopeAttributesKeys"
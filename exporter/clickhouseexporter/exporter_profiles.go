@@ -0,0 +1,384 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouseexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter"
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal/sqltemplates"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
+)
+
+type profilesExporter struct {
+	db                 driver.Conn
+	insertSamplesSQL   string
+	insertLocationsSQL string
+	insertFunctionsSQL string
+
+	logger *zap.Logger
+	cfg    *Config
+}
+
+func newProfilesExporter(logger *zap.Logger, cfg *Config) *profilesExporter {
+	return &profilesExporter{
+		insertSamplesSQL:   fmt.Sprintf(sqltemplates.ProfilesSamplesInsert, cfg.database(), cfg.ProfilesTables.Samples),
+		insertLocationsSQL: fmt.Sprintf(sqltemplates.ProfilesLocationsInsert, cfg.database(), cfg.ProfilesTables.Locations),
+		insertFunctionsSQL: fmt.Sprintf(sqltemplates.ProfilesFunctionsInsert, cfg.database(), cfg.ProfilesTables.Functions),
+		logger:             logger,
+		cfg:                cfg,
+	}
+}
+
+func (e *profilesExporter) start(ctx context.Context, _ component.Host) error {
+	opt, err := e.cfg.buildClickHouseOptions()
+	if err != nil {
+		return err
+	}
+
+	e.db, err = internal.NewClickhouseClientFromOptions(opt)
+	if err != nil {
+		return err
+	}
+
+	if e.cfg.shouldCreateSchema() {
+		if err := internal.CreateDatabase(ctx, e.db, e.cfg.database(), e.cfg.clusterString()); err != nil {
+			return err
+		}
+
+		if err := createProfilesTables(ctx, e.cfg, e.db); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *profilesExporter) shutdown(_ context.Context) error {
+	if e.db != nil {
+		return e.db.Close()
+	}
+
+	return nil
+}
+
+func (e *profilesExporter) pushProfilesData(ctx context.Context, pd pprofile.Profiles) error {
+	samplesBatch, err := e.db.PrepareBatch(ctx, e.insertSamplesSQL)
+	if err != nil {
+		return err
+	}
+	defer e.closeBatch(samplesBatch, "samples")
+
+	locationsBatch, err := e.db.PrepareBatch(ctx, e.insertLocationsSQL)
+	if err != nil {
+		return err
+	}
+	defer e.closeBatch(locationsBatch, "locations")
+
+	functionsBatch, err := e.db.PrepareBatch(ctx, e.insertFunctionsSQL)
+	if err != nil {
+		return err
+	}
+	defer e.closeBatch(functionsBatch, "functions")
+
+	processStart := time.Now()
+
+	dic := pd.Dictionary()
+	strs := dic.StringTable()
+	var sampleCount, locationCount, functionCount int
+	rsProfiles := pd.ResourceProfiles()
+	rsLen := rsProfiles.Len()
+	for i := range rsLen {
+		profiles := rsProfiles.At(i)
+		res := profiles.Resource()
+		resURL := profiles.SchemaUrl()
+		resAttr := res.Attributes()
+		serviceName := internal.GetServiceName(resAttr)
+		resAttrMap := internal.AttributesToMap(resAttr)
+
+		spLen := profiles.ScopeProfiles().Len()
+		for j := range spLen {
+			scopeProfile := profiles.ScopeProfiles().At(j)
+			scopeURL := scopeProfile.SchemaUrl()
+			scope := scopeProfile.Scope()
+			scopeName := scope.Name()
+			scopeVersion := scope.Version()
+			scopeAttrMap := internal.AttributesToMap(scope.Attributes())
+			scopeProfiles := scopeProfile.Profiles()
+
+			pLen := scopeProfiles.Len()
+			for k := range pLen {
+				profile := scopeProfiles.At(k)
+				profileID := profileIDToHex(profile, res, scope)
+				timestamp := profile.Time().AsTime()
+				profileAttrMap := internal.AttributesToMap(pprofile.FromAttributeIndices(dic.AttributeTable(), profile, dic))
+
+				// The locations and functions referenced by the samples are stored once per profile
+				locationIDs := map[int32]struct{}{}
+				functionIDs := map[int32]struct{}{}
+
+				samples := profile.Samples()
+				for l := 0; l < samples.Len(); l++ {
+					sample := samples.At(l)
+
+					var sampleLocationIDs []int32
+					if stackIndex := int(sample.StackIndex()); stackIndex >= 0 && stackIndex < dic.StackTable().Len() {
+						sampleLocationIDs = dic.StackTable().At(stackIndex).LocationIndices().AsRaw()
+					}
+					for _, locationID := range sampleLocationIDs {
+						locationIDs[locationID] = struct{}{}
+					}
+
+					traceID, spanID := "", ""
+					if linkIndex := int(sample.LinkIndex()); linkIndex >= 0 && linkIndex < dic.LinkTable().Len() {
+						link := dic.LinkTable().At(linkIndex)
+						traceID = traceutil.TraceIDToHexOrEmptyString(link.TraceID())
+						spanID = traceutil.SpanIDToHexOrEmptyString(link.SpanID())
+					}
+
+					sampleTimestamps := make([]time.Time, 0, sample.TimestampsUnixNano().Len())
+					for _, ts := range sample.TimestampsUnixNano().All() {
+						sampleTimestamps = append(sampleTimestamps, pcommon.Timestamp(ts).AsTime())
+					}
+
+					appendErr := samplesBatch.Append(
+						timestamp,
+						profileID,
+						traceID,
+						spanID,
+						serviceName,
+						resURL,
+						resAttrMap,
+						scopeURL,
+						scopeName,
+						scopeVersion,
+						scopeAttrMap,
+						profileAttrMap,
+						stringAt(strs, profile.SampleType().TypeStrindex()),
+						stringAt(strs, profile.SampleType().UnitStrindex()),
+						stringAt(strs, profile.PeriodType().TypeStrindex()),
+						stringAt(strs, profile.PeriodType().UnitStrindex()),
+						profile.Period(),
+						uint64(profile.Duration()),
+						sampleLocationIDs,
+						sample.Values().AsRaw(),
+						sampleTimestamps,
+						internal.AttributesToMap(pprofile.FromAttributeIndices(dic.AttributeTable(), sample, dic)),
+					)
+					if appendErr != nil {
+						return fmt.Errorf("failed to append profile sample row: %w", appendErr)
+					}
+
+					sampleCount++
+				}
+
+				for _, locationID := range slices.Sorted(maps.Keys(locationIDs)) {
+					if int(locationID) >= dic.LocationTable().Len() || locationID < 0 {
+						continue
+					}
+					location := dic.LocationTable().At(int(locationID))
+
+					var mappingFilename string
+					var mappingStart, mappingLimit, mappingOffset uint64
+					if mappingIndex := int(location.MappingIndex()); mappingIndex >= 0 && mappingIndex < dic.MappingTable().Len() {
+						mapping := dic.MappingTable().At(mappingIndex)
+						mappingFilename = stringAt(strs, mapping.FilenameStrindex())
+						mappingStart = mapping.MemoryStart()
+						mappingLimit = mapping.MemoryLimit()
+						mappingOffset = mapping.FileOffset()
+					}
+
+					lines := location.Lines()
+					lineFunctionIDs := make([]int32, 0, lines.Len())
+					lineNumbers := make([]int64, 0, lines.Len())
+					lineColumns := make([]int64, 0, lines.Len())
+					for m := 0; m < lines.Len(); m++ {
+						line := lines.At(m)
+						functionIDs[line.FunctionIndex()] = struct{}{}
+						lineFunctionIDs = append(lineFunctionIDs, line.FunctionIndex())
+						lineNumbers = append(lineNumbers, line.Line())
+						lineColumns = append(lineColumns, line.Column())
+					}
+
+					appendErr := locationsBatch.Append(
+						timestamp,
+						profileID,
+						serviceName,
+						locationID,
+						location.Address(),
+						mappingFilename,
+						mappingStart,
+						mappingLimit,
+						mappingOffset,
+						lineFunctionIDs,
+						lineNumbers,
+						lineColumns,
+						internal.AttributesToMap(pprofile.FromAttributeIndices(dic.AttributeTable(), location, dic)),
+					)
+					if appendErr != nil {
+						return fmt.Errorf("failed to append profile location row: %w", appendErr)
+					}
+
+					locationCount++
+				}
+
+				for _, functionID := range slices.Sorted(maps.Keys(functionIDs)) {
+					if int(functionID) >= dic.FunctionTable().Len() || functionID < 0 {
+						continue
+					}
+					function := dic.FunctionTable().At(int(functionID))
+
+					appendErr := functionsBatch.Append(
+						timestamp,
+						profileID,
+						serviceName,
+						functionID,
+						stringAt(strs, function.NameStrindex()),
+						stringAt(strs, function.SystemNameStrindex()),
+						stringAt(strs, function.FilenameStrindex()),
+						function.StartLine(),
+					)
+					if appendErr != nil {
+						return fmt.Errorf("failed to append profile function row: %w", appendErr)
+					}
+
+					functionCount++
+				}
+			}
+		}
+	}
+
+	processDuration := time.Since(processStart)
+	networkStart := time.Now()
+	// The samples are sent last, so that the locations and functions they reference are inserted before them.
+	if sendErr := functionsBatch.Send(); sendErr != nil {
+		return fmt.Errorf("profile functions insert failed: %w", sendErr)
+	}
+	if sendErr := locationsBatch.Send(); sendErr != nil {
+		return fmt.Errorf("profile locations insert failed: %w", sendErr)
+	}
+	if sendErr := samplesBatch.Send(); sendErr != nil {
+		return fmt.Errorf("profile samples insert failed: %w", sendErr)
+	}
+
+	networkDuration := time.Since(networkStart)
+	totalDuration := time.Since(processStart)
+	e.logger.Debug("insert profiles",
+		zap.Int("samples", sampleCount),
+		zap.Int("locations", locationCount),
+		zap.Int("functions", functionCount),
+		zap.String("process_cost", processDuration.String()),
+		zap.String("network_cost", networkDuration.String()),
+		zap.String("total_cost", totalDuration.String()))
+
+	return nil
+}
+
+func (e *profilesExporter) closeBatch(batch driver.Batch, table string) {
+	if closeErr := batch.Close(); closeErr != nil {
+		e.logger.Warn("failed to close profiles batch", zap.String("table", table), zap.Error(closeErr))
+	}
+}
+
+// profileIDToHex returns the hex representation of the profile ID. The profiles without ID are given an ID
+// derived from their resource, scope, time range and samples, so that their samples can still be linked to
+// their locations and functions, and a retried export writes the same rows.
+func profileIDToHex(profile pprofile.Profile, resource pcommon.Resource, scope pcommon.InstrumentationScope) string {
+	id := profile.ProfileID()
+	if id.IsEmpty() {
+		id = contentProfileID(profile, resource, scope)
+	}
+
+	return hex.EncodeToString(id[:])
+}
+
+// contentProfileID hashes the contents of a profile into a profile ID.
+func contentProfileID(profile pprofile.Profile, resource pcommon.Resource, scope pcommon.InstrumentationScope) pprofile.ProfileID {
+	h := sha256.New()
+	var buf []byte
+	writeString := func(s string) {
+		buf = binary.BigEndian.AppendUint64(buf[:0], uint64(len(s)))
+		_, _ = h.Write(buf)
+		_, _ = h.Write([]byte(s))
+	}
+	writeInt := func(v uint64) {
+		buf = binary.BigEndian.AppendUint64(buf[:0], v)
+		_, _ = h.Write(buf)
+	}
+
+	for k, v := range resource.Attributes().All() {
+		writeString(k)
+		writeString(v.AsString())
+	}
+	writeString(scope.Name())
+	writeString(scope.Version())
+	writeInt(uint64(profile.Time()))
+	writeInt(uint64(profile.Duration()))
+	for _, sample := range profile.Samples().All() {
+		writeInt(uint64(sample.StackIndex()))
+		writeInt(uint64(sample.LinkIndex()))
+		writeInt(uint64(sample.Values().Len()))
+		for _, v := range sample.Values().All() {
+			writeInt(uint64(v))
+		}
+		writeInt(uint64(sample.TimestampsUnixNano().Len()))
+		for _, ts := range sample.TimestampsUnixNano().All() {
+			writeInt(ts)
+		}
+		writeInt(uint64(sample.AttributeIndices().Len()))
+		for _, idx := range sample.AttributeIndices().All() {
+			writeInt(uint64(idx))
+		}
+	}
+
+	var id pprofile.ProfileID
+	copy(id[:], h.Sum(nil))
+	return id
+}
+
+// stringAt returns the string at the given index of the string table, or an empty string if the index is out of range.
+func stringAt(strs pcommon.StringSlice, index int32) string {
+	if index < 0 || int(index) >= strs.Len() {
+		return ""
+	}
+
+	return strs.At(int(index))
+}
+
+func renderCreateProfilesTableSQL(cfg *Config, template, table string) string {
+	ttlExpr := internal.GenerateTTLExpr(cfg.TTL, "toDateTime(Timestamp)")
+	return fmt.Sprintf(template, cfg.database(), table, cfg.clusterString(), cfg.tableEngineString(), ttlExpr)
+}
+
+func createProfilesTables(ctx context.Context, cfg *Config, db driver.Conn) error {
+	tables := []struct {
+		template string
+		name     string
+	}{
+		{sqltemplates.ProfilesSamplesCreateTable, cfg.ProfilesTables.Samples},
+		{sqltemplates.ProfilesLocationsCreateTable, cfg.ProfilesTables.Locations},
+		{sqltemplates.ProfilesFunctionsCreateTable, cfg.ProfilesTables.Functions},
+	}
+
+	for _, table := range tables {
+		if err := db.Exec(ctx, renderCreateProfilesTableSQL(cfg, table.template, table.name)); err != nil {
+			return fmt.Errorf("exec create profiles table %q sql: %w", table.name, err)
+		}
+	}
+
+	return nil
+}
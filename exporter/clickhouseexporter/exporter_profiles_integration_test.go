@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package clickhouseexporter
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.uber.org/zap/zaptest"
+)
+
+func testProfilesExporter(t *testing.T, endpoint string) {
+	exporter := newTestProfilesExporter(t, endpoint)
+	verifyExportProfiles(t, exporter)
+}
+
+func newTestProfilesExporter(t *testing.T, dsn string, fns ...func(*Config)) *profilesExporter {
+	exporter := newProfilesExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(dsn))
+
+	require.NoError(t, exporter.start(t.Context(), nil))
+
+	t.Cleanup(func() { _ = exporter.shutdown(t.Context()) })
+	return exporter
+}
+
+func verifyExportProfiles(t *testing.T, exporter *profilesExporter) {
+	pushConcurrentlyNoError(t, func() error {
+		return exporter.pushProfilesData(t.Context(), simpleProfiles(5000))
+	})
+
+	type sample struct {
+		Timestamp          time.Time         `ch:"Timestamp"`
+		ProfileID          string            `ch:"ProfileId"`
+		TraceID            string            `ch:"TraceId"`
+		SpanID             string            `ch:"SpanId"`
+		ServiceName        string            `ch:"ServiceName"`
+		ResourceSchemaURL  string            `ch:"ResourceSchemaUrl"`
+		ResourceAttributes map[string]string `ch:"ResourceAttributes"`
+		ScopeSchemaURL     string            `ch:"ScopeSchemaUrl"`
+		ScopeName          string            `ch:"ScopeName"`
+		ScopeVersion       string            `ch:"ScopeVersion"`
+		ScopeAttributes    map[string]string `ch:"ScopeAttributes"`
+		ProfileAttributes  map[string]string `ch:"ProfileAttributes"`
+		SampleType         string            `ch:"SampleType"`
+		SampleUnit         string            `ch:"SampleUnit"`
+		PeriodType         string            `ch:"PeriodType"`
+		PeriodUnit         string            `ch:"PeriodUnit"`
+		Period             int64             `ch:"Period"`
+		Duration           uint64            `ch:"Duration"`
+		LocationIDs        []int32           `ch:"LocationIds"`
+		Values             []int64           `ch:"Values"`
+		Timestamps         []time.Time       `ch:"Timestamps"`
+		SampleAttributes   map[string]string `ch:"SampleAttributes"`
+	}
+
+	expectedSample := sample{
+		Timestamp:          telemetryTimestamp,
+		ProfileID:          "01020300000000000000000000000000",
+		TraceID:            "01020300000000000000000000000000",
+		SpanID:             "0102030000000000",
+		ServiceName:        "test-service",
+		ResourceSchemaURL:  "https://opentelemetry.io/schemas/1.4.0",
+		ResourceAttributes: map[string]string{"service.name": "test-service"},
+		ScopeSchemaURL:     "https://opentelemetry.io/schemas/1.7.0",
+		ScopeName:          "io.opentelemetry.contrib.clickhouse",
+		ScopeVersion:       "1.0.0",
+		ScopeAttributes:    map[string]string{"lib": "clickhouse"},
+		ProfileAttributes:  map[string]string{"profile.frame.type": "go"},
+		SampleType:         "cpu",
+		SampleUnit:         "nanoseconds",
+		PeriodType:         "cpu",
+		PeriodUnit:         "nanoseconds",
+		Period:             10000000,
+		Duration:           60000000000,
+		LocationIDs:        []int32{0, 1},
+		Values:             []int64{10000000},
+		Timestamps:         []time.Time{telemetryTimestamp},
+		SampleAttributes:   map[string]string{"thread.name": "main"},
+	}
+
+	row := exporter.db.QueryRow(t.Context(), fmt.Sprintf("SELECT * FROM %q.%q", exporter.cfg.database(), exporter.cfg.ProfilesTables.Samples))
+	require.NoError(t, row.Err())
+
+	var actualSample sample
+	require.NoError(t, row.ScanStruct(&actualSample))
+	require.Equal(t, expectedSample, actualSample)
+
+	type location struct {
+		ProfileID       string  `ch:"ProfileId"`
+		LocationID      int32   `ch:"LocationId"`
+		Address         uint64  `ch:"Address"`
+		MappingFilename string  `ch:"MappingFilename"`
+		LinesFunctionID []int32 `ch:"Lines.FunctionId"`
+		LinesLine       []int64 `ch:"Lines.Line"`
+	}
+
+	var actualLocation location
+	row = exporter.db.QueryRow(t.Context(), fmt.Sprintf("SELECT ProfileId, LocationId, Address, MappingFilename, Lines.FunctionId, Lines.Line FROM %q.%q WHERE LocationId = 1", exporter.cfg.database(), exporter.cfg.ProfilesTables.Locations))
+	require.NoError(t, row.Err())
+	require.NoError(t, row.ScanStruct(&actualLocation))
+	require.Equal(t, location{
+		ProfileID:       "01020300000000000000000000000000",
+		LocationID:      1,
+		Address:         0x2000,
+		MappingFilename: "/usr/bin/app",
+		LinesFunctionID: []int32{1},
+		LinesLine:       []int64{42},
+	}, actualLocation)
+
+	type function struct {
+		ProfileID  string `ch:"ProfileId"`
+		FunctionID int32  `ch:"FunctionId"`
+		Name       string `ch:"Name"`
+		Filename   string `ch:"Filename"`
+		StartLine  int64  `ch:"StartLine"`
+	}
+
+	var actualFunction function
+	row = exporter.db.QueryRow(t.Context(), fmt.Sprintf("SELECT ProfileId, FunctionId, Name, Filename, StartLine FROM %q.%q WHERE FunctionId = 1", exporter.cfg.database(), exporter.cfg.ProfilesTables.Functions))
+	require.NoError(t, row.Err())
+	require.NoError(t, row.ScanStruct(&actualFunction))
+	require.Equal(t, function{
+		ProfileID:  "01020300000000000000000000000000",
+		FunctionID: 1,
+		Name:       "main.work",
+		Filename:   "main.go",
+		StartLine:  40,
+	}, actualFunction)
+}
+
+func simpleProfiles(count int) pprofile.Profiles {
+	profiles := pprofile.NewProfiles()
+	dic := profiles.Dictionary()
+	dic.StringTable().FromRaw([]string{"", "cpu", "nanoseconds", "/usr/bin/app", "main.main", "main.work", "main.go", "profile.frame.type", "thread.name"})
+
+	dic.MappingTable().AppendEmpty()
+	mapping := dic.MappingTable().AppendEmpty()
+	mapping.SetFilenameStrindex(3)
+
+	for i, name := range []int32{4, 5} {
+		fn := dic.FunctionTable().AppendEmpty()
+		fn.SetNameStrindex(name)
+		fn.SetFilenameStrindex(6)
+		fn.SetStartLine(int64(30 + 10*i))
+
+		loc := dic.LocationTable().AppendEmpty()
+		loc.SetMappingIndex(1)
+		loc.SetAddress(uint64(0x1000 * (i + 1)))
+		line := loc.Lines().AppendEmpty()
+		line.SetFunctionIndex(int32(i))
+		line.SetLine(int64(32 + 10*i))
+	}
+
+	stack := dic.StackTable().AppendEmpty()
+	stack.LocationIndices().FromRaw([]int32{0, 1})
+
+	dic.LinkTable().AppendEmpty()
+	link := dic.LinkTable().AppendEmpty()
+	link.SetTraceID([16]byte{1, 2, 3})
+	link.SetSpanID([8]byte{1, 2, 3})
+
+	dic.AttributeTable().AppendEmpty()
+	frameType := dic.AttributeTable().AppendEmpty()
+	frameType.SetKeyStrindex(7)
+	frameType.Value().SetStr("go")
+	threadName := dic.AttributeTable().AppendEmpty()
+	threadName.SetKeyStrindex(8)
+	threadName.Value().SetStr("main")
+
+	rp := profiles.ResourceProfiles().AppendEmpty()
+	rp.SetSchemaUrl("https://opentelemetry.io/schemas/1.4.0")
+	rp.Resource().Attributes().PutStr("service.name", "test-service")
+	sp := rp.ScopeProfiles().AppendEmpty()
+	sp.SetSchemaUrl("https://opentelemetry.io/schemas/1.7.0")
+	sp.Scope().SetName("io.opentelemetry.contrib.clickhouse")
+	sp.Scope().SetVersion("1.0.0")
+	sp.Scope().Attributes().PutStr("lib", "clickhouse")
+
+	for range count {
+		profile := sp.Profiles().AppendEmpty()
+		profile.SetProfileID([16]byte{1, 2, 3})
+		profile.SetTime(pcommon.NewTimestampFromTime(telemetryTimestamp))
+		profile.SetDuration(pcommon.Timestamp(time.Minute))
+		profile.SampleType().SetTypeStrindex(1)
+		profile.SampleType().SetUnitStrindex(2)
+		profile.PeriodType().SetTypeStrindex(1)
+		profile.PeriodType().SetUnitStrindex(2)
+		profile.SetPeriod(10000000)
+		profile.AttributeIndices().Append(1)
+
+		sample := profile.Samples().AppendEmpty()
+		sample.SetStackIndex(0)
+		sample.SetLinkIndex(1)
+		sample.Values().Append(10000000)
+		sample.TimestampsUnixNano().Append(uint64(telemetryTimestamp.UnixNano()))
+		sample.AttributeIndices().Append(2)
+	}
+
+	return profiles
+}
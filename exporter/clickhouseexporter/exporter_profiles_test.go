@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouseexporter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.uber.org/zap"
+)
+
+func TestProfilesExporter_New(t *testing.T) {
+	type validate func(*testing.T, *profilesExporter, error)
+
+	failWithMsg := func(msg string) validate {
+		return func(t *testing.T, _ *profilesExporter, err error) {
+			require.ErrorContains(t, err, msg)
+		}
+	}
+
+	tests := map[string]struct {
+		config *Config
+		want   validate
+	}{
+		"no dsn": {
+			config: withDefaultConfig(),
+			want:   failWithMsg("parse dsn address failed"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var err error
+			exporter := newProfilesExporter(zap.NewNop(), test.config)
+
+			if exporter != nil {
+				err = errors.Join(err, exporter.start(t.Context(), nil))
+				defer func() {
+					require.NoError(t, exporter.shutdown(t.Context()))
+				}()
+			}
+
+			test.want(t, exporter, err)
+		})
+	}
+}
+
+func TestProfileIDToHex(t *testing.T) {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "checkout")
+	scope := pcommon.NewInstrumentationScope()
+	scope.SetName("profiler")
+
+	profile := pprofile.NewProfile()
+	profile.SetProfileID(pprofile.ProfileID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	require.Equal(t, "0102030405060708090a0b0c0d0e0f10", profileIDToHex(profile, resource, scope))
+
+	// The profiles without ID are given an ID derived from their contents, which is the same when they are retried
+	profile.SetProfileID(pprofile.NewProfileIDEmpty())
+	profile.SetTime(pcommon.Timestamp(1_700_000_000_000_000_000))
+	profile.SetDuration(pcommon.Timestamp(10_000_000_000))
+	sample := profile.Samples().AppendEmpty()
+	sample.SetStackIndex(1)
+	sample.Values().Append(42)
+	generated := profileIDToHex(profile, resource, scope)
+	require.Len(t, generated, 32)
+	require.NotEqual(t, "00000000000000000000000000000000", generated)
+
+	retried := pprofile.NewProfile()
+	profile.CopyTo(retried)
+	require.Equal(t, generated, profileIDToHex(retried, resource, scope))
+
+	// Profiles with other contents are given other IDs
+	retried.Samples().At(0).Values().SetAt(0, 43)
+	require.NotEqual(t, generated, profileIDToHex(retried, resource, scope))
+	otherResource := pcommon.NewResource()
+	otherResource.Attributes().PutStr("service.name", "cart")
+	require.NotEqual(t, generated, profileIDToHex(profile, otherResource, scope))
+	profile.SetTime(profile.Time() + 1)
+	require.NotEqual(t, generated, profileIDToHex(profile, resource, scope))
+}
+
+func TestStringAt(t *testing.T) {
+	strs := pcommon.NewStringSlice()
+	strs.FromRaw([]string{"", "cpu", "nanoseconds"})
+
+	require.Equal(t, "cpu", stringAt(strs, 1))
+	require.Empty(t, stringAt(strs, 0))
+	require.Empty(t, stringAt(strs, -1))
+	require.Empty(t, stringAt(strs, 3))
+}
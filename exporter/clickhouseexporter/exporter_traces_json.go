@@ -62,6 +62,10 @@ func (e *tracesJSONExporter) start(ctx context.Context, _ component.Host) error
 		if createTableErr := createTraceJSONTables(ctx, e.cfg, e.db); createTableErr != nil {
 			return createTableErr
 		}
+
+		if migrateErr := internal.RunMigrations(ctx, e.db, e.cfg.migrationsTable(), tracesJSONSchema, e.cfg.TracesTableName, tracesJSONMigrations); migrateErr != nil {
+			return fmt.Errorf("schema migration: %w", migrateErr)
+		}
 	}
 
 	err = e.detectSchemaFeatures(ctx)
@@ -74,6 +78,15 @@ func (e *tracesJSONExporter) start(ctx context.Context, _ component.Host) error
 	return nil
 }
 
+// tracesJSONMigrations upgrade the JSON traces tables created by previous versions of the exporter.
+// tracesJSONSchema identifies the schema of the table in the schema migrations table, as several schemas
+// may use the same table name.
+const tracesJSONSchema = "traces_json"
+
+var tracesJSONMigrations = []internal.Migration{
+	{Version: 1, Description: "add attribute keys columns", Statement: sqltemplates.TracesJSONMigrationV1},
+}
+
 const (
 	tracesJSONColumnResourceAttributesKeys = "ResourceAttributesKeys"
 	tracesJSONColumnSpanAttributesKeys     = "SpanAttributesKeys"
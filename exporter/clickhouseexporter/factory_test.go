@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/exporter/xexporter"
 	"go.opentelemetry.io/collector/featuregate"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal/metadata"
@@ -92,3 +93,16 @@ func TestFactory_CreateMetrics(t *testing.T) {
 
 	require.NoError(t, exporter.Shutdown(t.Context()))
 }
+
+func TestFactory_CreateProfiles(t *testing.T) {
+	factory := NewFactory().(xexporter.Factory)
+	cfg := withDefaultConfig(func(cfg *Config) {
+		cfg.Endpoint = defaultEndpoint
+	})
+	params := exportertest.NewNopSettings(metadata.Type)
+	exporter, err := factory.CreateProfiles(t.Context(), params, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, exporter)
+
+	require.NoError(t, exporter.Shutdown(t.Context()))
+}
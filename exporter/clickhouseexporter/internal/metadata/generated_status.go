@@ -12,7 +12,8 @@ var (
 )
 
 const (
-	MetricsStability = component.StabilityLevelAlpha
-	TracesStability  = component.StabilityLevelBeta
-	LogsStability    = component.StabilityLevelBeta
+	ProfilesStability = component.StabilityLevelDevelopment
+	MetricsStability  = component.StabilityLevelAlpha
+	TracesStability   = component.StabilityLevelBeta
+	LogsStability     = component.StabilityLevelBeta
 )
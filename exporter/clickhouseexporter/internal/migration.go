@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal/sqltemplates"
+)
+
+// Migration is a versioned change to the schema of a table.
+type Migration struct {
+	// Version orders the migrations of a table, starting at 1.
+	Version uint32
+	// Description is recorded along with the version once the migration is applied.
+	Description string
+	// Statement is the DDL applying the migration, formatted with the database, table and ON CLUSTER string.
+	// It must be idempotent (e.g. ADD COLUMN IF NOT EXISTS), as it may run against a table created with the latest
+	// schema, or concurrently by several collectors.
+	Statement string
+}
+
+// MigrationsTable defines the table recording the migrations applied to the tables of a database.
+type MigrationsTable struct {
+	Database   string
+	Name       string
+	ClusterStr string
+	Engine     string
+}
+
+// RunMigrations applies the migrations of a table that are newer than its recorded version, in order,
+// and records each applied version. The versions are recorded per schema and table, as the tables of
+// different schemas, such as the logs and JSON logs, may have the same name.
+func RunMigrations(ctx context.Context, db driver.Conn, mt MigrationsTable, schema, table string, migrations []Migration) error {
+	if len(migrations) == 0 {
+		return nil
+	}
+
+	createTable := fmt.Sprintf(sqltemplates.SchemaMigrationsCreateTable, mt.Database, mt.Name, mt.ClusterStr, mt.Engine)
+	if err := db.Exec(ctx, createTable); err != nil {
+		return fmt.Errorf("create schema migrations table: %w", err)
+	}
+
+	var version uint32
+	if err := db.QueryRow(ctx, fmt.Sprintf(sqltemplates.SchemaMigrationsVersion, mt.Database, mt.Name), schema, table).Scan(&version); err != nil {
+		return fmt.Errorf("get %s schema version of table %q: %w", schema, table, err)
+	}
+
+	pending, err := PendingMigrations(migrations, version)
+	if err != nil {
+		return err
+	}
+
+	insertSQL := fmt.Sprintf(sqltemplates.SchemaMigrationsInsert, mt.Database, mt.Name)
+	for _, m := range pending {
+		if err := db.Exec(ctx, fmt.Sprintf(m.Statement, mt.Database, table, mt.ClusterStr)); err != nil {
+			return fmt.Errorf("apply %s migration %d (%s) to table %q: %w", schema, m.Version, m.Description, table, err)
+		}
+
+		if err := db.Exec(ctx, insertSQL, schema, table, m.Version, m.Description); err != nil {
+			return fmt.Errorf("record %s migration %d of table %q: %w", schema, m.Version, table, err)
+		}
+	}
+
+	return nil
+}
+
+// PendingMigrations returns the migrations newer than the given version.
+// The migrations must be sorted by strictly increasing versions, starting at 1.
+func PendingMigrations(migrations []Migration, version uint32) ([]Migration, error) {
+	var previous uint32
+	for i, m := range migrations {
+		if m.Version <= previous {
+			return nil, fmt.Errorf("migration %d: version %d must be greater than %d", i, m.Version, previous)
+		}
+		previous = m.Version
+	}
+
+	for i, m := range migrations {
+		if m.Version > version {
+			return migrations[i:], nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingMigrations(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Description: "first"},
+		{Version: 2, Description: "second"},
+		{Version: 4, Description: "third"},
+	}
+
+	tests := []struct {
+		name       string
+		migrations []Migration
+		version    uint32
+		expected   []Migration
+		wantErr    string
+	}{
+		{
+			name:       "new table",
+			migrations: migrations,
+			version:    0,
+			expected:   migrations,
+		},
+		{
+			name:       "partially migrated table",
+			migrations: migrations,
+			version:    2,
+			expected:   migrations[2:],
+		},
+		{
+			name:       "migrated table",
+			migrations: migrations,
+			version:    4,
+		},
+		{
+			name:       "table migrated by a newer version",
+			migrations: migrations,
+			version:    5,
+		},
+		{
+			name: "no migrations",
+		},
+		{
+			name: "unsorted versions",
+			migrations: []Migration{
+				{Version: 2},
+				{Version: 1},
+			},
+			wantErr: "migration 1: version 1 must be greater than 2",
+		},
+		{
+			name: "version zero",
+			migrations: []Migration{
+				{Version: 0},
+			},
+			wantErr: "migration 0: version 0 must be greater than 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pending, err := PendingMigrations(tt.migrations, tt.version)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, pending)
+		})
+	}
+}
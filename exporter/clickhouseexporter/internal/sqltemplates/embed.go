@@ -69,3 +69,46 @@ var MetricsSummaryCreateTable string
 
 //go:embed metrics_summary_insert.sql
 var MetricsSummaryInsert string
+
+// PROFILES
+
+//go:embed profiles_samples_table.sql
+var ProfilesSamplesCreateTable string
+
+//go:embed profiles_samples_insert.sql
+var ProfilesSamplesInsert string
+
+//go:embed profiles_locations_table.sql
+var ProfilesLocationsCreateTable string
+
+//go:embed profiles_locations_insert.sql
+var ProfilesLocationsInsert string
+
+//go:embed profiles_functions_table.sql
+var ProfilesFunctionsCreateTable string
+
+//go:embed profiles_functions_insert.sql
+var ProfilesFunctionsInsert string
+
+// SCHEMA MIGRATIONS
+
+//go:embed schema_migrations_table.sql
+var SchemaMigrationsCreateTable string
+
+//go:embed schema_migrations_version.sql
+var SchemaMigrationsVersion string
+
+//go:embed schema_migrations_insert.sql
+var SchemaMigrationsInsert string
+
+//go:embed logs_migration_v1.sql
+var LogsMigrationV1 string
+
+//go:embed logs_json_migration_v1.sql
+var LogsJSONMigrationV1 string
+
+//go:embed logs_json_migration_v2.sql
+var LogsJSONMigrationV2 string
+
+//go:embed traces_json_migration_v1.sql
+var TracesJSONMigrationV1 string
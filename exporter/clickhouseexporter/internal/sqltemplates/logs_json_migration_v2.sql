@@ -0,0 +1,2 @@
+ALTER TABLE %q.%q %s
+    ADD COLUMN IF NOT EXISTS EventName String CODEC(ZSTD(1))
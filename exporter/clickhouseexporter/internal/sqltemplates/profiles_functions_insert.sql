@@ -0,0 +1,19 @@
+INSERT INTO %q.%q (
+    Timestamp,
+    ProfileId,
+    ServiceName,
+    FunctionId,
+    Name,
+    SystemName,
+    Filename,
+    StartLine
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+)
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS %q.%q %s (
+    Timestamp DateTime64(9) CODEC(Delta(8), ZSTD(1)),
+    ProfileId String CODEC(ZSTD(1)),
+    ServiceName LowCardinality(String) CODEC(ZSTD(1)),
+    FunctionId Int32 CODEC(ZSTD(1)),
+    Name String CODEC(ZSTD(1)),
+    SystemName String CODEC(ZSTD(1)),
+    Filename String CODEC(ZSTD(1)),
+    StartLine Int64 CODEC(ZSTD(1)),
+
+    INDEX idx_profile_id ProfileId TYPE bloom_filter(0.001) GRANULARITY 1,
+    INDEX idx_name Name TYPE tokenbf_v1(32768, 3, 0) GRANULARITY 8
+) ENGINE = %s
+PARTITION BY toDate(Timestamp)
+ORDER BY (ServiceName, ProfileId, FunctionId)
+%s
+SETTINGS index_granularity = 8192, ttl_only_drop_parts = 1
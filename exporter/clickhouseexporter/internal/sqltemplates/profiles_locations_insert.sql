@@ -0,0 +1,29 @@
+INSERT INTO %q.%q (
+    Timestamp,
+    ProfileId,
+    ServiceName,
+    LocationId,
+    Address,
+    MappingFilename,
+    MappingMemoryStart,
+    MappingMemoryLimit,
+    MappingFileOffset,
+    Lines.FunctionId,
+    Lines.Line,
+    Lines.Column,
+    Attributes
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+)
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS %q.%q %s (
+    Timestamp DateTime64(9) CODEC(Delta(8), ZSTD(1)),
+    ProfileId String CODEC(ZSTD(1)),
+    ServiceName LowCardinality(String) CODEC(ZSTD(1)),
+    LocationId Int32 CODEC(ZSTD(1)),
+    Address UInt64 CODEC(ZSTD(1)),
+    MappingFilename String CODEC(ZSTD(1)),
+    MappingMemoryStart UInt64 CODEC(ZSTD(1)),
+    MappingMemoryLimit UInt64 CODEC(ZSTD(1)),
+    MappingFileOffset UInt64 CODEC(ZSTD(1)),
+    Lines Nested (
+        FunctionId Int32,
+        Line Int64,
+        Column Int64
+    ) CODEC(ZSTD(1)),
+    Attributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+
+    INDEX idx_profile_id ProfileId TYPE bloom_filter(0.001) GRANULARITY 1
+) ENGINE = %s
+PARTITION BY toDate(Timestamp)
+ORDER BY (ServiceName, ProfileId, LocationId)
+%s
+SETTINGS index_granularity = 8192, ttl_only_drop_parts = 1
@@ -0,0 +1,47 @@
+INSERT INTO %q.%q (
+    Timestamp,
+    ProfileId,
+    TraceId,
+    SpanId,
+    ServiceName,
+    ResourceSchemaUrl,
+    ResourceAttributes,
+    ScopeSchemaUrl,
+    ScopeName,
+    ScopeVersion,
+    ScopeAttributes,
+    ProfileAttributes,
+    SampleType,
+    SampleUnit,
+    PeriodType,
+    PeriodUnit,
+    Period,
+    Duration,
+    LocationIds,
+    Values,
+    Timestamps,
+    SampleAttributes
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+)
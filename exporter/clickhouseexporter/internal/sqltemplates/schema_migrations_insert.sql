@@ -0,0 +1,11 @@
+INSERT INTO %q.%q (
+    Schema,
+    TableName,
+    Version,
+    Description
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?
+)
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS %q.%q %s (
+    Schema String,
+    TableName String,
+    Version UInt32,
+    Description String,
+    AppliedAt DateTime DEFAULT now()
+) ENGINE = %s
+ORDER BY (Schema, TableName, Version)
@@ -0,0 +1 @@
+SELECT max(Version) FROM %q.%q WHERE Schema = ? AND TableName = ?
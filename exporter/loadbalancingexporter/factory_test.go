@@ -17,6 +17,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/exporter/xexporter"
 	"go.opentelemetry.io/collector/otelcol/otelcoltest"
 	metricnoop "go.opentelemetry.io/otel/metric/noop"
 	tracenoop "go.opentelemetry.io/otel/trace/noop"
@@ -62,6 +63,24 @@ func TestLogExporterGetsCreatedWithValidConfiguration(t *testing.T) {
 	assert.NotNil(t, exp)
 }
 
+func TestProfilesExporterGetsCreatedWithValidConfiguration(t *testing.T) {
+	// prepare
+	factory := NewFactory().(xexporter.Factory)
+	creationParams := exportertest.NewNopSettings(metadata.Type)
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			Static: configoptional.Some(StaticResolver{Hostnames: []string{"endpoint-1"}}),
+		},
+	}
+
+	// test
+	exp, err := factory.CreateProfiles(t.Context(), creationParams, cfg)
+
+	// verify
+	assert.NoError(t, err)
+	assert.NotNil(t, exp)
+}
+
 func TestOTLPConfigIsValid(t *testing.T) {
 	// prepare
 	factory := NewFactory()
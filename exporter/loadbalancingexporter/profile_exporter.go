@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/exporter/xexporter"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/otel/metric"
+	conventions "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+)
+
+var _ xexporter.Profiles = (*profileExporterImp)(nil)
+
+type profileExporterImp struct {
+	loadBalancer *loadBalancer
+	routingKey   routingKey
+
+	logger     *zap.Logger
+	stopped    bool
+	shutdownWg sync.WaitGroup
+	telemetry  *metadata.TelemetryBuilder
+}
+
+// Create new profiles exporter
+func newProfilesExporter(params exporter.Settings, cfg component.Config) (*profileExporterImp, error) {
+	telemetry, err := metadata.NewTelemetryBuilder(params.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	exporterFactory := otlpexporter.NewFactory().(xexporter.Factory)
+	cfFunc := func(ctx context.Context, endpoint string) (component.Component, error) {
+		oCfg := buildExporterConfig(cfg.(*Config), endpoint)
+		oParams := buildExporterSettings(exporterFactory.Type(), params, endpoint)
+
+		return exporterFactory.CreateProfiles(ctx, oParams, &oCfg)
+	}
+
+	lb, err := newLoadBalancer(params.Logger, cfg, cfFunc, telemetry)
+	if err != nil {
+		return nil, err
+	}
+
+	profileExporter := profileExporterImp{
+		loadBalancer: lb,
+		routingKey:   svcRouting,
+		telemetry:    telemetry,
+		logger:       params.Logger,
+	}
+
+	switch cfg.(*Config).RoutingKey {
+	case svcRoutingStr, "":
+		// default case for empty routing key
+		profileExporter.routingKey = svcRouting
+	case resourceRoutingStr:
+		profileExporter.routingKey = resourceRouting
+	default:
+		return nil, fmt.Errorf("unsupported routing_key: %q", cfg.(*Config).RoutingKey)
+	}
+	return &profileExporter, nil
+}
+
+func (*profileExporterImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *profileExporterImp) Start(ctx context.Context, host component.Host) error {
+	return e.loadBalancer.Start(ctx, host)
+}
+
+func (e *profileExporterImp) Shutdown(ctx context.Context) error {
+	err := e.loadBalancer.Shutdown(ctx)
+	e.stopped = true
+	e.shutdownWg.Wait()
+	return err
+}
+
+func (e *profileExporterImp) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	var batches map[string]pprofile.ResourceProfilesSlice
+
+	switch e.routingKey {
+	case svcRouting:
+		var errs []error
+		batches, errs = splitProfilesByResourceServiceName(pd)
+		if len(errs) > 0 {
+			for _, ee := range errs {
+				e.logger.Error("failed to export profile", zap.Error(ee))
+			}
+			if len(batches) == 0 {
+				return consumererror.NewPermanent(errors.Join(errs...))
+			}
+		}
+	case resourceRouting:
+		batches = splitProfilesByResourceID(pd)
+	}
+
+	// Now assign each batch to an exporter. The profiles reference the dictionary by index,
+	// so each exporter receives a copy of the whole dictionary along with its resource profiles.
+	profilesByExporter := map[*wrappedExporter]pprofile.Profiles{}
+
+	for routingID, rps := range batches {
+		exp, _, err := e.loadBalancer.exporterAndEndpoint([]byte(routingID))
+		if err != nil {
+			return err
+		}
+
+		expProfiles, ok := profilesByExporter[exp]
+		if !ok {
+			exp.consumeWG.Add(1)
+			expProfiles = pprofile.NewProfiles()
+			pd.Dictionary().CopyTo(expProfiles.Dictionary())
+			profilesByExporter[exp] = expProfiles
+		}
+
+		rps.MoveAndAppendTo(expProfiles.ResourceProfiles())
+	}
+
+	var errs error
+	for exp, pds := range profilesByExporter {
+		start := time.Now()
+		err := exp.ConsumeProfiles(ctx, pds)
+		duration := time.Since(start)
+
+		exp.consumeWG.Done()
+		errs = multierr.Append(errs, err)
+		e.telemetry.LoadbalancerBackendLatency.Record(ctx, duration.Milliseconds(), metric.WithAttributeSet(exp.endpointAttr))
+		if err == nil {
+			e.telemetry.LoadbalancerBackendOutcome.Add(ctx, 1, metric.WithAttributeSet(exp.successAttr))
+		} else {
+			e.telemetry.LoadbalancerBackendOutcome.Add(ctx, 1, metric.WithAttributeSet(exp.failureAttr))
+			e.logger.Debug("failed to export profiles", zap.Error(err))
+		}
+	}
+
+	return errs
+}
+
+func splitProfilesByResourceServiceName(pd pprofile.Profiles) (map[string]pprofile.ResourceProfilesSlice, []error) {
+	results := map[string]pprofile.ResourceProfilesSlice{}
+	var errs []error
+
+	for i := 0; i < pd.ResourceProfiles().Len(); i++ {
+		rp := pd.ResourceProfiles().At(i)
+
+		svc, ok := rp.Resource().Attributes().Get(string(conventions.ServiceNameKey))
+		if !ok {
+			errs = append(errs, fmt.Errorf("unable to get service name from resource profile with attributes: %v", rp.Resource().Attributes().AsRaw()))
+			continue
+		}
+
+		appendResourceProfiles(results, svc.Str(), rp)
+	}
+
+	return results, errs
+}
+
+func splitProfilesByResourceID(pd pprofile.Profiles) map[string]pprofile.ResourceProfilesSlice {
+	results := map[string]pprofile.ResourceProfilesSlice{}
+
+	for i := 0; i < pd.ResourceProfiles().Len(); i++ {
+		rp := pd.ResourceProfiles().At(i)
+		appendResourceProfiles(results, identity.OfResource(rp.Resource()).String(), rp)
+	}
+
+	return results
+}
+
+func appendResourceProfiles(results map[string]pprofile.ResourceProfilesSlice, key string, rp pprofile.ResourceProfiles) {
+	rps, ok := results[key]
+	if !ok {
+		rps = pprofile.NewResourceProfilesSlice()
+		results[key] = rps
+	}
+	rp.CopyTo(rps.AppendEmpty())
+}
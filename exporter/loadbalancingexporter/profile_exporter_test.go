@@ -0,0 +1,285 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter/xexporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	conventions "go.opentelemetry.io/otel/semconv/v1.27.0"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+)
+
+func TestNewProfilesExporter(t *testing.T) {
+	ts, _ := getTelemetryAssets(t)
+	for _, tt := range []struct {
+		desc   string
+		config *Config
+		err    string
+	}{
+		{
+			"empty routing key",
+			&Config{},
+			errNoResolver.Error(),
+		},
+		{
+			"service",
+			serviceBasedRoutingConfig(),
+			"",
+		},
+		{
+			"resource",
+			resourceBasedRoutingConfig(),
+			"",
+		},
+		{
+			"traceID",
+			&Config{
+				Resolver: ResolverSettings{
+					Static: configoptional.Some(StaticResolver{Hostnames: []string{"endpoint-1"}}),
+				},
+				RoutingKey: traceIDRoutingStr,
+			},
+			`unsupported routing_key: "traceID"`,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			_, err := newProfilesExporter(ts, tt.config)
+
+			// verify
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestProfilesExporterShutdown(t *testing.T) {
+	ts, _ := getTelemetryAssets(t)
+	p, err := newProfilesExporter(ts, serviceBasedRoutingConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// test
+	res := p.Shutdown(t.Context())
+
+	// verify
+	assert.NoError(t, res)
+}
+
+func TestConsumeProfiles(t *testing.T) {
+	for _, routingKey := range []string{svcRoutingStr, resourceRoutingStr} {
+		t.Run(routingKey, func(t *testing.T) {
+			ts, tb := getTelemetryAssets(t)
+			config := &Config{
+				Resolver: ResolverSettings{
+					Static: configoptional.Some(StaticResolver{Hostnames: []string{"endpoint-1", "endpoint-2"}}),
+				},
+				RoutingKey: routingKey,
+			}
+
+			p, err := newProfilesExporter(ts, config)
+			require.NoError(t, err)
+
+			// each endpoint gets its own sink, so that the routing can be verified
+			var mu sync.Mutex
+			sinks := map[string]*consumertest.ProfilesSink{}
+			componentFactory := func(_ context.Context, endpoint string) (component.Component, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				sink := &consumertest.ProfilesSink{}
+				sinks[endpoint] = sink
+				return newMockProfilesExporter(sink.ConsumeProfiles), nil
+			}
+			lb, err := newLoadBalancer(ts.Logger, config, componentFactory, tb)
+			require.NoError(t, err)
+			lb.res = &mockResolver{
+				triggerCallbacks: true,
+				onResolve: func(_ context.Context) ([]string, error) {
+					return []string{"endpoint-1", "endpoint-2"}, nil
+				},
+			}
+			p.loadBalancer = lb
+
+			require.NoError(t, p.Start(t.Context(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, p.Shutdown(t.Context()))
+			}()
+
+			// test
+			input := twoServicesProfiles()
+			require.NoError(t, p.ConsumeProfiles(t.Context(), input))
+
+			// verify
+			received := 0
+			for endpoint, sink := range sinks {
+				for _, pd := range sink.AllProfiles() {
+					// the dictionary is kept as is, so that the indices of the profiles remain valid
+					assert.Equal(t, input.Dictionary().StringTable().AsRaw(), pd.Dictionary().StringTable().AsRaw())
+					for i := 0; i < pd.ResourceProfiles().Len(); i++ {
+						rp := pd.ResourceProfiles().At(i)
+						key := routingIDOfResourceProfiles(t, rp, routingKey)
+						_, expected, err := lb.exporterAndEndpoint([]byte(key))
+						require.NoError(t, err)
+						assert.Equal(t, endpointWithPort(expected), endpoint)
+
+						profile := rp.ScopeProfiles().At(0).Profiles().At(0)
+						assert.Equal(t, rp.Resource().Attributes().AsRaw()[string(conventions.ServiceNameKey)], pd.Dictionary().StringTable().At(int(profile.SampleType().TypeStrindex())))
+						received++
+					}
+				}
+			}
+			assert.Equal(t, 2, received)
+		})
+	}
+}
+
+func TestConsumeProfilesNoServiceName(t *testing.T) {
+	ts, tb := getTelemetryAssets(t)
+	config := serviceBasedRoutingConfig()
+	p, err := newProfilesExporter(ts, config)
+	require.NoError(t, err)
+
+	sink := &consumertest.ProfilesSink{}
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newMockProfilesExporter(sink.ConsumeProfiles), nil
+	}
+	lb, err := newLoadBalancer(ts.Logger, config, componentFactory, tb)
+	require.NoError(t, err)
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(_ context.Context) ([]string, error) {
+			return []string{"endpoint-1"}, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	require.NoError(t, p.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, p.Shutdown(t.Context()))
+	}()
+
+	// the resources without a service name are dropped
+	input := twoServicesProfiles()
+	input.ResourceProfiles().At(1).Resource().Attributes().Remove(string(conventions.ServiceNameKey))
+	require.NoError(t, p.ConsumeProfiles(t.Context(), input))
+	require.Len(t, sink.AllProfiles(), 1)
+	assert.Equal(t, 1, sink.AllProfiles()[0].ResourceProfiles().Len())
+
+	// the error is permanent when no resource has a service name
+	input.ResourceProfiles().At(0).Resource().Attributes().Remove(string(conventions.ServiceNameKey))
+	err = p.ConsumeProfiles(t.Context(), input)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Len(t, sink.AllProfiles(), 1)
+}
+
+func TestConsumeProfilesUnexpectedExporterType(t *testing.T) {
+	ts, tb := getTelemetryAssets(t)
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	lb, err := newLoadBalancer(ts.Logger, serviceBasedRoutingConfig(), componentFactory, tb)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newProfilesExporter(ts, serviceBasedRoutingConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// pre-load an exporter here, so that we don't use the actual OTLP exporter
+	lb.addMissingExporters(t.Context(), []string{"endpoint-1"})
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(_ context.Context) ([]string, error) {
+			return []string{"endpoint-1"}, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(t.Context(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(t.Context()))
+	}()
+
+	// test
+	res := p.ConsumeProfiles(t.Context(), twoServicesProfiles())
+
+	// verify
+	assert.EqualError(t, res, fmt.Sprintf("unable to export profiles, unexpected exporter type: expected xexporter.Profiles but got %T", newNopMockExporter()))
+}
+
+func routingIDOfResourceProfiles(t *testing.T, rp pprofile.ResourceProfiles, routingKey string) string {
+	if routingKey == resourceRoutingStr {
+		return identity.OfResource(rp.Resource()).String()
+	}
+	svc, ok := rp.Resource().Attributes().Get(string(conventions.ServiceNameKey))
+	require.True(t, ok)
+	return svc.Str()
+}
+
+// twoServicesProfiles returns profiles of two services, whose sample types reference the service names in the
+// dictionary.
+func twoServicesProfiles() pprofile.Profiles {
+	pd := pprofile.NewProfiles()
+	strs := pd.Dictionary().StringTable()
+	strs.Append("")
+	for _, svc := range []string{serviceName1, serviceName2} {
+		rp := pd.ResourceProfiles().AppendEmpty()
+		rp.Resource().Attributes().PutStr(string(conventions.ServiceNameKey), svc)
+		profile := rp.ScopeProfiles().AppendEmpty().Profiles().AppendEmpty()
+		profile.SetProfileID(pprofile.ProfileID([16]byte{byte(strs.Len())}))
+		profile.SampleType().SetTypeStrindex(int32(strs.Len()))
+		profile.SetTime(pcommon.Timestamp(1))
+		strs.Append(svc)
+	}
+	return pd
+}
+
+type mockProfilesExporter struct {
+	component.Component
+	consumeProfilesFn func(ctx context.Context, pd pprofile.Profiles) error
+	consumeErr        error
+}
+
+func newMockProfilesExporter(consumeProfilesFn func(ctx context.Context, pd pprofile.Profiles) error) xexporter.Profiles {
+	return &mockProfilesExporter{
+		Component:         mockComponent{},
+		consumeProfilesFn: consumeProfilesFn,
+	}
+}
+
+func (*mockProfilesExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *mockProfilesExporter) Shutdown(context.Context) error {
+	e.consumeErr = errors.New("exporter is shut down")
+	return nil
+}
+
+func (e *mockProfilesExporter) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	if e.consumeProfilesFn == nil {
+		return e.consumeErr
+	}
+	return e.consumeProfilesFn(ctx, pd)
+}
@@ -10,8 +10,10 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/xexporter"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/otel/attribute"
 )
@@ -66,3 +68,11 @@ func (we *wrappedExporter) ConsumeLogs(ctx context.Context, ld plog.Logs) error
 	}
 	return le.ConsumeLogs(ctx, ld)
 }
+
+func (we *wrappedExporter) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	pe, ok := we.Component.(xexporter.Profiles)
+	if !ok {
+		return fmt.Errorf("unable to export profiles, unexpected exporter type: expected xexporter.Profiles but got %T", we.Component)
+	}
+	return pe.ConsumeProfiles(ctx, pd)
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// resourceColumns are the columns describing the resource of a row.
+type resourceColumns struct {
+	ResourceAttributes map[string]string `parquet:"resource_attributes"`
+	ResourceSchemaURL  string            `parquet:"resource_schema_url"`
+}
+
+func newResourceColumns(resource pcommon.Resource, schemaURL string) resourceColumns {
+	return resourceColumns{
+		ResourceAttributes: attributesToMap(resource.Attributes()),
+		ResourceSchemaURL:  schemaURL,
+	}
+}
+
+func (c *resourceColumns) equal(other *resourceColumns) bool {
+	return c.ResourceSchemaURL == other.ResourceSchemaURL && maps.Equal(c.ResourceAttributes, other.ResourceAttributes)
+}
+
+func (c *resourceColumns) copyTo(resource pcommon.Resource) {
+	putAttributes(resource.Attributes(), c.ResourceAttributes)
+}
+
+// scopeColumns are the columns describing the instrumentation scope of a row.
+type scopeColumns struct {
+	ScopeName       string            `parquet:"scope_name,dict"`
+	ScopeVersion    string            `parquet:"scope_version,dict"`
+	ScopeAttributes map[string]string `parquet:"scope_attributes"`
+	ScopeSchemaURL  string            `parquet:"scope_schema_url"`
+}
+
+func newScopeColumns(scope pcommon.InstrumentationScope, schemaURL string) scopeColumns {
+	return scopeColumns{
+		ScopeName:       scope.Name(),
+		ScopeVersion:    scope.Version(),
+		ScopeAttributes: attributesToMap(scope.Attributes()),
+		ScopeSchemaURL:  schemaURL,
+	}
+}
+
+func (c *scopeColumns) equal(other *scopeColumns) bool {
+	return c.ScopeName == other.ScopeName &&
+		c.ScopeVersion == other.ScopeVersion &&
+		c.ScopeSchemaURL == other.ScopeSchemaURL &&
+		maps.Equal(c.ScopeAttributes, other.ScopeAttributes)
+}
+
+func (c *scopeColumns) copyTo(scope pcommon.InstrumentationScope) {
+	scope.SetName(c.ScopeName)
+	scope.SetVersion(c.ScopeVersion)
+	putAttributes(scope.Attributes(), c.ScopeAttributes)
+}
+
+// attributesToMap flattens the attributes to their string representation, so that they can be
+// queried without decoding nested values.
+func attributesToMap(attributes pcommon.Map) map[string]string {
+	if attributes.Len() == 0 {
+		return nil
+	}
+	m := make(map[string]string, attributes.Len())
+	for k, v := range attributes.All() {
+		m[k] = v.AsString()
+	}
+	return m
+}
+
+// putAttributes sets the attributes as string values, in the order of their keys.
+func putAttributes(dst pcommon.Map, src map[string]string) {
+	dst.EnsureCapacity(len(src))
+	for _, k := range slices.Sorted(maps.Keys(src)) {
+		dst.PutStr(k, src[k])
+	}
+}
+
+func traceIDToHex(id pcommon.TraceID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return hex.EncodeToString(id[:])
+}
+
+func spanIDToHex(id pcommon.SpanID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return hex.EncodeToString(id[:])
+}
+
+func hexToTraceID(s string) (pcommon.TraceID, error) {
+	var id pcommon.TraceID
+	if err := decodeID(id[:], s); err != nil {
+		return id, fmt.Errorf("invalid trace id %q: %w", s, err)
+	}
+	return id, nil
+}
+
+func hexToSpanID(s string) (pcommon.SpanID, error) {
+	var id pcommon.SpanID
+	if err := decodeID(id[:], s); err != nil {
+		return id, fmt.Errorf("invalid span id %q: %w", s, err)
+	}
+	return id, nil
+}
+
+// decodeID decodes the hex encoded id into dst, leaving it empty when s is empty.
+func decodeID(dst []byte, s string) error {
+	if s == "" {
+		return nil
+	}
+	if hex.DecodedLen(len(s)) != len(dst) {
+		return fmt.Errorf("expected %d bytes", len(dst))
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err
+}
+
+// parseEnum returns the value whose string representation is s.
+func parseEnum[E fmt.Stringer](s string, values ...E) (E, error) {
+	for _, v := range values {
+		if v.String() == s {
+			return v, nil
+		}
+	}
+	var zero E
+	return zero, fmt.Errorf("unknown value %q", s)
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+)
+
+const (
+	compressionNone   = "none"
+	compressionSnappy = "snappy"
+	compressionGzip   = "gzip"
+	compressionZstd   = "zstd"
+)
+
+var _ xconfmap.Validator = (*Config)(nil)
+
+type Config struct {
+	// Compression is the codec used to compress the column chunks of the Parquet files.
+	// One of none, snappy, gzip or zstd.
+	Compression string `mapstructure:"compression"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (c *Config) Validate() error {
+	switch c.Compression {
+	case compressionNone, compressionSnappy, compressionGzip, compressionZstd:
+		return nil
+	}
+	return fmt.Errorf("unsupported compression: %q", c.Compression)
+}
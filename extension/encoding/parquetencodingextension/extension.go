@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding"
+)
+
+var (
+	_ encoding.TracesMarshalerExtension    = (*parquetExtension)(nil)
+	_ encoding.TracesUnmarshalerExtension  = (*parquetExtension)(nil)
+	_ encoding.LogsMarshalerExtension      = (*parquetExtension)(nil)
+	_ encoding.LogsUnmarshalerExtension    = (*parquetExtension)(nil)
+	_ encoding.MetricsMarshalerExtension   = (*parquetExtension)(nil)
+	_ encoding.MetricsUnmarshalerExtension = (*parquetExtension)(nil)
+)
+
+type parquetExtension struct {
+	config *Config
+	codec  compress.Codec
+}
+
+func newExtension(config *Config) (*parquetExtension, error) {
+	var codec compress.Codec
+	switch config.Compression {
+	case compressionNone:
+		codec = &parquet.Uncompressed
+	case compressionSnappy:
+		codec = &parquet.Snappy
+	case compressionGzip:
+		codec = &parquet.Gzip
+	case compressionZstd:
+		codec = &parquet.Zstd
+	default:
+		return nil, fmt.Errorf("unsupported compression: %q", config.Compression)
+	}
+	return &parquetExtension{config: config, codec: codec}, nil
+}
+
+// writeRows encodes the rows as a Parquet file.
+func writeRows[T any](rows []T, codec compress.Codec) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := parquet.Write(&buf, rows, parquet.Compression(codec)); err != nil {
+		return nil, fmt.Errorf("failed to write the Parquet file: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// readRows decodes the rows of a Parquet file.
+func readRows[T any](buf []byte) ([]T, error) {
+	rows, err := parquet.Read[T](bytes.NewReader(buf), int64(len(buf)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the Parquet file: %w", err)
+	}
+	return rows, nil
+}
+
+func (*parquetExtension) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (*parquetExtension) Shutdown(context.Context) error {
+	return nil
+}
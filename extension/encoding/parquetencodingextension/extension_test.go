@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	for _, compression := range []string{"none", "snappy", "gzip", "zstd"} {
+		cfg := &Config{Compression: compression}
+		assert.NoError(t, cfg.Validate(), compression)
+	}
+
+	cfg := &Config{Compression: "lzo"}
+	assert.EqualError(t, cfg.Validate(), `unsupported compression: "lzo"`)
+}
+
+func TestExtension_Start(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.Equal(t, compressionZstd, cfg.(*Config).Compression)
+
+	ext, err := factory.Create(t.Context(), extensiontest.NewNopSettings(factory.Type()), cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(t.Context(), componenttest.NewNopHost()))
+	require.NoError(t, ext.Shutdown(t.Context()))
+}
+
+func TestExtension_Compression(t *testing.T) {
+	for _, compression := range []string{"none", "snappy", "gzip", "zstd"} {
+		t.Run(compression, func(t *testing.T) {
+			ex, err := newExtension(&Config{Compression: compression})
+			require.NoError(t, err)
+
+			logs := generateLogs()
+			buf, err := ex.MarshalLogs(logs)
+			require.NoError(t, err)
+
+			file, err := parquet.OpenFile(bytes.NewReader(buf), int64(len(buf)))
+			require.NoError(t, err)
+			assert.Equal(t, int64(logs.LogRecordCount()), file.NumRows())
+			for _, rowGroup := range file.Metadata().RowGroups {
+				for _, column := range rowGroup.Columns {
+					assert.Equal(t, ex.codec.CompressionCodec(), column.MetaData.Codec)
+				}
+			}
+
+			actual, err := ex.UnmarshalLogs(buf)
+			require.NoError(t, err)
+			assert.Equal(t, logs, actual)
+		})
+	}
+}
+
+func TestExtension_UnmarshalInvalid(t *testing.T) {
+	ex, err := newExtension(&Config{Compression: compressionZstd})
+	require.NoError(t, err)
+
+	_, err = ex.UnmarshalLogs([]byte("not parquet"))
+	assert.ErrorContains(t, err, "failed to read the Parquet file")
+	_, err = ex.UnmarshalMetrics([]byte("not parquet"))
+	assert.ErrorContains(t, err, "failed to read the Parquet file")
+	_, err = ex.UnmarshalTraces([]byte("not parquet"))
+	assert.ErrorContains(t, err, "failed to read the Parquet file")
+}
@@ -0,0 +1,13 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package parquetencodingextension
+
+import (
+	"testing"
+
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// logRow is a log record, along with its resource and scope.
+type logRow struct {
+	resourceColumns
+	scopeColumns
+	Timestamp         int64             `parquet:"timestamp,timestamp(nanosecond)"`
+	ObservedTimestamp int64             `parquet:"observed_timestamp,timestamp(nanosecond)"`
+	SeverityNumber    int32             `parquet:"severity_number"`
+	SeverityText      string            `parquet:"severity_text,dict"`
+	Body              string            `parquet:"body"`
+	Attributes        map[string]string `parquet:"attributes"`
+	TraceID           string            `parquet:"trace_id"`
+	SpanID            string            `parquet:"span_id"`
+	Flags             uint32            `parquet:"flags"`
+	EventName         string            `parquet:"event_name"`
+}
+
+func (ex *parquetExtension) MarshalLogs(logs plog.Logs) ([]byte, error) {
+	rows := make([]logRow, 0, logs.LogRecordCount())
+	for _, rl := range logs.ResourceLogs().All() {
+		resource := newResourceColumns(rl.Resource(), rl.SchemaUrl())
+		for _, sl := range rl.ScopeLogs().All() {
+			scope := newScopeColumns(sl.Scope(), sl.SchemaUrl())
+			for _, lr := range sl.LogRecords().All() {
+				rows = append(rows, logRow{
+					resourceColumns:   resource,
+					scopeColumns:      scope,
+					Timestamp:         int64(lr.Timestamp()),
+					ObservedTimestamp: int64(lr.ObservedTimestamp()),
+					SeverityNumber:    int32(lr.SeverityNumber()),
+					SeverityText:      lr.SeverityText(),
+					Body:              lr.Body().AsString(),
+					Attributes:        attributesToMap(lr.Attributes()),
+					TraceID:           traceIDToHex(lr.TraceID()),
+					SpanID:            spanIDToHex(lr.SpanID()),
+					Flags:             uint32(lr.Flags()),
+					EventName:         lr.EventName(),
+				})
+			}
+		}
+	}
+	return writeRows(rows, ex.codec)
+}
+
+func (*parquetExtension) UnmarshalLogs(buf []byte) (plog.Logs, error) {
+	rows, err := readRows[logRow](buf)
+	if err != nil {
+		return plog.Logs{}, err
+	}
+
+	logs := plog.NewLogs()
+	var rl plog.ResourceLogs
+	var sl plog.ScopeLogs
+	for i := range rows {
+		row := &rows[i]
+		newResource := i == 0 || !row.resourceColumns.equal(&rows[i-1].resourceColumns)
+		if newResource {
+			rl = logs.ResourceLogs().AppendEmpty()
+			row.resourceColumns.copyTo(rl.Resource())
+			rl.SetSchemaUrl(row.ResourceSchemaURL)
+		}
+		if newResource || !row.scopeColumns.equal(&rows[i-1].scopeColumns) {
+			sl = rl.ScopeLogs().AppendEmpty()
+			row.scopeColumns.copyTo(sl.Scope())
+			sl.SetSchemaUrl(row.ScopeSchemaURL)
+		}
+
+		if err := row.copyTo(sl.LogRecords().AppendEmpty()); err != nil {
+			return plog.Logs{}, err
+		}
+	}
+	return logs, nil
+}
+
+func (row *logRow) copyTo(lr plog.LogRecord) error {
+	lr.SetTimestamp(pcommon.Timestamp(row.Timestamp))
+	lr.SetObservedTimestamp(pcommon.Timestamp(row.ObservedTimestamp))
+	lr.SetSeverityNumber(plog.SeverityNumber(row.SeverityNumber))
+	lr.SetSeverityText(row.SeverityText)
+	if row.Body != "" {
+		lr.Body().SetStr(row.Body)
+	}
+	putAttributes(lr.Attributes(), row.Attributes)
+	traceID, err := hexToTraceID(row.TraceID)
+	if err != nil {
+		return err
+	}
+	lr.SetTraceID(traceID)
+	spanID, err := hexToSpanID(row.SpanID)
+	if err != nil {
+		return err
+	}
+	lr.SetSpanID(spanID)
+	lr.SetFlags(plog.LogRecordFlags(row.Flags))
+	lr.SetEventName(row.EventName)
+	return nil
+}
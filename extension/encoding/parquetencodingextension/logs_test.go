@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+var (
+	testTraceID = pcommon.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10}
+	testSpanID  = pcommon.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+	testTime    = time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+)
+
+func generateLogs() plog.Logs {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl("https://opentelemetry.io/schemas/1.26.0")
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("checkout.logger")
+	sl.Scope().SetVersion("1.0.0")
+
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(testTime.Add(time.Second)))
+	lr.SetSeverityNumber(plog.SeverityNumberError)
+	lr.SetSeverityText("ERROR")
+	lr.Body().SetStr("payment declined")
+	lr.Attributes().PutStr("order.id", "1234")
+	lr.SetTraceID(testTraceID)
+	lr.SetSpanID(testSpanID)
+	lr.SetFlags(plog.DefaultLogRecordFlags.WithIsSampled(true))
+	lr.SetEventName("payment.declined")
+
+	sl.LogRecords().AppendEmpty().Body().SetStr("retrying")
+
+	sl = rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("http.logger")
+	sl.LogRecords().AppendEmpty().Body().SetStr("request received")
+
+	rl = logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "cart")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("cart updated")
+	return logs
+}
+
+func TestMarshalLogs(t *testing.T) {
+	ex, err := newExtension(&Config{Compression: compressionZstd})
+	require.NoError(t, err)
+
+	buf, err := ex.MarshalLogs(generateLogs())
+	require.NoError(t, err)
+
+	rows, err := parquet.Read[logRow](bytes.NewReader(buf), int64(len(buf)))
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	assert.Equal(t, logRow{
+		resourceColumns: resourceColumns{
+			ResourceAttributes: map[string]string{"service.name": "checkout"},
+			ResourceSchemaURL:  "https://opentelemetry.io/schemas/1.26.0",
+		},
+		scopeColumns: scopeColumns{
+			ScopeName:       "checkout.logger",
+			ScopeVersion:    "1.0.0",
+			ScopeAttributes: map[string]string{},
+		},
+		Timestamp:         testTime.UnixNano(),
+		ObservedTimestamp: testTime.Add(time.Second).UnixNano(),
+		SeverityNumber:    int32(plog.SeverityNumberError),
+		SeverityText:      "ERROR",
+		Body:              "payment declined",
+		Attributes:        map[string]string{"order.id": "1234"},
+		TraceID:           "0102030405060708090a0b0c0d0e0f10",
+		SpanID:            "0102030405060708",
+		Flags:             1,
+		EventName:         "payment.declined",
+	}, rows[0])
+	assert.Equal(t, "cart", rows[3].ResourceAttributes["service.name"])
+}
+
+func TestUnmarshalLogs(t *testing.T) {
+	ex, err := newExtension(&Config{Compression: compressionZstd})
+	require.NoError(t, err)
+
+	logs := generateLogs()
+	buf, err := ex.MarshalLogs(logs)
+	require.NoError(t, err)
+
+	actual, err := ex.UnmarshalLogs(buf)
+	require.NoError(t, err)
+	assert.Equal(t, logs, actual)
+
+	// Attributes and bodies that are not strings are read back as strings
+	logs = plog.NewLogs()
+	lr := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Body().SetEmptyMap().PutInt("status", 500)
+	lr.Attributes().PutBool("retry", true)
+	buf, err = ex.MarshalLogs(logs)
+	require.NoError(t, err)
+
+	actual, err = ex.UnmarshalLogs(buf)
+	require.NoError(t, err)
+	actualRecord := actual.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, `{"status":500}`, actualRecord.Body().Str())
+	assert.Equal(t, map[string]any{"retry": "true"}, actualRecord.Attributes().AsRaw())
+}
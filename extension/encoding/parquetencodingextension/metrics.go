@@ -0,0 +1,337 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+var (
+	metricTypes = []pmetric.MetricType{
+		pmetric.MetricTypeGauge,
+		pmetric.MetricTypeSum,
+		pmetric.MetricTypeHistogram,
+		pmetric.MetricTypeExponentialHistogram,
+		pmetric.MetricTypeSummary,
+	}
+	aggregationTemporalities = []pmetric.AggregationTemporality{
+		pmetric.AggregationTemporalityUnspecified,
+		pmetric.AggregationTemporalityDelta,
+		pmetric.AggregationTemporalityCumulative,
+	}
+)
+
+// metricColumns are the columns describing the metric of a data point.
+type metricColumns struct {
+	MetricName             string `parquet:"metric_name,dict"`
+	MetricDescription      string `parquet:"metric_description,dict"`
+	MetricUnit             string `parquet:"metric_unit,dict"`
+	MetricType             string `parquet:"metric_type,dict"`
+	AggregationTemporality string `parquet:"aggregation_temporality,dict"`
+	IsMonotonic            bool   `parquet:"is_monotonic"`
+}
+
+// dataPointRow is a data point of any metric type, along with its metric, resource and scope.
+// Only the columns of the metric type are set.
+type dataPointRow struct {
+	resourceColumns
+	scopeColumns
+	metricColumns
+	StartTimestamp int64             `parquet:"start_timestamp,timestamp(nanosecond)"`
+	Timestamp      int64             `parquet:"timestamp,timestamp(nanosecond)"`
+	Attributes     map[string]string `parquet:"attributes"`
+	Flags          uint32            `parquet:"flags"`
+
+	// Gauge and Sum
+	ValueDouble *float64 `parquet:"value_double,optional"`
+	ValueInt    *int64   `parquet:"value_int,optional"`
+
+	// Histogram, ExponentialHistogram and Summary
+	Count uint64   `parquet:"count"`
+	Sum   *float64 `parquet:"sum,optional"`
+	Min   *float64 `parquet:"min,optional"`
+	Max   *float64 `parquet:"max,optional"`
+
+	// Histogram
+	BucketCounts   []uint64  `parquet:"bucket_counts,list"`
+	ExplicitBounds []float64 `parquet:"explicit_bounds,list"`
+
+	// ExponentialHistogram
+	Scale                int32    `parquet:"scale"`
+	ZeroCount            uint64   `parquet:"zero_count"`
+	ZeroThreshold        float64  `parquet:"zero_threshold"`
+	PositiveOffset       int32    `parquet:"positive_offset"`
+	PositiveBucketCounts []uint64 `parquet:"positive_bucket_counts,list"`
+	NegativeOffset       int32    `parquet:"negative_offset"`
+	NegativeBucketCounts []uint64 `parquet:"negative_bucket_counts,list"`
+
+	// Summary
+	QuantileValues []quantileValue `parquet:"quantile_values,list"`
+}
+
+type quantileValue struct {
+	Quantile float64 `parquet:"quantile"`
+	Value    float64 `parquet:"value"`
+}
+
+func newMetricColumns(metric pmetric.Metric) metricColumns {
+	c := metricColumns{
+		MetricName:        metric.Name(),
+		MetricDescription: metric.Description(),
+		MetricUnit:        metric.Unit(),
+		MetricType:        metric.Type().String(),
+	}
+	switch metric.Type() {
+	case pmetric.MetricTypeSum:
+		c.AggregationTemporality = metric.Sum().AggregationTemporality().String()
+		c.IsMonotonic = metric.Sum().IsMonotonic()
+	case pmetric.MetricTypeHistogram:
+		c.AggregationTemporality = metric.Histogram().AggregationTemporality().String()
+	case pmetric.MetricTypeExponentialHistogram:
+		c.AggregationTemporality = metric.ExponentialHistogram().AggregationTemporality().String()
+	}
+	return c
+}
+
+// copyTo sets the metric fields, creating the data of the metric type.
+func (c *metricColumns) copyTo(metric pmetric.Metric) error {
+	metric.SetName(c.MetricName)
+	metric.SetDescription(c.MetricDescription)
+	metric.SetUnit(c.MetricUnit)
+
+	metricType, err := parseEnum(c.MetricType, metricTypes...)
+	if err != nil {
+		return fmt.Errorf("invalid metric type: %w", err)
+	}
+	var temporality pmetric.AggregationTemporality
+	if c.AggregationTemporality != "" {
+		if temporality, err = parseEnum(c.AggregationTemporality, aggregationTemporalities...); err != nil {
+			return fmt.Errorf("invalid aggregation temporality: %w", err)
+		}
+	}
+	switch metricType {
+	case pmetric.MetricTypeGauge:
+		metric.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		metric.SetEmptySum().SetAggregationTemporality(temporality)
+		metric.Sum().SetIsMonotonic(c.IsMonotonic)
+	case pmetric.MetricTypeHistogram:
+		metric.SetEmptyHistogram().SetAggregationTemporality(temporality)
+	case pmetric.MetricTypeExponentialHistogram:
+		metric.SetEmptyExponentialHistogram().SetAggregationTemporality(temporality)
+	case pmetric.MetricTypeSummary:
+		metric.SetEmptySummary()
+	}
+	return nil
+}
+
+func (ex *parquetExtension) MarshalMetrics(metrics pmetric.Metrics) ([]byte, error) {
+	rows := make([]dataPointRow, 0, metrics.DataPointCount())
+	for _, rm := range metrics.ResourceMetrics().All() {
+		resource := newResourceColumns(rm.Resource(), rm.SchemaUrl())
+		for _, sm := range rm.ScopeMetrics().All() {
+			scope := newScopeColumns(sm.Scope(), sm.SchemaUrl())
+			for _, metric := range sm.Metrics().All() {
+				columns := newMetricColumns(metric)
+				newRow := func(start, ts pcommon.Timestamp, attributes pcommon.Map, flags pmetric.DataPointFlags) dataPointRow {
+					return dataPointRow{
+						resourceColumns: resource,
+						scopeColumns:    scope,
+						metricColumns:   columns,
+						StartTimestamp:  int64(start),
+						Timestamp:       int64(ts),
+						Attributes:      attributesToMap(attributes),
+						Flags:           uint32(flags),
+					}
+				}
+
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					rows = appendNumberDataPoints(rows, metric.Gauge().DataPoints(), newRow)
+				case pmetric.MetricTypeSum:
+					rows = appendNumberDataPoints(rows, metric.Sum().DataPoints(), newRow)
+				case pmetric.MetricTypeHistogram:
+					for _, dp := range metric.Histogram().DataPoints().All() {
+						row := newRow(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+						row.Count = dp.Count()
+						row.Sum, row.Min, row.Max = optionalValue(dp.HasSum(), dp.Sum()), optionalValue(dp.HasMin(), dp.Min()), optionalValue(dp.HasMax(), dp.Max())
+						row.BucketCounts = dp.BucketCounts().AsRaw()
+						row.ExplicitBounds = dp.ExplicitBounds().AsRaw()
+						rows = append(rows, row)
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					for _, dp := range metric.ExponentialHistogram().DataPoints().All() {
+						row := newRow(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+						row.Count = dp.Count()
+						row.Sum, row.Min, row.Max = optionalValue(dp.HasSum(), dp.Sum()), optionalValue(dp.HasMin(), dp.Min()), optionalValue(dp.HasMax(), dp.Max())
+						row.Scale = dp.Scale()
+						row.ZeroCount = dp.ZeroCount()
+						row.ZeroThreshold = dp.ZeroThreshold()
+						row.PositiveOffset = dp.Positive().Offset()
+						row.PositiveBucketCounts = dp.Positive().BucketCounts().AsRaw()
+						row.NegativeOffset = dp.Negative().Offset()
+						row.NegativeBucketCounts = dp.Negative().BucketCounts().AsRaw()
+						rows = append(rows, row)
+					}
+				case pmetric.MetricTypeSummary:
+					for _, dp := range metric.Summary().DataPoints().All() {
+						row := newRow(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+						row.Count = dp.Count()
+						row.Sum = optionalValue(true, dp.Sum())
+						for _, q := range dp.QuantileValues().All() {
+							row.QuantileValues = append(row.QuantileValues, quantileValue{Quantile: q.Quantile(), Value: q.Value()})
+						}
+						rows = append(rows, row)
+					}
+				}
+			}
+		}
+	}
+	return writeRows(rows, ex.codec)
+}
+
+func appendNumberDataPoints(
+	rows []dataPointRow,
+	dps pmetric.NumberDataPointSlice,
+	newRow func(start, ts pcommon.Timestamp, attributes pcommon.Map, flags pmetric.DataPointFlags) dataPointRow,
+) []dataPointRow {
+	for _, dp := range dps.All() {
+		row := newRow(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeDouble:
+			row.ValueDouble = optionalValue(true, dp.DoubleValue())
+		case pmetric.NumberDataPointValueTypeInt:
+			row.ValueInt = optionalValue(true, dp.IntValue())
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// optionalValue returns a pointer to v if it is set.
+func optionalValue[T any](set bool, v T) *T {
+	if !set {
+		return nil
+	}
+	return &v
+}
+
+func (*parquetExtension) UnmarshalMetrics(buf []byte) (pmetric.Metrics, error) {
+	rows, err := readRows[dataPointRow](buf)
+	if err != nil {
+		return pmetric.Metrics{}, err
+	}
+
+	metrics := pmetric.NewMetrics()
+	var rm pmetric.ResourceMetrics
+	var sm pmetric.ScopeMetrics
+	var metric pmetric.Metric
+	for i := range rows {
+		row := &rows[i]
+		newResource := i == 0 || !row.resourceColumns.equal(&rows[i-1].resourceColumns)
+		if newResource {
+			rm = metrics.ResourceMetrics().AppendEmpty()
+			row.resourceColumns.copyTo(rm.Resource())
+			rm.SetSchemaUrl(row.ResourceSchemaURL)
+		}
+		newScope := newResource || !row.scopeColumns.equal(&rows[i-1].scopeColumns)
+		if newScope {
+			sm = rm.ScopeMetrics().AppendEmpty()
+			row.scopeColumns.copyTo(sm.Scope())
+			sm.SetSchemaUrl(row.ScopeSchemaURL)
+		}
+		if newScope || row.metricColumns != rows[i-1].metricColumns {
+			metric = sm.Metrics().AppendEmpty()
+			if err := row.metricColumns.copyTo(metric); err != nil {
+				return pmetric.Metrics{}, err
+			}
+		}
+
+		row.copyTo(metric)
+	}
+	return metrics, nil
+}
+
+// copyTo appends the data point to the data of the metric.
+func (row *dataPointRow) copyTo(metric pmetric.Metric) {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		row.copyToNumberDataPoint(metric.Gauge().DataPoints().AppendEmpty())
+	case pmetric.MetricTypeSum:
+		row.copyToNumberDataPoint(metric.Sum().DataPoints().AppendEmpty())
+	case pmetric.MetricTypeHistogram:
+		dp := metric.Histogram().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pcommon.Timestamp(row.StartTimestamp))
+		dp.SetTimestamp(pcommon.Timestamp(row.Timestamp))
+		putAttributes(dp.Attributes(), row.Attributes)
+		dp.SetFlags(pmetric.DataPointFlags(row.Flags))
+		dp.SetCount(row.Count)
+		if row.Sum != nil {
+			dp.SetSum(*row.Sum)
+		}
+		if row.Min != nil {
+			dp.SetMin(*row.Min)
+		}
+		if row.Max != nil {
+			dp.SetMax(*row.Max)
+		}
+		dp.BucketCounts().FromRaw(row.BucketCounts)
+		dp.ExplicitBounds().FromRaw(row.ExplicitBounds)
+	case pmetric.MetricTypeExponentialHistogram:
+		dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pcommon.Timestamp(row.StartTimestamp))
+		dp.SetTimestamp(pcommon.Timestamp(row.Timestamp))
+		putAttributes(dp.Attributes(), row.Attributes)
+		dp.SetFlags(pmetric.DataPointFlags(row.Flags))
+		dp.SetCount(row.Count)
+		if row.Sum != nil {
+			dp.SetSum(*row.Sum)
+		}
+		if row.Min != nil {
+			dp.SetMin(*row.Min)
+		}
+		if row.Max != nil {
+			dp.SetMax(*row.Max)
+		}
+		dp.SetScale(row.Scale)
+		dp.SetZeroCount(row.ZeroCount)
+		dp.SetZeroThreshold(row.ZeroThreshold)
+		dp.Positive().SetOffset(row.PositiveOffset)
+		dp.Positive().BucketCounts().FromRaw(row.PositiveBucketCounts)
+		dp.Negative().SetOffset(row.NegativeOffset)
+		dp.Negative().BucketCounts().FromRaw(row.NegativeBucketCounts)
+	case pmetric.MetricTypeSummary:
+		dp := metric.Summary().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pcommon.Timestamp(row.StartTimestamp))
+		dp.SetTimestamp(pcommon.Timestamp(row.Timestamp))
+		putAttributes(dp.Attributes(), row.Attributes)
+		dp.SetFlags(pmetric.DataPointFlags(row.Flags))
+		dp.SetCount(row.Count)
+		if row.Sum != nil {
+			dp.SetSum(*row.Sum)
+		}
+		dp.QuantileValues().EnsureCapacity(len(row.QuantileValues))
+		for _, qv := range row.QuantileValues {
+			q := dp.QuantileValues().AppendEmpty()
+			q.SetQuantile(qv.Quantile)
+			q.SetValue(qv.Value)
+		}
+	}
+}
+
+func (row *dataPointRow) copyToNumberDataPoint(dp pmetric.NumberDataPoint) {
+	dp.SetStartTimestamp(pcommon.Timestamp(row.StartTimestamp))
+	dp.SetTimestamp(pcommon.Timestamp(row.Timestamp))
+	putAttributes(dp.Attributes(), row.Attributes)
+	dp.SetFlags(pmetric.DataPointFlags(row.Flags))
+	switch {
+	case row.ValueDouble != nil:
+		dp.SetDoubleValue(*row.ValueDouble)
+	case row.ValueInt != nil:
+		dp.SetIntValue(*row.ValueInt)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func generateMetrics() pmetric.Metrics {
+	start := pcommon.NewTimestampFromTime(testTime)
+	ts := pcommon.NewTimestampFromTime(testTime.Add(time.Minute))
+
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("checkout.meter")
+
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("process.memory.usage")
+	gauge.SetUnit("By")
+	dp := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(1024)
+
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("orders")
+	sum.SetDescription("The number of orders")
+	sum.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.Sum().SetIsMonotonic(true)
+	for _, status := range []string{"completed", "declined"} {
+		dp = sum.Sum().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(ts)
+		dp.SetDoubleValue(12.5)
+		dp.Attributes().PutStr("status", status)
+	}
+
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("http.server.request.duration")
+	histogram.SetUnit("s")
+	hdp := histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+	histogram.Histogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	hdp.SetStartTimestamp(start)
+	hdp.SetTimestamp(ts)
+	hdp.SetCount(6)
+	hdp.SetSum(1.5)
+	hdp.SetMin(0.1)
+	hdp.SetMax(0.6)
+	hdp.BucketCounts().FromRaw([]uint64{1, 2, 3})
+	hdp.ExplicitBounds().FromRaw([]float64{0.25, 0.5})
+
+	exponential := sm.Metrics().AppendEmpty()
+	exponential.SetName("queue.latency")
+	edp := exponential.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	exponential.ExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	edp.SetStartTimestamp(start)
+	edp.SetTimestamp(ts)
+	edp.SetCount(7)
+	edp.SetSum(3.5)
+	edp.SetScale(2)
+	edp.SetZeroCount(1)
+	edp.SetZeroThreshold(0.001)
+	edp.Positive().SetOffset(1)
+	edp.Positive().BucketCounts().FromRaw([]uint64{2, 3})
+	edp.Negative().SetOffset(-1)
+	edp.Negative().BucketCounts().FromRaw([]uint64{1})
+	edp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+
+	summary := sm.Metrics().AppendEmpty()
+	summary.SetName("gc.pause")
+	sdp := summary.SetEmptySummary().DataPoints().AppendEmpty()
+	sdp.SetTimestamp(ts)
+	sdp.SetCount(10)
+	sdp.SetSum(20)
+	q := sdp.QuantileValues().AppendEmpty()
+	q.SetQuantile(0.99)
+	q.SetValue(4)
+
+	rm = metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "payment")
+	gauge = rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	gauge.SetName("process.memory.usage")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(2048)
+	return metrics
+}
+
+func TestMarshalMetrics(t *testing.T) {
+	ex, err := newExtension(&Config{Compression: compressionZstd})
+	require.NoError(t, err)
+
+	buf, err := ex.MarshalMetrics(generateMetrics())
+	require.NoError(t, err)
+
+	rows, err := parquet.Read[dataPointRow](bytes.NewReader(buf), int64(len(buf)))
+	require.NoError(t, err)
+	require.Len(t, rows, 7)
+
+	int64Value, float64Value := int64(1024), 12.5
+	assert.Equal(t, metricColumns{MetricName: "process.memory.usage", MetricUnit: "By", MetricType: "Gauge"}, rows[0].metricColumns)
+	assert.Equal(t, &int64Value, rows[0].ValueInt)
+	assert.Nil(t, rows[0].ValueDouble)
+
+	assert.Equal(t, metricColumns{
+		MetricName:             "orders",
+		MetricDescription:      "The number of orders",
+		MetricType:             "Sum",
+		AggregationTemporality: "Cumulative",
+		IsMonotonic:            true,
+	}, rows[1].metricColumns)
+	assert.Equal(t, &float64Value, rows[1].ValueDouble)
+	assert.Equal(t, map[string]string{"status": "declined"}, rows[2].Attributes)
+
+	assert.Equal(t, "Histogram", rows[3].MetricType)
+	assert.Equal(t, uint64(6), rows[3].Count)
+	assert.Equal(t, []uint64{1, 2, 3}, rows[3].BucketCounts)
+	assert.Equal(t, []float64{0.25, 0.5}, rows[3].ExplicitBounds)
+
+	assert.Equal(t, "ExponentialHistogram", rows[4].MetricType)
+	assert.Nil(t, rows[4].Min)
+	assert.Equal(t, []uint64{2, 3}, rows[4].PositiveBucketCounts)
+
+	assert.Equal(t, "Summary", rows[5].MetricType)
+	assert.Equal(t, []quantileValue{{Quantile: 0.99, Value: 4}}, rows[5].QuantileValues)
+
+	assert.Equal(t, "payment", rows[6].ResourceAttributes["service.name"])
+}
+
+func TestUnmarshalMetrics(t *testing.T) {
+	ex, err := newExtension(&Config{Compression: compressionZstd})
+	require.NoError(t, err)
+
+	metrics := generateMetrics()
+	buf, err := ex.MarshalMetrics(metrics)
+	require.NoError(t, err)
+
+	actual, err := ex.UnmarshalMetrics(buf)
+	require.NoError(t, err)
+	assert.Equal(t, metrics, actual)
+}
+
+func TestUnmarshalMetrics_InvalidRow(t *testing.T) {
+	ex, err := newExtension(&Config{Compression: compressionZstd})
+	require.NoError(t, err)
+
+	for name, row := range map[string]dataPointRow{
+		"Invalid metric type": {metricColumns: metricColumns{MetricType: "Counter"}},
+		"Invalid temporality": {metricColumns: metricColumns{MetricType: "Sum", AggregationTemporality: "Windowed"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			buf, err := writeRows([]dataPointRow{row}, ex.codec)
+			require.NoError(t, err)
+			_, err = ex.UnmarshalMetrics(buf)
+			assert.Error(t, err)
+		})
+	}
+}
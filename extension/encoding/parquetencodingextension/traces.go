@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	spanKinds = []ptrace.SpanKind{
+		ptrace.SpanKindUnspecified,
+		ptrace.SpanKindInternal,
+		ptrace.SpanKindServer,
+		ptrace.SpanKindClient,
+		ptrace.SpanKindProducer,
+		ptrace.SpanKindConsumer,
+	}
+	statusCodes = []ptrace.StatusCode{
+		ptrace.StatusCodeUnset,
+		ptrace.StatusCodeOk,
+		ptrace.StatusCodeError,
+	}
+)
+
+// spanRow is a span, along with its resource and scope.
+type spanRow struct {
+	resourceColumns
+	scopeColumns
+	TraceID        string            `parquet:"trace_id"`
+	SpanID         string            `parquet:"span_id"`
+	ParentSpanID   string            `parquet:"parent_span_id"`
+	TraceState     string            `parquet:"trace_state"`
+	Name           string            `parquet:"name,dict"`
+	Kind           string            `parquet:"kind,dict"`
+	StartTimestamp int64             `parquet:"start_timestamp,timestamp(nanosecond)"`
+	EndTimestamp   int64             `parquet:"end_timestamp,timestamp(nanosecond)"`
+	Duration       int64             `parquet:"duration"`
+	StatusCode     string            `parquet:"status_code,dict"`
+	StatusMessage  string            `parquet:"status_message"`
+	Attributes     map[string]string `parquet:"attributes"`
+	Events         []spanEvent       `parquet:"events,list"`
+	Links          []spanLink        `parquet:"links,list"`
+	Flags          uint32            `parquet:"flags"`
+}
+
+type spanEvent struct {
+	Timestamp  int64             `parquet:"timestamp,timestamp(nanosecond)"`
+	Name       string            `parquet:"name"`
+	Attributes map[string]string `parquet:"attributes"`
+}
+
+type spanLink struct {
+	TraceID    string            `parquet:"trace_id"`
+	SpanID     string            `parquet:"span_id"`
+	TraceState string            `parquet:"trace_state"`
+	Attributes map[string]string `parquet:"attributes"`
+	Flags      uint32            `parquet:"flags"`
+}
+
+func (ex *parquetExtension) MarshalTraces(traces ptrace.Traces) ([]byte, error) {
+	rows := make([]spanRow, 0, traces.SpanCount())
+	for _, rs := range traces.ResourceSpans().All() {
+		resource := newResourceColumns(rs.Resource(), rs.SchemaUrl())
+		for _, ss := range rs.ScopeSpans().All() {
+			scope := newScopeColumns(ss.Scope(), ss.SchemaUrl())
+			for _, span := range ss.Spans().All() {
+				row := spanRow{
+					resourceColumns: resource,
+					scopeColumns:    scope,
+					TraceID:         traceIDToHex(span.TraceID()),
+					SpanID:          spanIDToHex(span.SpanID()),
+					ParentSpanID:    spanIDToHex(span.ParentSpanID()),
+					TraceState:      span.TraceState().AsRaw(),
+					Name:            span.Name(),
+					Kind:            span.Kind().String(),
+					StartTimestamp:  int64(span.StartTimestamp()),
+					EndTimestamp:    int64(span.EndTimestamp()),
+					Duration:        int64(span.EndTimestamp()) - int64(span.StartTimestamp()),
+					StatusCode:      span.Status().Code().String(),
+					StatusMessage:   span.Status().Message(),
+					Attributes:      attributesToMap(span.Attributes()),
+					Flags:           span.Flags(),
+				}
+				for _, event := range span.Events().All() {
+					row.Events = append(row.Events, spanEvent{
+						Timestamp:  int64(event.Timestamp()),
+						Name:       event.Name(),
+						Attributes: attributesToMap(event.Attributes()),
+					})
+				}
+				for _, link := range span.Links().All() {
+					row.Links = append(row.Links, spanLink{
+						TraceID:    traceIDToHex(link.TraceID()),
+						SpanID:     spanIDToHex(link.SpanID()),
+						TraceState: link.TraceState().AsRaw(),
+						Attributes: attributesToMap(link.Attributes()),
+						Flags:      link.Flags(),
+					})
+				}
+				rows = append(rows, row)
+			}
+		}
+	}
+	return writeRows(rows, ex.codec)
+}
+
+func (*parquetExtension) UnmarshalTraces(buf []byte) (ptrace.Traces, error) {
+	rows, err := readRows[spanRow](buf)
+	if err != nil {
+		return ptrace.Traces{}, err
+	}
+
+	traces := ptrace.NewTraces()
+	var rs ptrace.ResourceSpans
+	var ss ptrace.ScopeSpans
+	for i := range rows {
+		row := &rows[i]
+		newResource := i == 0 || !row.resourceColumns.equal(&rows[i-1].resourceColumns)
+		if newResource {
+			rs = traces.ResourceSpans().AppendEmpty()
+			row.resourceColumns.copyTo(rs.Resource())
+			rs.SetSchemaUrl(row.ResourceSchemaURL)
+		}
+		if newResource || !row.scopeColumns.equal(&rows[i-1].scopeColumns) {
+			ss = rs.ScopeSpans().AppendEmpty()
+			row.scopeColumns.copyTo(ss.Scope())
+			ss.SetSchemaUrl(row.ScopeSchemaURL)
+		}
+
+		if err := row.copyTo(ss.Spans().AppendEmpty()); err != nil {
+			return ptrace.Traces{}, err
+		}
+	}
+	return traces, nil
+}
+
+func (row *spanRow) copyTo(span ptrace.Span) error {
+	traceID, err := hexToTraceID(row.TraceID)
+	if err != nil {
+		return err
+	}
+	span.SetTraceID(traceID)
+	spanID, err := hexToSpanID(row.SpanID)
+	if err != nil {
+		return err
+	}
+	span.SetSpanID(spanID)
+	parentSpanID, err := hexToSpanID(row.ParentSpanID)
+	if err != nil {
+		return err
+	}
+	span.SetParentSpanID(parentSpanID)
+	span.TraceState().FromRaw(row.TraceState)
+	span.SetName(row.Name)
+	kind, err := parseEnum(row.Kind, spanKinds...)
+	if err != nil {
+		return fmt.Errorf("invalid span kind: %w", err)
+	}
+	span.SetKind(kind)
+	span.SetStartTimestamp(pcommon.Timestamp(row.StartTimestamp))
+	span.SetEndTimestamp(pcommon.Timestamp(row.EndTimestamp))
+	code, err := parseEnum(row.StatusCode, statusCodes...)
+	if err != nil {
+		return fmt.Errorf("invalid status code: %w", err)
+	}
+	span.Status().SetCode(code)
+	span.Status().SetMessage(row.StatusMessage)
+	putAttributes(span.Attributes(), row.Attributes)
+	span.SetFlags(row.Flags)
+
+	span.Events().EnsureCapacity(len(row.Events))
+	for _, e := range row.Events {
+		event := span.Events().AppendEmpty()
+		event.SetTimestamp(pcommon.Timestamp(e.Timestamp))
+		event.SetName(e.Name)
+		putAttributes(event.Attributes(), e.Attributes)
+	}
+
+	span.Links().EnsureCapacity(len(row.Links))
+	for _, l := range row.Links {
+		link := span.Links().AppendEmpty()
+		traceID, err := hexToTraceID(l.TraceID)
+		if err != nil {
+			return err
+		}
+		link.SetTraceID(traceID)
+		spanID, err := hexToSpanID(l.SpanID)
+		if err != nil {
+			return err
+		}
+		link.SetSpanID(spanID)
+		link.TraceState().FromRaw(l.TraceState)
+		putAttributes(link.Attributes(), l.Attributes)
+		link.SetFlags(l.Flags)
+	}
+	return nil
+}
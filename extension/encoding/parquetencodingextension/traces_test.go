@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func generateTraces() ptrace.Traces {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("checkout.tracer")
+
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID(testTraceID)
+	span.SetSpanID(testSpanID)
+	span.SetParentSpanID(pcommon.SpanID{0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01})
+	span.TraceState().FromRaw("vendor=value")
+	span.SetName("POST /checkout")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(testTime))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(testTime.Add(250 * time.Millisecond)))
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.Status().SetMessage("payment declined")
+	span.Attributes().PutStr("http.request.method", "POST")
+	span.SetFlags(1)
+
+	event := span.Events().AppendEmpty()
+	event.SetTimestamp(pcommon.NewTimestampFromTime(testTime.Add(time.Millisecond)))
+	event.SetName("exception")
+	event.Attributes().PutStr("exception.type", "PaymentError")
+
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(pcommon.TraceID{0x10, 0x0f, 0x0e, 0x0d, 0x0c, 0x0b, 0x0a, 0x09, 0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01})
+	link.SetSpanID(testSpanID)
+	link.Attributes().PutStr("link.type", "follows_from")
+
+	span = ss.Spans().AppendEmpty()
+	span.SetTraceID(testTraceID)
+	span.SetSpanID(pcommon.SpanID{0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01})
+	span.SetName("checkout")
+
+	rs = traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "payment")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("charge")
+	return traces
+}
+
+func TestMarshalTraces(t *testing.T) {
+	ex, err := newExtension(&Config{Compression: compressionZstd})
+	require.NoError(t, err)
+
+	buf, err := ex.MarshalTraces(generateTraces())
+	require.NoError(t, err)
+
+	rows, err := parquet.Read[spanRow](bytes.NewReader(buf), int64(len(buf)))
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, spanRow{
+		resourceColumns: resourceColumns{
+			ResourceAttributes: map[string]string{"service.name": "checkout"},
+		},
+		scopeColumns: scopeColumns{
+			ScopeName:       "checkout.tracer",
+			ScopeAttributes: map[string]string{},
+		},
+		TraceID:        "0102030405060708090a0b0c0d0e0f10",
+		SpanID:         "0102030405060708",
+		ParentSpanID:   "0807060504030201",
+		TraceState:     "vendor=value",
+		Name:           "POST /checkout",
+		Kind:           "Server",
+		StartTimestamp: testTime.UnixNano(),
+		EndTimestamp:   testTime.Add(250 * time.Millisecond).UnixNano(),
+		Duration:       (250 * time.Millisecond).Nanoseconds(),
+		StatusCode:     "Error",
+		StatusMessage:  "payment declined",
+		Attributes:     map[string]string{"http.request.method": "POST"},
+		Events: []spanEvent{{
+			Timestamp:  testTime.Add(time.Millisecond).UnixNano(),
+			Name:       "exception",
+			Attributes: map[string]string{"exception.type": "PaymentError"},
+		}},
+		Links: []spanLink{{
+			TraceID:    "100f0e0d0c0b0a090807060504030201",
+			SpanID:     "0102030405060708",
+			Attributes: map[string]string{"link.type": "follows_from"},
+		}},
+		Flags: 1,
+	}, rows[0])
+	assert.Equal(t, "Unset", rows[1].StatusCode)
+	assert.Equal(t, "payment", rows[2].ResourceAttributes["service.name"])
+}
+
+func TestUnmarshalTraces(t *testing.T) {
+	ex, err := newExtension(&Config{Compression: compressionZstd})
+	require.NoError(t, err)
+
+	traces := generateTraces()
+	buf, err := ex.MarshalTraces(traces)
+	require.NoError(t, err)
+
+	actual, err := ex.UnmarshalTraces(buf)
+	require.NoError(t, err)
+	assert.Equal(t, traces, actual)
+}
+
+func TestUnmarshalTraces_InvalidRow(t *testing.T) {
+	ex, err := newExtension(&Config{Compression: compressionZstd})
+	require.NoError(t, err)
+
+	for name, row := range map[string]spanRow{
+		"Invalid trace id": {TraceID: "0102", Kind: "Server", StatusCode: "Ok"},
+		"Invalid kind":     {Kind: "Remote", StatusCode: "Ok"},
+		"Invalid status":   {Kind: "Server", StatusCode: "Failed"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			buf, err := writeRows([]spanRow{row}, ex.codec)
+			require.NoError(t, err)
+			_, err = ex.UnmarshalTraces(buf)
+			assert.Error(t, err)
+		})
+	}
+}
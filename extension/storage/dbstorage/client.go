@@ -9,6 +9,8 @@ import (
 	"errors"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	_ "github.com/jackc/pgx/v5/stdlib" // Postgres driver
 	"go.opentelemetry.io/collector/extension/xextension/storage"
@@ -20,10 +22,17 @@ type dbStorageClient struct {
 	logger  *zap.Logger
 	db      *sql.DB
 	dialect *dbDialect
+	// keyPrefix is prepended to the keys when the table is shared by all the components
+	keyPrefix string
+	// ttl is the time after which the keys expire, 0 if they never expire
+	ttl time.Duration
+	// onClose is called once when the client is closed
+	onClose   func()
+	closeOnce sync.Once
 }
 
-func newClient(ctx context.Context, logger *zap.Logger, db *sql.DB, driverName, tableName string) (*dbStorageClient, error) {
-	dialect := newDBDialect(driverName, tableName)
+func newClient(ctx context.Context, logger *zap.Logger, db *sql.DB, driverName, tableName, keyPrefix string, ttl time.Duration) (*dbStorageClient, error) {
+	dialect := newDBDialect(driverName, tableName, ttl > 0)
 
 	// Create storage table if not exists
 	_, err := db.ExecContext(ctx, dialect.Queries.QueryCreateTable)
@@ -31,18 +40,38 @@ func newClient(ctx context.Context, logger *zap.Logger, db *sql.DB, driverName,
 		return nil, err
 	}
 
+	// Tables created before the keys could expire don't have the expiration column
+	if ttl > 0 && !hasExpirationColumn(ctx, db, dialect) {
+		_, err = db.ExecContext(ctx, dialect.Queries.QueryAddExpiration)
+		// The column might have been added concurrently by another client of the same table
+		if err != nil && !hasExpirationColumn(ctx, db, dialect) {
+			return nil, err
+		}
+	}
+
 	// Set Prepared Statements for some regularly used queries for performance optimization
 	if err := dialect.Prepare(ctx, db); err != nil {
 		return nil, err
 	}
 
 	return &dbStorageClient{
-		logger:  logger,
-		db:      db,
-		dialect: dialect,
+		logger:    logger,
+		db:        db,
+		dialect:   dialect,
+		keyPrefix: keyPrefix,
+		ttl:       ttl,
 	}, nil
 }
 
+// hasExpirationColumn checks whether the table has the column storing the expiration time of the keys
+func hasExpirationColumn(ctx context.Context, db *sql.DB, dialect *dbDialect) bool {
+	rows, err := db.QueryContext(ctx, dialect.Queries.QuerySelectExpiration)
+	if err != nil {
+		return false
+	}
+	return rows.Close() == nil
+}
+
 // Get will retrieve data from storage that corresponds to the specified key
 func (c *dbStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
 	return c.get(ctx, key, nil)
@@ -110,11 +139,18 @@ func (c *dbStorageClient) Batch(ctx context.Context, ops ...*storage.Operation)
 // Close will close all prepared statements
 // Caller is responsible of closing used DB connection after this
 func (c *dbStorageClient) Close(_ context.Context) error {
+	if c.onClose != nil {
+		c.closeOnce.Do(c.onClose)
+	}
 	return c.dialect.Close()
 }
 
 func (c *dbStorageClient) get(ctx context.Context, key string, tx *sql.Tx) ([]byte, error) {
-	rows, err := wrapTx(c.dialect.GetRowStmt, tx).QueryContext(ctx, key)
+	args := []any{c.keyPrefix + key}
+	if c.ttl > 0 {
+		args = append(args, time.Now().UnixNano())
+	}
+	rows, err := wrapTx(c.dialect.GetRowStmt, tx).QueryContext(ctx, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -132,15 +168,24 @@ func (c *dbStorageClient) get(ctx context.Context, key string, tx *sql.Tx) ([]by
 }
 
 func (c *dbStorageClient) set(ctx context.Context, key string, value []byte, tx *sql.Tx) error {
-	_, err := wrapTx(c.dialect.SetRowStmt, tx).ExecContext(ctx, key, value)
+	args := []any{c.keyPrefix + key, value}
+	if c.ttl > 0 {
+		args = append(args, c.expiresAt())
+	}
+	_, err := wrapTx(c.dialect.SetRowStmt, tx).ExecContext(ctx, args...)
 	return err
 }
 
 func (c *dbStorageClient) delete(ctx context.Context, key string, tx *sql.Tx) error {
-	_, err := wrapTx(c.dialect.DeleteRowStmt, tx).ExecContext(ctx, key)
+	_, err := wrapTx(c.dialect.DeleteRowStmt, tx).ExecContext(ctx, c.keyPrefix+key)
 	return err
 }
 
+// expiresAt returns the expiration time of the keys set now, in Unix nanoseconds
+func (c *dbStorageClient) expiresAt() int64 {
+	return time.Now().Add(c.ttl).UnixNano()
+}
+
 func (c *dbStorageClient) aggregatedBatch(ctx context.Context, tx *sql.Tx, squashOp storage.OpType, ops ...*storage.Operation) error {
 	var opFunc func(ctx context.Context, tx *sql.Tx, ops ...*storage.Operation) error
 
@@ -178,8 +223,13 @@ func (c *dbStorageClient) batchGet(ctx context.Context, tx *sql.Tx, ops ...*stor
 	keys := make([]any, opsCount)
 	keysIdx := make(map[string]int, opsCount)
 	for idx, op := range ops {
-		keys[idx] = op.Key
-		keysIdx[op.Key] = idx
+		keys[idx] = c.keyPrefix + op.Key
+		keysIdx[c.keyPrefix+op.Key] = idx
+	}
+	if c.ttl > 0 {
+		// The current time is passed after the keys
+		query = strings.Replace(query, "$0", "$"+strconv.Itoa(opsCount+1), 1)
+		keys = append(keys, time.Now().UnixNano())
 	}
 
 	rows, err := tx.QueryContext(ctx, query, keys...)
@@ -214,16 +264,20 @@ func (c *dbStorageClient) batchGet(ctx context.Context, tx *sql.Tx, ops ...*stor
 
 func (c *dbStorageClient) batchSet(ctx context.Context, tx *sql.Tx, ops ...*storage.Operation) error {
 	opsCount := len(ops)
-	// Form a multi-row INSERT Query
-	placeholders := generatePlaceholders(opsCount, 2)
+	// Form a multi-row INSERT Query, with the expiration time of every row if the keys expire
+	groupSize := 2
+	if c.ttl > 0 {
+		groupSize = 3
+	}
+	placeholders := generatePlaceholders(opsCount, groupSize)
 
-	vals := make([]any, opsCount*2)
-	idx := 0
+	vals := make([]any, 0, opsCount*groupSize)
+	expiresAt := c.expiresAt()
 	for _, op := range ops {
-		vals[idx] = op.Key
-		idx++
-		vals[idx] = op.Value
-		idx++
+		vals = append(vals, c.keyPrefix+op.Key, op.Value)
+		if c.ttl > 0 {
+			vals = append(vals, expiresAt)
+		}
 	}
 
 	query := strings.Replace(c.dialect.Queries.QuerySetMultiRows, "$1", placeholders, 1)
@@ -240,7 +294,7 @@ func (c *dbStorageClient) batchDelete(ctx context.Context, tx *sql.Tx, ops ...*s
 
 	vals := make([]any, opsCount)
 	for idx, op := range ops {
-		vals[idx] = op.Key
+		vals[idx] = c.keyPrefix + op.Key
 	}
 
 	_, err := tx.ExecContext(ctx, query, vals...)
@@ -28,6 +28,16 @@ const (
 	sqlPostgreSQLCreateTableQuery = "CREATE TABLE IF NOT EXISTS %s (key TEXT PRIMARY KEY, value bytea)"
 	// Other to be added later...
 
+	// Queries used when the keys expire, the expiration time is stored in Unix nanoseconds, NULL if the key never expires
+	// "$0" is replaced by the placeholder of the current time, after the placeholders of the keys
+	sqlTTLGetQuery               = "SELECT value FROM %s WHERE key=$1 AND (expires_at IS NULL OR expires_at > $2)"
+	sqlTTLMultiGetQuery          = "SELECT key, value FROM %s WHERE key IN ($1) AND (expires_at IS NULL OR expires_at > $0)"
+	sqlTTLInsertQuery            = "INSERT INTO %s(key, value, expires_at) VALUES($1, $2, $3) ON CONFLICT(key) DO UPDATE SET value=excluded.value, expires_at=excluded.expires_at"
+	sqlTTLMultiInsertQuery       = "INSERT INTO %s(key, value, expires_at) VALUES $1 ON CONFLICT(key) DO UPDATE SET value=excluded.value, expires_at=excluded.expires_at"
+	sqlTTLSelectExpirationQuery  = "SELECT expires_at FROM %s LIMIT 0"
+	sqlTTLAddExpirationQuery     = "ALTER TABLE %s ADD COLUMN expires_at BIGINT"
+	sqlTTLDeleteExpiredRowsQuery = "DELETE FROM %s WHERE expires_at <= $1"
+
 	// Max amount of similar queries that can be aggregated into single query, driver-specific
 	// This numbers are taken from benchmarks when aggregation profit is leveled out on specific amount of aggregated queries
 	maxAggregatedOpsSQLite     = 200
@@ -44,6 +54,10 @@ type sqlQuerySet struct {
 	QueryGetMultiRows    string
 	QuerySetMultiRows    string
 	QueryDeleteMultiRows string
+	// Queries only used when the keys expire
+	QuerySelectExpiration  string
+	QueryAddExpiration     string
+	QueryDeleteExpiredRows string
 }
 
 // getDialectQueries returns set of queries used in this extension, driver-specific
@@ -68,6 +82,18 @@ func getDialectQueries(driverName string) sqlQuerySet {
 	return set
 }
 
+// withTTL returns the set of queries storing and checking the expiration time of the keys
+func (set sqlQuerySet) withTTL() sqlQuerySet {
+	set.QueryGetRow = sqlTTLGetQuery
+	set.QuerySetRow = sqlTTLInsertQuery
+	set.QueryGetMultiRows = sqlTTLMultiGetQuery
+	set.QuerySetMultiRows = sqlTTLMultiInsertQuery
+	set.QuerySelectExpiration = sqlTTLSelectExpirationQuery
+	set.QueryAddExpiration = sqlTTLAddExpirationQuery
+	set.QueryDeleteExpiredRows = sqlTTLDeleteExpiredRowsQuery
+	return set
+}
+
 type dbDialect struct {
 	// Original set of DB-specific queries with table substitution in place
 	// Some of them are used as is, some - will be prepared for multiple re-use
@@ -115,8 +141,11 @@ func (d *dbDialect) Close() error {
 }
 
 // newDBDialect creates a new DB dialect which is just a set of DB-specific queries/prepared statements
-func newDBDialect(driverName, tableName string) *dbDialect {
+func newDBDialect(driverName, tableName string, ttl bool) *dbDialect {
 	queries := getDialectQueries(driverName)
+	if ttl {
+		queries = queries.withTTL()
+	}
 
 	var aggSize int
 	switch driverName {
@@ -136,7 +165,19 @@ func newDBDialect(driverName, tableName string) *dbDialect {
 			QueryGetMultiRows:    fmt.Sprintf(queries.QueryGetMultiRows, tableName),
 			QuerySetMultiRows:    fmt.Sprintf(queries.QuerySetMultiRows, tableName),
 			QueryDeleteMultiRows: fmt.Sprintf(queries.QueryDeleteMultiRows, tableName),
+			// Queries only used when the keys expire
+			QuerySelectExpiration:  formatQuery(queries.QuerySelectExpiration, tableName),
+			QueryAddExpiration:     formatQuery(queries.QueryAddExpiration, tableName),
+			QueryDeleteExpiredRows: formatQuery(queries.QueryDeleteExpiredRows, tableName),
 		},
 		MaxAggregationSize: aggSize,
 	}
 }
+
+// formatQuery substitutes the table name in the query, unless the query is not used
+func formatQuery(query, tableName string) string {
+	if query == "" {
+		return ""
+	}
+	return fmt.Sprintf(query, tableName)
+}
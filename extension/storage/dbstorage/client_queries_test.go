@@ -4,6 +4,7 @@
 package dbstorage
 
 import (
+	"fmt"
 	"regexp"
 	"testing"
 
@@ -35,7 +36,7 @@ func Test_dbDialect_Prepare(t *testing.T) {
 	defer db.Close()
 
 	t.Run("Should prepare queries", func(t *testing.T) {
-		dialect := newDBDialect(driverPostgreSQL, testTableName)
+		dialect := newDBDialect(driverPostgreSQL, testTableName, false)
 		mock.ExpectPrepare(regexp.QuoteMeta(dialect.Queries.QueryGetRow))
 		mock.ExpectPrepare(regexp.QuoteMeta(dialect.Queries.QuerySetRow))
 		mock.ExpectPrepare(regexp.QuoteMeta(dialect.Queries.QueryDeleteRow))
@@ -45,7 +46,7 @@ func Test_dbDialect_Prepare(t *testing.T) {
 
 func Test_newDBDialect(t *testing.T) {
 	t.Run("Should substitute table name on Dialect creation", func(t *testing.T) {
-		got := newDBDialect(driverPostgreSQL, testTableName)
+		got := newDBDialect(driverPostgreSQL, testTableName, false)
 		assert.Contains(t, got.Queries.QueryCreateTable, testTableName)
 		assert.Contains(t, got.Queries.QueryGetRow, testTableName)
 		assert.Contains(t, got.Queries.QuerySetRow, testTableName)
@@ -55,3 +56,25 @@ func Test_newDBDialect(t *testing.T) {
 		assert.Contains(t, got.Queries.QueryDeleteMultiRows, testTableName)
 	})
 }
+
+func Test_newDBDialectWithTTL(t *testing.T) {
+	t.Run("Should only use the expiration queries when the keys expire", func(t *testing.T) {
+		got := newDBDialect(driverPostgreSQL, testTableName, false)
+		assert.Empty(t, got.Queries.QuerySelectExpiration)
+		assert.Empty(t, got.Queries.QueryAddExpiration)
+		assert.Empty(t, got.Queries.QueryDeleteExpiredRows)
+	})
+	t.Run("Should substitute table name in the expiration queries", func(t *testing.T) {
+		got := newDBDialect(driverSQLite, testTableName, true)
+		assert.Equal(t, fmt.Sprintf(sqlTTLGetQuery, testTableName), got.Queries.QueryGetRow)
+		assert.Equal(t, fmt.Sprintf(sqlTTLInsertQuery, testTableName), got.Queries.QuerySetRow)
+		assert.Equal(t, fmt.Sprintf(sqlTTLMultiGetQuery, testTableName), got.Queries.QueryGetMultiRows)
+		assert.Equal(t, fmt.Sprintf(sqlTTLMultiInsertQuery, testTableName), got.Queries.QuerySetMultiRows)
+		assert.Contains(t, got.Queries.QuerySelectExpiration, testTableName)
+		assert.Contains(t, got.Queries.QueryAddExpiration, testTableName)
+		assert.Contains(t, got.Queries.QueryDeleteExpiredRows, testTableName)
+		// The create table and delete queries don't depend on the expiration
+		assert.Equal(t, fmt.Sprintf(sqlSQLiteCreateTableQuery, testTableName), got.Queries.QueryCreateTable)
+		assert.Equal(t, fmt.Sprintf(sqlGenericDeleteQuery, testTableName), got.Queries.QueryDeleteRow)
+	})
+}
@@ -32,7 +32,7 @@ func Test_newClient(t *testing.T) {
 		mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf(queries.QuerySetRow, testTableName)))
 		mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf(queries.QueryDeleteRow, testTableName)))
 
-		_, err = newClient(t.Context(), zap.L(), db, driverPostgreSQL, testTableName)
+		_, err = newClient(t.Context(), zap.L(), db, driverPostgreSQL, testTableName, "", 0)
 		assert.NoError(t, err)
 	})
 	t.Run("Should return client with Sqlite query(s)", func(t *testing.T) {
@@ -47,7 +47,7 @@ func Test_newClient(t *testing.T) {
 		mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf(queries.QuerySetRow, testTableName)))
 		mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf(queries.QueryDeleteRow, testTableName)))
 
-		_, err = newClient(t.Context(), zap.L(), db, driverSQLite, testTableName)
+		_, err = newClient(t.Context(), zap.L(), db, driverSQLite, testTableName, "", 0)
 		assert.NoError(t, err)
 	})
 }
@@ -430,7 +430,7 @@ func newTestClient(t *testing.T, driverName string) (*dbStorageClient, sqlmock.S
 	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf(queries.QuerySetRow, testTableName)))
 	mock.ExpectPrepare(regexp.QuoteMeta(fmt.Sprintf(queries.QueryDeleteRow, testTableName)))
 
-	dialect := newDBDialect(driverName, testTableName)
+	dialect := newDBDialect(driverName, testTableName, false)
 	err = dialect.Prepare(t.Context(), db)
 	require.NoError(t, err)
 
@@ -4,8 +4,12 @@
 package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
+	"regexp"
+	"slices"
+	"time"
 )
 
 const (
@@ -14,10 +18,22 @@ const (
 	driverSQLiteLegacy = "sqlite3"
 )
 
+// tableNameRegexp matches the table names that can be used in queries without quoting
+var tableNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 // Config defines configuration for dbstorage extension.
 type Config struct {
 	DriverName string `mapstructure:"driver,omitempty"`
 	DataSource string `mapstructure:"datasource,omitempty"`
+
+	// TTL is the time after which a key expires if it is not set again, 0 means that the keys never expire
+	TTL time.Duration `mapstructure:"ttl,omitempty"`
+	// SweepInterval is the interval at which the expired keys are deleted
+	SweepInterval time.Duration `mapstructure:"sweep_interval,omitempty"`
+
+	// SharedTable is the name of a table storing the data of all the components,
+	// by default the data of every component is stored in its own table
+	SharedTable string `mapstructure:"shared_table,omitempty"`
 }
 
 func (cfg *Config) Validate() error {
@@ -28,11 +44,22 @@ func (cfg *Config) Validate() error {
 		return errors.New("missing driver name")
 	}
 
-	if cfg.DriverName != driverPostgreSQL &&
-		cfg.DriverName != driverSQLite &&
-		cfg.DriverName != driverSQLiteLegacy {
+	// Drivers other than the built-in ones are supported when they are registered in the program,
+	// the generic queries are then used
+	if cfg.DriverName != driverSQLiteLegacy && !slices.Contains(sql.Drivers(), cfg.DriverName) {
 		return fmt.Errorf("unsupported driver %s", cfg.DriverName)
 	}
 
+	if cfg.TTL < 0 {
+		return errors.New("ttl cannot be negative")
+	}
+	if cfg.TTL > 0 && cfg.SweepInterval <= 0 {
+		return errors.New("sweep interval must be positive when ttl is set")
+	}
+
+	if cfg.SharedTable != "" && !tableNameRegexp.MatchString(cfg.SharedTable) {
+		return fmt.Errorf("invalid shared table name %q, it must only contain letters, digits and underscores", cfg.SharedTable)
+	}
+
 	return nil
 }
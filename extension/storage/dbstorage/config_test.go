@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -32,11 +33,41 @@ func TestConfigValidate(t *testing.T) {
 			Config{DriverName: "foo", DataSource: "bar"},
 			fmt.Errorf("unsupported driver %s", "foo"),
 		},
+		{
+			"Legacy driver",
+			Config{DriverName: driverSQLiteLegacy, DataSource: "bar"},
+			nil,
+		},
+		{
+			"Registered driver",
+			Config{DriverName: genericTestDriver, DataSource: "bar"},
+			nil,
+		},
+		{
+			"Negative TTL",
+			Config{DriverName: driverSQLite, DataSource: "bar", TTL: -time.Minute},
+			errors.New("ttl cannot be negative"),
+		},
+		{
+			"TTL without sweep interval",
+			Config{DriverName: driverSQLite, DataSource: "bar", TTL: time.Hour},
+			errors.New("sweep interval must be positive when ttl is set"),
+		},
+		{
+			"Invalid shared table",
+			Config{DriverName: driverSQLite, DataSource: "bar", SharedTable: "storage; DROP TABLE foo"},
+			fmt.Errorf("invalid shared table name %q, it must only contain letters, digits and underscores", "storage; DROP TABLE foo"),
+		},
 		{
 			"Valid",
 			Config{DriverName: driverSQLite, DataSource: "bar"},
 			nil,
 		},
+		{
+			"Valid with TTL and shared table",
+			Config{DriverName: driverPostgreSQL, DataSource: "bar", TTL: time.Hour, SweepInterval: time.Minute, SharedTable: "otel_storage"},
+			nil,
+		},
 	}
 
 	for _, test := range tests {
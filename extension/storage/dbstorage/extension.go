@@ -7,7 +7,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"maps"
 	"strings"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
@@ -18,8 +21,19 @@ import (
 type databaseStorage struct {
 	driverName     string
 	datasourceName string
+	sharedTable    string
+	ttl            time.Duration
+	sweepInterval  time.Duration
 	logger         *zap.Logger
 	db             *sql.DB
+
+	// sweepQueries are the queries deleting the expired keys of every table used by the open clients,
+	// sweepClients the number of open clients of every table
+	sweepQueriesMu sync.Mutex
+	sweepQueries   map[string]string
+	sweepClients   map[string]int
+	cancel         context.CancelFunc
+	sweepWg        sync.WaitGroup
 }
 
 // Ensure this storage extension implements the appropriate interface
@@ -29,7 +43,12 @@ func newDBStorage(logger *zap.Logger, config *Config) (extension.Extension, erro
 	return &databaseStorage{
 		driverName:     config.DriverName,
 		datasourceName: config.DataSource,
+		sharedTable:    config.SharedTable,
+		ttl:            config.TTL,
+		sweepInterval:  config.SweepInterval,
 		logger:         logger,
+		sweepQueries:   map[string]string{},
+		sweepClients:   map[string]int{},
 	}, nil
 }
 
@@ -57,17 +76,64 @@ func (ds *databaseStorage) Start(context.Context, component.Host) error {
 		return err
 	}
 	ds.db = db
+
+	if ds.ttl > 0 {
+		var ctx context.Context
+		ctx, ds.cancel = context.WithCancel(context.Background())
+		ds.sweepWg.Add(1)
+		go ds.sweepLoop(ctx)
+	}
 	return nil
 }
 
 // Shutdown closes the connection to the database
 func (ds *databaseStorage) Shutdown(context.Context) error {
+	if ds.cancel != nil {
+		ds.cancel()
+		ds.sweepWg.Wait()
+	}
 	if ds.db == nil {
 		return nil
 	}
 	return ds.db.Close()
 }
 
+// sweepLoop periodically deletes the expired keys
+func (ds *databaseStorage) sweepLoop(ctx context.Context) {
+	defer ds.sweepWg.Done()
+
+	ticker := time.NewTicker(ds.sweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			ds.sweep(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sweep deletes the expired keys of every table used by the clients
+func (ds *databaseStorage) sweep(ctx context.Context) {
+	ds.sweepQueriesMu.Lock()
+	queries := make(map[string]string, len(ds.sweepQueries))
+	maps.Copy(queries, ds.sweepQueries)
+	ds.sweepQueriesMu.Unlock()
+
+	now := time.Now().UnixNano()
+	for table, query := range queries {
+		result, err := ds.db.ExecContext(ctx, query, now)
+		if err != nil {
+			ds.logger.Warn("Failed to delete expired keys", zap.String("table", table), zap.Error(err))
+			continue
+		}
+		if deleted, err := result.RowsAffected(); err == nil && deleted > 0 {
+			ds.logger.Debug("Deleted expired keys", zap.String("table", table), zap.Int64("count", deleted))
+		}
+	}
+}
+
 // GetClient returns a storage client for an individual component
 func (ds *databaseStorage) GetClient(ctx context.Context, kind component.Kind, ent component.ID, name string) (storage.Client, error) {
 	var fullName string
@@ -77,7 +143,38 @@ func (ds *databaseStorage) GetClient(ctx context.Context, kind component.Kind, e
 		fullName = fmt.Sprintf("%s_%s_%s_%s", kindString(kind), ent.Type(), ent.Name(), name)
 	}
 	fullName = strings.ReplaceAll(fullName, " ", "")
-	return newClient(ctx, ds.logger, ds.db, ds.driverName, fullName)
+
+	// In a shared table the keys are prefixed with the component name, separated by a space
+	// which can't be part of the component name
+	tableName, keyPrefix := fullName, ""
+	if ds.sharedTable != "" {
+		tableName, keyPrefix = ds.sharedTable, fullName+" "
+	}
+	client, err := newClient(ctx, ds.logger, ds.db, ds.driverName, tableName, keyPrefix, ds.ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	if ds.ttl > 0 {
+		ds.sweepQueriesMu.Lock()
+		ds.sweepQueries[tableName] = client.dialect.Queries.QueryDeleteExpiredRows
+		ds.sweepClients[tableName]++
+		ds.sweepQueriesMu.Unlock()
+		client.onClose = func() { ds.releaseSweepQuery(tableName) }
+	}
+	return client, nil
+}
+
+// releaseSweepQuery stops sweeping a table once its last client is closed
+func (ds *databaseStorage) releaseSweepQuery(tableName string) {
+	ds.sweepQueriesMu.Lock()
+	defer ds.sweepQueriesMu.Unlock()
+
+	ds.sweepClients[tableName]--
+	if ds.sweepClients[tableName] <= 0 {
+		delete(ds.sweepClients, tableName)
+		delete(ds.sweepQueries, tableName)
+	}
 }
 
 func kindString(k component.Kind) string {
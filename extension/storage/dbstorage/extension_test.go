@@ -5,6 +5,7 @@ package dbstorage
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"os"
@@ -24,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/extension/extensiontest"
 	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"modernc.org/sqlite"
 )
 
 func TestExtensionIntegrityWithSqlite(t *testing.T) {
@@ -53,6 +55,205 @@ func TestExtensionIntegrityWithPostgres(t *testing.T) {
 	testExtensionIntegrity(t, se)
 }
 
+func TestExtensionTTLWithSqlite(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "foo.db")
+	se, err := newSqliteTestExtension(dbPath, func(cfg *Config) {
+		cfg.TTL = 500 * time.Millisecond
+		cfg.SweepInterval = 50 * time.Millisecond
+	})
+	require.NoError(t, err)
+	require.NoError(t, se.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, se.Shutdown(context.Background())) //nolint:usetesting
+	}()
+
+	client, err := se.GetClient(t.Context(), component.KindReceiver, newTestEntity("receiver_one"), "")
+	require.NoError(t, err)
+	defer client.Close(t.Context())
+
+	require.NoError(t, client.Set(t.Context(), "single", []byte("value")))
+	require.NoError(t, client.Batch(t.Context(), &storage.Operation{Type: storage.Set, Key: "batch", Value: []byte("value")}))
+
+	value, err := client.Get(t.Context(), "single")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	get := &storage.Operation{Type: storage.Get, Key: "batch"}
+	require.NoError(t, client.Batch(t.Context(), get))
+	assert.Equal(t, []byte("value"), get.Value)
+
+	// The expired keys are deleted by the sweeper
+	db := se.(*databaseStorage).db
+	require.Eventually(t, func() bool {
+		var count int
+		return db.QueryRowContext(t.Context(), "SELECT COUNT(*) FROM receiver_nop_receiver_one").Scan(&count) == nil && count == 0
+	}, 10*time.Second, 50*time.Millisecond)
+
+	value, err = client.Get(t.Context(), "single")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+// genericTestDriver is the SQLite driver registered under another name, to use the generic queries
+const genericTestDriver = "sqlite_generic"
+
+func init() {
+	sql.Register(genericTestDriver, &sqlite.Driver{})
+}
+
+func TestExtensionWithGenericDriver(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "foo.db")
+	se, err := newSqliteTestExtension(dbPath, func(cfg *Config) {
+		cfg.DriverName = genericTestDriver
+		cfg.SharedTable = "otel_storage"
+		cfg.TTL = time.Hour
+		cfg.SweepInterval = time.Minute
+		require.NoError(t, cfg.Validate())
+	})
+	require.NoError(t, err)
+	testExtensionIntegrity(t, se)
+}
+
+func TestExtensionTTLStopsSweepingClosedTables(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "foo.db")
+	se, err := newSqliteTestExtension(dbPath, func(cfg *Config) {
+		cfg.TTL = time.Minute
+		cfg.SweepInterval = time.Hour
+		cfg.SharedTable = "otel_storage"
+	})
+	require.NoError(t, err)
+	require.NoError(t, se.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, se.Shutdown(context.Background())) //nolint:usetesting
+	}()
+	ds := se.(*databaseStorage)
+
+	one, err := se.GetClient(t.Context(), component.KindReceiver, newTestEntity("receiver_one"), "")
+	require.NoError(t, err)
+	two, err := se.GetClient(t.Context(), component.KindReceiver, newTestEntity("receiver_two"), "")
+	require.NoError(t, err)
+	assert.Contains(t, ds.sweepQueries, "otel_storage")
+
+	// The shared table is still swept while one of its clients is open, even if another client is closed twice
+	require.NoError(t, one.Close(t.Context()))
+	require.NoError(t, one.Close(t.Context()))
+	assert.Contains(t, ds.sweepQueries, "otel_storage")
+
+	require.NoError(t, two.Close(t.Context()))
+	assert.Empty(t, ds.sweepQueries)
+	assert.Empty(t, ds.sweepClients)
+}
+
+func TestExtensionTTLFiltersExpiredKeys(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "foo.db")
+	se, err := newSqliteTestExtension(dbPath, func(cfg *Config) {
+		cfg.TTL = 50 * time.Millisecond
+		// The expired keys are not swept during the test
+		cfg.SweepInterval = time.Hour
+	})
+	require.NoError(t, err)
+	require.NoError(t, se.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, se.Shutdown(context.Background())) //nolint:usetesting
+	}()
+
+	client, err := se.GetClient(t.Context(), component.KindReceiver, newTestEntity("receiver_one"), "")
+	require.NoError(t, err)
+	defer client.Close(t.Context())
+
+	require.NoError(t, client.Set(t.Context(), "key", []byte("value")))
+	time.Sleep(100 * time.Millisecond)
+
+	value, err := client.Get(t.Context(), "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	get := &storage.Operation{Type: storage.Get, Key: "key"}
+	require.NoError(t, client.Batch(t.Context(), get))
+	assert.Nil(t, get.Value)
+
+	// Setting the key again renews its expiration
+	require.NoError(t, client.Set(t.Context(), "key", []byte("renewed")))
+	value, err = client.Get(t.Context(), "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("renewed"), value)
+}
+
+func TestExtensionTTLMigratesExistingTable(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "foo.db")
+
+	// Store a key in a table created without expiration
+	se, err := newSqliteTestExtension(dbPath)
+	require.NoError(t, err)
+	require.NoError(t, se.Start(t.Context(), componenttest.NewNopHost()))
+	client, err := se.GetClient(t.Context(), component.KindReceiver, newTestEntity("receiver_one"), "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(t.Context(), "old", []byte("value")))
+	require.NoError(t, client.Close(t.Context()))
+	require.NoError(t, se.Shutdown(t.Context()))
+
+	se, err = newSqliteTestExtension(dbPath, func(cfg *Config) {
+		cfg.TTL = time.Hour
+		cfg.SweepInterval = time.Hour
+	})
+	require.NoError(t, err)
+	require.NoError(t, se.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, se.Shutdown(context.Background())) //nolint:usetesting
+	}()
+	client, err = se.GetClient(t.Context(), component.KindReceiver, newTestEntity("receiver_one"), "")
+	require.NoError(t, err)
+	defer client.Close(t.Context())
+
+	// The keys stored before never expire
+	value, err := client.Get(t.Context(), "old")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	require.NoError(t, client.Set(t.Context(), "new", []byte("value")))
+	value, err = client.Get(t.Context(), "new")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func TestExtensionSharedTableWithSqlite(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "foo.db")
+	se, err := newSqliteTestExtension(dbPath, func(cfg *Config) {
+		cfg.SharedTable = "otel_storage"
+	})
+	require.NoError(t, err)
+	testExtensionIntegrity(t, se)
+
+	se, err = newSqliteTestExtension(dbPath, func(cfg *Config) {
+		cfg.SharedTable = "otel_storage"
+	})
+	require.NoError(t, err)
+	require.NoError(t, se.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, se.Shutdown(context.Background())) //nolint:usetesting
+	}()
+
+	one, err := se.GetClient(t.Context(), component.KindReceiver, newTestEntity("receiver_one"), "")
+	require.NoError(t, err)
+	defer one.Close(t.Context())
+	two, err := se.GetClient(t.Context(), component.KindReceiver, newTestEntity("receiver_two"), "")
+	require.NoError(t, err)
+	defer two.Close(t.Context())
+
+	require.NoError(t, one.Set(t.Context(), "key", []byte("one")))
+	require.NoError(t, two.Set(t.Context(), "key", []byte("two")))
+
+	value, err := one.Get(t.Context(), "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("one"), value)
+	value, err = two.Get(t.Context(), "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("two"), value)
+
+	// Both components store their keys in the shared table
+	var count int
+	require.NoError(t, se.(*databaseStorage).db.QueryRowContext(t.Context(), "SELECT COUNT(*) FROM otel_storage").Scan(&count))
+	assert.Equal(t, 2, count)
+}
+
 func testExtensionIntegrity(t *testing.T, se storage.Extension) {
 	ctx := t.Context()
 
@@ -186,11 +387,14 @@ func testExtensionIntegrity(t *testing.T, se storage.Extension) {
 	wg.Wait()
 }
 
-func newSqliteTestExtension(dbPath string) (storage.Extension, error) {
+func newSqliteTestExtension(dbPath string, opts ...func(*Config)) (storage.Extension, error) {
 	f := NewFactory()
 	cfg := f.CreateDefaultConfig().(*Config)
 	cfg.DriverName = driverSQLite
 	cfg.DataSource = fmt.Sprintf("%s?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)&_pragma=synchronous(NORMAL)", dbPath)
+	for _, opt := range opts {
+		opt(cfg)
+	}
 
 	extension, err := f.Create(context.Background(), extensiontest.NewNopSettings(f.Type()), cfg)
 	if err != nil {
@@ -7,6 +7,7 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
@@ -24,8 +25,12 @@ func NewFactory() extension.Factory {
 	)
 }
 
+const defaultSweepInterval = time.Minute
+
 func createDefaultConfig() component.Config {
-	return &Config{}
+	return &Config{
+		SweepInterval: defaultSweepInterval,
+	}
 }
 
 func createExtension(
@@ -5,6 +5,7 @@ package filestorage // import "github.com/open-telemetry/opentelemetry-collector
 
 import (
 	"context"
+	"crypto/cipher"
 	"errors"
 	"fmt"
 	"os"
@@ -15,6 +16,8 @@ import (
 	"go.etcd.io/bbolt"
 	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/metadata"
 )
 
 var defaultBucket = []byte(`default`)
@@ -37,6 +40,27 @@ type fileStorageClient struct {
 	openTimeout     time.Duration
 	cancel          context.CancelFunc
 	closed          bool
+	// aead encrypts the stored values, nil if encryption is disabled
+	aead cipher.AEAD
+	// quota bounds the size of the stored data, nil if it is unlimited
+	quota *sizeQuota
+}
+
+// clientOption configures the optional features of a client.
+type clientOption func(*fileStorageClient)
+
+// withEncryption encrypts the stored values.
+func withEncryption(aead cipher.AEAD) clientOption {
+	return func(c *fileStorageClient) {
+		c.aead = aead
+	}
+}
+
+// withMaxSize bounds the size of the stored keys and values, in bytes.
+func withMaxSize(maxSize int64, policy string, telemetryBuilder *metadata.TelemetryBuilder) clientOption {
+	return func(c *fileStorageClient) {
+		c.quota = &sizeQuota{maxSize: maxSize, policy: policy, telemetryBuilder: telemetryBuilder}
+	}
 }
 
 func bboltOptions(timeout time.Duration, noSync bool) *bbolt.Options {
@@ -48,23 +72,32 @@ func bboltOptions(timeout time.Duration, noSync bool) *bbolt.Options {
 	}
 }
 
-func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, noSync bool) (*fileStorageClient, error) {
+func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, noSync bool, opts ...clientOption) (*fileStorageClient, error) {
 	options := bboltOptions(timeout, noSync)
 	db, err := bbolt.Open(filePath, 0o600, options)
 	if err != nil {
 		return nil, err
 	}
 
+	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout}
+	for _, opt := range opts {
+		opt(client)
+	}
+
 	initBucket := func(tx *bbolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists(defaultBucket)
-		return err
+		if _, err := tx.CreateBucketIfNotExists(defaultBucket); err != nil {
+			return err
+		}
+		if client.quota != nil {
+			return client.quota.init(context.Background(), tx)
+		}
+		return nil
 	}
 	if err := db.Update(initBucket); err != nil {
 		_ = db.Close()
 		return nil, err
 	}
 
-	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout}
 	if compactionCfg.OnRebound {
 		client.startCompactionLoop(context.Background())
 	}
@@ -94,7 +127,8 @@ func (c *fileStorageClient) Delete(ctx context.Context, key string) error {
 }
 
 // Batch executes the specified operations in order. Get operation results are updated in place
-func (c *fileStorageClient) Batch(_ context.Context, ops ...*storage.Operation) error {
+func (c *fileStorageClient) Batch(ctx context.Context, ops ...*storage.Operation) error {
+	var qtx *quotaTx
 	batch := func(tx *bbolt.Tx) error {
 		bucket := tx.Bucket(defaultBucket)
 		if bucket == nil {
@@ -102,22 +136,36 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...*storage.Operation)
 		}
 
 		var err error
+		if c.quota != nil {
+			if qtx, err = c.quota.begin(tx); err != nil {
+				return err
+			}
+		}
 		for _, op := range ops {
 			switch op.Type {
 			case storage.Get:
 				value := bucket.Get([]byte(op.Key))
 				if value != nil {
-					// the output of Bucket.Get is only valid within a transaction, so we need to make a copy
-					// to be able to return the value
-					op.Value = make([]byte, len(value))
-					copy(op.Value, value)
+					op.Value, err = c.decrypt(op.Key, value)
 				} else {
 					op.Value = nil
 				}
 			case storage.Set:
-				err = bucket.Put([]byte(op.Key), op.Value)
+				var value []byte
+				if value, err = c.encrypt(op.Key, op.Value); err != nil {
+					break
+				}
+				if qtx != nil {
+					err = qtx.put([]byte(op.Key), value)
+				} else {
+					err = bucket.Put([]byte(op.Key), value)
+				}
 			case storage.Delete:
-				err = bucket.Delete([]byte(op.Key))
+				if qtx != nil {
+					err = qtx.delete([]byte(op.Key))
+				} else {
+					err = bucket.Delete([]byte(op.Key))
+				}
 			default:
 				return errors.New("wrong operation type")
 			}
@@ -132,17 +180,28 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...*storage.Operation)
 
 	c.compactionMutex.RLock()
 	defer c.compactionMutex.RUnlock()
-	return c.db.Update(batch)
+	if c.quota == nil {
+		return c.db.Update(batch)
+	}
+
+	c.quota.mutex.Lock()
+	defer c.quota.mutex.Unlock()
+	err := c.db.Update(batch)
+	c.quota.end(ctx, qtx, err)
+	return err
 }
 
 // Close will close the database
-func (c *fileStorageClient) Close(_ context.Context) error {
+func (c *fileStorageClient) Close(ctx context.Context) error {
 	c.compactionMutex.Lock()
 	defer c.compactionMutex.Unlock()
 
 	if c.cancel != nil {
 		c.cancel()
 	}
+	if c.quota != nil && !c.closed {
+		c.quota.close(ctx)
+	}
 	c.closed = true
 	return c.db.Close()
 }
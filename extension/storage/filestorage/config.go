@@ -4,12 +4,23 @@
 package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"strconv"
+	"strings"
 	"time"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+const (
+	// maxSizePolicyReject rejects the writes that would exceed the max size
+	maxSizePolicyReject = "reject"
+	// maxSizePolicyEvictOldest evicts the oldest written entries until the write fits in the max size
+	maxSizePolicyEvictOldest = "evict_oldest"
 )
 
 var (
@@ -33,6 +44,46 @@ type Config struct {
 	directoryPermissionsParsed int64  `mapstructure:"-,omitempty"`
 
 	Recreate bool `mapstructure:"recreate,omitempty"`
+
+	// Encryption specifies that the stored values are encrypted with AES-GCM
+	Encryption *EncryptionConfig `mapstructure:"encryption,omitempty"`
+
+	// MaxSizeMiB specifies the maximum size of the keys and values stored for every component, 0 means no limit
+	MaxSizeMiB int64 `mapstructure:"max_size_mib,omitempty"`
+	// MaxSizePolicy specifies what happens to a write that would exceed MaxSizeMiB: reject or evict_oldest
+	MaxSizePolicy string `mapstructure:"max_size_policy,omitempty"`
+}
+
+// EncryptionConfig defines configuration for the encryption of the stored values.
+type EncryptionConfig struct {
+	// Key is the base64 encoded AES key of 16, 24 or 32 bytes. It is typically provided through
+	// a confmap provider, for instance ${env:STORAGE_KEY} or a value decrypted by the aes provider.
+	Key configopaque.String `mapstructure:"key,omitempty"`
+	// KeyFile is the path of a file containing the base64 encoded AES key
+	KeyFile string `mapstructure:"key_file,omitempty"`
+}
+
+// loadKey returns the decoded AES key.
+func (cfg *EncryptionConfig) loadKey() ([]byte, error) {
+	encoded := string(cfg.Key)
+	if cfg.KeyFile != "" {
+		content, err := os.ReadFile(cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read encryption key file: %w", err)
+		}
+		encoded = strings.TrimSpace(string(content))
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("encryption key must be base64 encoded: %w", err)
+	}
+	switch len(key) {
+	case 16, 24, 32:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("encryption key must be 16, 24 or 32 bytes long, got %d bytes", len(key))
+	}
 }
 
 // CompactionConfig defines configuration for optional file storage compaction.
@@ -94,6 +145,22 @@ func (cfg *Config) Validate() error {
 		return errors.New("compaction check interval must be positive when rebound compaction is set")
 	}
 
+	if cfg.Encryption != nil {
+		if (cfg.Encryption.Key == "") == (cfg.Encryption.KeyFile == "") {
+			return errors.New("exactly one of encryption key and key_file must be set")
+		}
+		if _, err := cfg.Encryption.loadKey(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.MaxSizeMiB < 0 {
+		return errors.New("max size cannot be less than 0")
+	}
+	if cfg.MaxSizePolicy != maxSizePolicyReject && cfg.MaxSizePolicy != maxSizePolicyEvictOldest {
+		return fmt.Errorf("max size policy must be %q or %q", maxSizePolicyReject, maxSizePolicyEvictOldest)
+	}
+
 	if cfg.CreateDirectory {
 		permissions, err := strconv.ParseInt(cfg.DirectoryPermissions, 8, 32)
 		if err != nil {
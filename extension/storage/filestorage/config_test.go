@@ -54,6 +54,11 @@ func TestLoadConfig(t *testing.T) {
 				FSync:                true,
 				CreateDirectory:      false,
 				DirectoryPermissions: "0750",
+				Encryption: &EncryptionConfig{
+					Key: "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=",
+				},
+				MaxSizeMiB:    512,
+				MaxSizePolicy: maxSizePolicyEvictOldest,
 			},
 		},
 	}
@@ -272,3 +277,74 @@ func TestCompactionDirectory(t *testing.T) {
 		})
 	}
 }
+
+func TestEncryptionConfig(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("AAECAwQFBgcICQoLDA0ODw==\n"), 0o600))
+
+	tests := []struct {
+		name       string
+		encryption *EncryptionConfig
+		err        string
+	}{
+		{
+			name:       "key",
+			encryption: &EncryptionConfig{Key: "AAECAwQFBgcICQoLDA0ODxAREhMUFRYX"},
+		},
+		{
+			name:       "key file",
+			encryption: &EncryptionConfig{KeyFile: keyFile},
+		},
+		{
+			name:       "no key",
+			encryption: &EncryptionConfig{},
+			err:        "exactly one of encryption key and key_file must be set",
+		},
+		{
+			name:       "key and key file",
+			encryption: &EncryptionConfig{Key: "AAECAwQFBgcICQoLDA0ODw==", KeyFile: keyFile},
+			err:        "exactly one of encryption key and key_file must be set",
+		},
+		{
+			name:       "missing key file",
+			encryption: &EncryptionConfig{KeyFile: filepath.Join(t.TempDir(), "missing")},
+			err:        "failed to read encryption key file",
+		},
+		{
+			name:       "key not base64",
+			encryption: &EncryptionConfig{Key: "not base64!"},
+			err:        "encryption key must be base64 encoded",
+		},
+		{
+			name:       "invalid key length",
+			encryption: &EncryptionConfig{Key: "AAECAwQFBgc="},
+			err:        "encryption key must be 16, 24 or 32 bytes long, got 8 bytes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Directory = t.TempDir()
+			cfg.Encryption = tt.encryption
+			err := xconfmap.Validate(cfg)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestMaxSizeConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	assert.Equal(t, maxSizePolicyReject, cfg.MaxSizePolicy)
+
+	cfg.MaxSizeMiB = -1
+	require.EqualError(t, xconfmap.Validate(cfg), "max size cannot be less than 0")
+
+	cfg.MaxSizeMiB = 1
+	cfg.MaxSizePolicy = "drop"
+	require.EqualError(t, xconfmap.Validate(cfg), `max size policy must be "reject" or "evict_oldest"`)
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+var errDecryptionFailed = errors.New("failed to decrypt stored value, the encryption key may have changed or the value may not be encrypted")
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create encryption cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt returns the value encrypted with a random nonce, followed by the nonce.
+// The key is authenticated along with the value, so that a value cannot be read under another key.
+func (c *fileStorageClient) encrypt(key string, value []byte) ([]byte, error) {
+	if c.aead == nil {
+		return value, nil
+	}
+	nonce := make([]byte, c.aead.NonceSize(), c.aead.NonceSize()+len(value)+c.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return c.aead.Seal(nonce, nonce, value, []byte(key)), nil
+}
+
+// decrypt returns a copy of the decrypted value.
+func (c *fileStorageClient) decrypt(key string, value []byte) ([]byte, error) {
+	if c.aead == nil {
+		// the output of Bucket.Get is only valid within a transaction, so we need to make a copy
+		// to be able to return the value
+		decrypted := make([]byte, len(value))
+		copy(decrypted, value)
+		return decrypted, nil
+	}
+	if len(value) < c.aead.NonceSize() {
+		return nil, errDecryptionFailed
+	}
+	nonce, ciphertext := value[:c.aead.NonceSize()], value[c.aead.NonceSize():]
+	// the value is decrypted into a non-nil slice, as a nil value means that the key is not found
+	decrypted, err := c.aead.Open(make([]byte, 0, len(ciphertext)), nonce, ciphertext, []byte(key))
+	if err != nil {
+		return nil, errDecryptionFailed
+	}
+	return decrypted, nil
+}
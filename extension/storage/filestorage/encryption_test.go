@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.uber.org/zap"
+)
+
+func newTestAEAD(t *testing.T, seed byte) cipher.AEAD {
+	aead, err := newAEAD(bytes.Repeat([]byte{seed}, 32))
+	require.NoError(t, err)
+	return aead
+}
+
+func TestClientEncryption(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+	aead := newTestAEAD(t, 1)
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, withEncryption(aead))
+	require.NoError(t, err)
+
+	ctx := t.Context()
+	testValue := []byte("customer payload")
+	require.NoError(t, client.Set(ctx, "key", testValue))
+	require.NoError(t, client.Set(ctx, "empty", []byte{}))
+
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, testValue, value)
+
+	// An empty value is not confused with a missing key
+	value, err = client.Get(ctx, "empty")
+	require.NoError(t, err)
+	require.NotNil(t, value)
+	require.Empty(t, value)
+
+	// The value is not stored in plain text, and cannot be read under another key
+	var stored []byte
+	require.NoError(t, client.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(defaultBucket)
+		stored = append([]byte(nil), bucket.Get([]byte("key"))...)
+		return bucket.Put([]byte("other"), stored)
+	}))
+	require.NotContains(t, string(stored), string(testValue))
+	_, err = client.Get(ctx, "other")
+	require.ErrorIs(t, err, errDecryptionFailed)
+	require.NoError(t, client.Close(ctx))
+
+	// The value cannot be read with another key
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, withEncryption(newTestAEAD(t, 2)))
+	require.NoError(t, err)
+	_, err = client.Get(ctx, "key")
+	require.ErrorIs(t, err, errDecryptionFailed)
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestExtensionEncryption(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.Encryption = &EncryptionConfig{Key: "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="}
+
+	ext, err := f.Create(t.Context(), extensiontest.NewNopSettings(f.Type()), cfg)
+	require.NoError(t, err)
+	client, err := ext.(storage.Extension).GetClient(t.Context(), component.KindReceiver, newTestEntity("encrypted"), "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(t.Context()))
+		require.NoError(t, ext.Shutdown(t.Context()))
+	})
+
+	require.NoError(t, client.Set(t.Context(), "key", []byte("value")))
+	value, err := client.Get(t.Context(), "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+	require.NotNil(t, client.(*fileStorageClient).aead)
+}
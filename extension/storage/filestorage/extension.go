@@ -5,6 +5,7 @@ package filestorage // import "github.com/open-telemetry/opentelemetry-collector
 
 import (
 	"context"
+	"crypto/cipher"
 	"errors"
 	"fmt"
 	"os"
@@ -16,17 +17,22 @@ import (
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/metadata"
 )
 
 type localFileStorage struct {
-	cfg    *Config
-	logger *zap.Logger
+	cfg              *Config
+	logger           *zap.Logger
+	telemetryBuilder *metadata.TelemetryBuilder
+	// aead encrypts the stored values, nil if encryption is disabled
+	aead cipher.AEAD
 }
 
 // Ensure this storage extension implements the appropriate interface
 var _ storage.Extension = (*localFileStorage)(nil)
 
-func newLocalFileStorage(logger *zap.Logger, config *Config) (extension.Extension, error) {
+func newLocalFileStorage(set component.TelemetrySettings, config *Config) (extension.Extension, error) {
 	if config.CreateDirectory {
 		var dirs []string
 		if config.Compaction.OnStart || config.Compaction.OnRebound {
@@ -40,9 +46,25 @@ func newLocalFileStorage(logger *zap.Logger, config *Config) (extension.Extensio
 			}
 		}
 	}
+	var aead cipher.AEAD
+	if config.Encryption != nil {
+		key, err := config.Encryption.loadKey()
+		if err != nil {
+			return nil, err
+		}
+		if aead, err = newAEAD(key); err != nil {
+			return nil, err
+		}
+	}
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set)
+	if err != nil {
+		return nil, err
+	}
 	return &localFileStorage{
-		cfg:    config,
-		logger: logger,
+		cfg:              config,
+		logger:           set.Logger,
+		telemetryBuilder: telemetryBuilder,
+		aead:             aead,
 	}, nil
 }
 
@@ -55,9 +77,10 @@ func (lfs *localFileStorage) Start(context.Context, component.Host) error {
 }
 
 // Shutdown will close any open databases
-func (*localFileStorage) Shutdown(context.Context) error {
+func (lfs *localFileStorage) Shutdown(context.Context) error {
 	// TODO clean up data files that did not have a client
 	// and are older than a threshold (possibly configurable)
+	lfs.telemetryBuilder.Shutdown()
 	return nil
 }
 
@@ -94,10 +117,18 @@ func (lfs *localFileStorage) GetClient(_ context.Context, kind component.Kind, e
 // and a panic occurs (typically due to database corruption), it will rename the file
 // and try again with a fresh database
 func (lfs *localFileStorage) createClientWithPanicRecovery(absoluteName string) (client *fileStorageClient, err error) {
+	var opts []clientOption
+	if lfs.aead != nil {
+		opts = append(opts, withEncryption(lfs.aead))
+	}
+	if lfs.cfg.MaxSizeMiB > 0 {
+		opts = append(opts, withMaxSize(lfs.cfg.MaxSizeMiB*oneMiB, lfs.cfg.MaxSizePolicy, lfs.telemetryBuilder))
+	}
+
 	// First attempt: try to create client normally
 	if !lfs.cfg.Recreate {
 		// If recreate is disabled, just try once
-		return newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, !lfs.cfg.FSync)
+		return newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, !lfs.cfg.FSync, opts...)
 	}
 
 	// If recreate is enabled, handle potential panics during database opening
@@ -120,12 +151,12 @@ func (lfs *localFileStorage) createClientWithPanicRecovery(absoluteName string)
 				zap.String("backup", backupName))
 
 			// Try to create client again with fresh database
-			client, err = newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, !lfs.cfg.FSync)
+			client, err = newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, !lfs.cfg.FSync, opts...)
 		}
 	}()
 
 	// Try to create the client normally first
-	client, err = newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, !lfs.cfg.FSync)
+	client, err = newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, !lfs.cfg.FSync, opts...)
 	return client, err
 }
 
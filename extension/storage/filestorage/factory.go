@@ -51,6 +51,7 @@ func createDefaultConfig() component.Config {
 		FSync:                false,
 		CreateDirectory:      false,
 		DirectoryPermissions: "0750",
+		MaxSizePolicy:        maxSizePolicyReject,
 	}
 }
 
@@ -59,5 +60,5 @@ func createExtension(
 	params extension.Settings,
 	cfg component.Config,
 ) (extension.Extension, error) {
-	return newLocalFileStorage(params.Logger, cfg.(*Config))
+	return newLocalFileStorage(params.TelemetrySettings, cfg.(*Config))
 }
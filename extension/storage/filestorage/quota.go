@@ -0,0 +1,238 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"sync"
+
+	"go.etcd.io/bbolt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/metadata"
+)
+
+// ErrMaxSizeReached is returned when a write would exceed max_size_mib, and the max size policy
+// is to reject the writes or the written value cannot fit in the max size.
+var ErrMaxSizeReached = errors.New("storage max size reached")
+
+var (
+	// evictionOrderBucket maps the write sequence numbers to the keys, in the order they were written
+	evictionOrderBucket = []byte(`eviction_order`)
+	// evictionKeysBucket maps the keys to their last write sequence number
+	evictionKeysBucket = []byte(`eviction_keys`)
+)
+
+// sizeQuota bounds the size of the keys and values stored by a client.
+type sizeQuota struct {
+	maxSize          int64
+	policy           string
+	telemetryBuilder *metadata.TelemetryBuilder
+
+	// mutex serializes the write transactions, so that usage always matches the committed data
+	mutex sync.Mutex
+	usage int64
+}
+
+// quotaTx tracks the changes of the usage in a write transaction.
+type quotaTx struct {
+	quota   *sizeQuota
+	bucket  *bbolt.Bucket
+	order   *bbolt.Bucket
+	keys    *bbolt.Bucket
+	usage   int64
+	evicted int64
+}
+
+func entrySize(key, value []byte) int64 {
+	return int64(len(key) + len(value))
+}
+
+// init computes the usage of the stored data. When evicting, it also initializes the write order of the keys
+// that were written without eviction, and forgets the keys that were deleted without eviction.
+func (q *sizeQuota) init(ctx context.Context, tx *bbolt.Tx) error {
+	bucket := tx.Bucket(defaultBucket)
+	q.usage = 0
+	if err := bucket.ForEach(func(k, v []byte) error {
+		q.usage += entrySize(k, v)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if q.policy == maxSizePolicyEvictOldest {
+		t, err := q.begin(tx)
+		if err != nil {
+			return err
+		}
+		// the keys are copied, as they are only valid until the buckets are modified
+		var deleted [][]byte
+		if err = t.order.ForEach(func(_, k []byte) error {
+			if bucket.Get(k) == nil {
+				deleted = append(deleted, append([]byte(nil), k...))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, k := range deleted {
+			if err = t.forget(k); err != nil {
+				return err
+			}
+		}
+		var unordered [][]byte
+		if err = bucket.ForEach(func(k, _ []byte) error {
+			if t.keys.Get(k) == nil {
+				unordered = append(unordered, append([]byte(nil), k...))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, k := range unordered {
+			if err = t.touch(k); err != nil {
+				return err
+			}
+		}
+	}
+
+	q.telemetryBuilder.FilestorageStoredSize.Add(ctx, q.usage)
+	return nil
+}
+
+// close stops reporting the usage of the client.
+func (q *sizeQuota) close(ctx context.Context) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.telemetryBuilder.FilestorageStoredSize.Add(ctx, -q.usage)
+	q.usage = 0
+}
+
+// begin starts tracking the usage in a write transaction.
+func (q *sizeQuota) begin(tx *bbolt.Tx) (*quotaTx, error) {
+	t := &quotaTx{quota: q, bucket: tx.Bucket(defaultBucket), usage: q.usage}
+	if q.policy == maxSizePolicyEvictOldest {
+		var err error
+		if t.order, err = tx.CreateBucketIfNotExists(evictionOrderBucket); err != nil {
+			return nil, err
+		}
+		if t.keys, err = tx.CreateBucketIfNotExists(evictionKeysBucket); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// end applies the usage of the transaction once it is committed, and reports it.
+func (q *sizeQuota) end(ctx context.Context, t *quotaTx, err error) {
+	switch {
+	case t == nil:
+	case err == nil:
+		q.telemetryBuilder.FilestorageStoredSize.Add(ctx, t.usage-q.usage)
+		q.telemetryBuilder.FilestorageEvictedEntries.Add(ctx, t.evicted)
+		q.usage = t.usage
+	case errors.Is(err, ErrMaxSizeReached):
+		q.telemetryBuilder.FilestorageRejectedWrites.Add(ctx, 1)
+	}
+}
+
+// put stores the value, evicting the oldest entries if needed and allowed.
+func (t *quotaTx) put(key, value []byte) error {
+	usage := t.usage + entrySize(key, value)
+	if old := t.bucket.Get(key); old != nil {
+		usage -= entrySize(key, old)
+	}
+	for usage > t.quota.maxSize {
+		if t.quota.policy != maxSizePolicyEvictOldest {
+			return ErrMaxSizeReached
+		}
+		size, evicted, err := t.evictOldest(key)
+		if err != nil {
+			return err
+		}
+		if !evicted {
+			// nothing is left to evict, the value alone does not fit
+			return ErrMaxSizeReached
+		}
+		usage -= size
+	}
+
+	if err := t.bucket.Put(key, value); err != nil {
+		return err
+	}
+	t.usage = usage
+	if t.order != nil {
+		return t.touch(key)
+	}
+	return nil
+}
+
+// delete removes the value of the key.
+func (t *quotaTx) delete(key []byte) error {
+	if old := t.bucket.Get(key); old != nil {
+		t.usage -= entrySize(key, old)
+	}
+	if err := t.bucket.Delete(key); err != nil {
+		return err
+	}
+	if t.order != nil {
+		return t.forget(key)
+	}
+	return nil
+}
+
+// evictOldest deletes the oldest written entry other than the given key, and returns its size.
+// It returns false if there is no such entry.
+func (t *quotaTx) evictOldest(except []byte) (int64, bool, error) {
+	cursor := t.order.Cursor()
+	for seq, key := cursor.First(); seq != nil; seq, key = cursor.Next() {
+		if string(key) == string(except) {
+			continue
+		}
+		// copy the key, as it is only valid until the bucket is modified
+		key = append([]byte(nil), key...)
+		var size int64
+		if value := t.bucket.Get(key); value != nil {
+			size = entrySize(key, value)
+		}
+		if err := t.bucket.Delete(key); err != nil {
+			return 0, false, err
+		}
+		if err := t.forget(key); err != nil {
+			return 0, false, err
+		}
+		t.evicted++
+		return size, true, nil
+	}
+	return 0, false, nil
+}
+
+// touch records that the key is the most recently written.
+func (t *quotaTx) touch(key []byte) error {
+	if err := t.forget(key); err != nil {
+		return err
+	}
+	next, err := t.order.NextSequence()
+	if err != nil {
+		return err
+	}
+	seq := binary.BigEndian.AppendUint64(nil, next)
+	if err = t.order.Put(seq, key); err != nil {
+		return err
+	}
+	return t.keys.Put(key, seq)
+}
+
+// forget removes the key from the write order.
+func (t *quotaTx) forget(key []byte) error {
+	seq := t.keys.Get(key)
+	if seq == nil {
+		return nil
+	}
+	if err := t.order.Delete(seq); err != nil {
+		return err
+	}
+	return t.keys.Delete(key)
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/metadatatest"
+)
+
+func newTestQuotaClient(t *testing.T, dbFile string, maxSize int64, policy string) (*fileStorageClient, *componenttest.Telemetry) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() {
+		require.NoError(t, tel.Shutdown(context.Background()))
+	})
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, withMaxSize(maxSize, policy, telemetryBuilder))
+	require.NoError(t, err)
+	return client, tel
+}
+
+func TestClientMaxSizeReject(t *testing.T) {
+	ctx := t.Context()
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+	client, tel := newTestQuotaClient(t, dbFile, 20, maxSizePolicyReject)
+
+	// Every entry uses 10 bytes
+	require.NoError(t, client.Set(ctx, "key1", []byte("value1")))
+	require.NoError(t, client.Set(ctx, "key2", []byte("value2")))
+	require.ErrorIs(t, client.Set(ctx, "key3", []byte("value3")), ErrMaxSizeReached)
+
+	// Overwriting a value only uses the difference
+	require.NoError(t, client.Set(ctx, "key2", []byte("value0")))
+
+	// A rejected batch is rolled back
+	require.ErrorIs(t, client.Batch(ctx,
+		storage.DeleteOperation("key1"),
+		storage.SetOperation("key3", []byte("value3")),
+		storage.SetOperation("key4", []byte("value4")),
+	), ErrMaxSizeReached)
+	value, err := client.Get(ctx, "key1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), value)
+
+	// Deleting frees the space
+	require.NoError(t, client.Delete(ctx, "key1"))
+	require.NoError(t, client.Set(ctx, "key3", []byte("value3")))
+
+	metadatatest.AssertEqualFilestorageStoredSize(t, tel, []metricdata.DataPoint[int64]{{Value: 20}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualFilestorageRejectedWrites(t, tel, []metricdata.DataPoint[int64]{{Value: 2}}, metricdatatest.IgnoreTimestamp())
+
+	// The usage is computed again when the database is opened
+	require.NoError(t, client.Close(ctx))
+	metadatatest.AssertEqualFilestorageStoredSize(t, tel, []metricdata.DataPoint[int64]{{Value: 0}}, metricdatatest.IgnoreTimestamp())
+	client, _ = newTestQuotaClient(t, dbFile, 20, maxSizePolicyReject)
+	require.Equal(t, int64(20), client.quota.usage)
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestClientMaxSizeEvictOldest(t *testing.T) {
+	ctx := t.Context()
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+
+	// Entries written without eviction are evicted first
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key0", []byte("value0")))
+	require.NoError(t, client.Set(ctx, "gone", []byte("value")))
+	require.NoError(t, client.Close(ctx))
+
+	client, tel := newTestQuotaClient(t, dbFile, 30, maxSizePolicyEvictOldest)
+	require.NoError(t, client.Delete(ctx, "gone"))
+	require.NoError(t, client.Set(ctx, "key1", []byte("value1")))
+	require.NoError(t, client.Set(ctx, "key2", []byte("value2")))
+
+	// Writing key1 again makes key2 the oldest entry after key0
+	require.NoError(t, client.Set(ctx, "key1", []byte("value1")))
+	require.NoError(t, client.Set(ctx, "key3", []byte("value3")))
+	require.NoError(t, client.Set(ctx, "key4", []byte("value4")))
+	for key, expected := range map[string][]byte{
+		"key0": nil,
+		"key1": []byte("value1"),
+		"key2": nil,
+		"key3": []byte("value3"),
+		"key4": []byte("value4"),
+	} {
+		value, err := client.Get(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, expected, value, key)
+	}
+
+	// A value that does not fit alone is rejected, without evicting anything
+	require.ErrorIs(t, client.Set(ctx, "large", make([]byte, 30)), ErrMaxSizeReached)
+	value, err := client.Get(ctx, "key3")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value3"), value)
+
+	metadatatest.AssertEqualFilestorageStoredSize(t, tel, []metricdata.DataPoint[int64]{{Value: 30}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualFilestorageEvictedEntries(t, tel, []metricdata.DataPoint[int64]{{Value: 2}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualFilestorageRejectedWrites(t, tel, []metricdata.DataPoint[int64]{{Value: 1}}, metricdatatest.IgnoreTimestamp())
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestExtensionMaxSize(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() {
+		require.NoError(t, tel.Shutdown(context.Background()))
+	})
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.MaxSizeMiB = 1
+	cfg.Encryption = &EncryptionConfig{Key: "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="}
+
+	ext, err := f.Create(t.Context(), metadatatest.NewSettings(tel), cfg)
+	require.NoError(t, err)
+	client, err := ext.(storage.Extension).GetClient(t.Context(), component.KindExporter, newTestEntity("queue"), "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(t.Context()))
+		require.NoError(t, ext.Shutdown(t.Context()))
+	})
+
+	require.NoError(t, client.Set(t.Context(), "key", make([]byte, oneMiB/2)))
+	require.ErrorIs(t, client.Set(t.Context(), "other", make([]byte, oneMiB/2)), ErrMaxSizeReached)
+}
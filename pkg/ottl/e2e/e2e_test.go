@@ -813,6 +813,24 @@ func Test_e2e_converters(t *testing.T) {
 				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
 			},
 		},
+		{
+			statement: `set(attributes["test"], "pass") where IsInCIDR("10.1.2.3", ["192.168.0.0/16", "10.0.0.0/8"])`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], JSONPath("{\"user\": {\"id\": \"u-1\"}}", "$.user.id"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "u-1")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Template("{method} {path}", {"method": attributes["http.method"], "path": attributes["http.path"]}))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "get /health")
+			},
+		},
 		{
 			statement: `set(attributes["test"], "pass") where IsString("")`,
 			want: func(tCtx ottllog.TransformContext) {
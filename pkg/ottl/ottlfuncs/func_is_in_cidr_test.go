@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_isInCIDR(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		networks []string
+		expected bool
+	}{
+		{
+			name:     "ipv4 in network",
+			target:   "192.168.1.10",
+			networks: []string{"192.168.0.0/16"},
+			expected: true,
+		},
+		{
+			name:     "ipv4 not in network",
+			target:   "192.169.1.10",
+			networks: []string{"192.168.0.0/16"},
+			expected: false,
+		},
+		{
+			name:     "ipv4 in one of the networks",
+			target:   "10.0.0.1",
+			networks: []string{"192.168.0.0/16", "172.16.0.0/12", "10.0.0.0/8"},
+			expected: true,
+		},
+		{
+			name:     "network with host bits",
+			target:   "10.20.30.40",
+			networks: []string{"10.20.30.1/24"},
+			expected: true,
+		},
+		{
+			name:     "ipv6 in network",
+			target:   "2001:db8::1",
+			networks: []string{"2001:db8::/32"},
+			expected: true,
+		},
+		{
+			name:     "ipv6 not in ipv4 network",
+			target:   "2001:db8::1",
+			networks: []string{"0.0.0.0/0"},
+			expected: false,
+		},
+		{
+			name:     "ipv4-mapped ipv6 in ipv4 network",
+			target:   "::ffff:10.0.0.1",
+			networks: []string{"10.0.0.0/8"},
+			expected: true,
+		},
+		{
+			name:     "not an ip",
+			target:   "localhost",
+			networks: []string{"127.0.0.0/8"},
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc, err := isInCIDR[any](target, tt.networks)
+			require.NoError(t, err)
+			result, err := exprFunc(t.Context(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_isInCIDR_validation(t *testing.T) {
+	target := &ottl.StandardStringGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return "10.0.0.1", nil
+		},
+	}
+
+	_, err := isInCIDR[any](target, nil)
+	assert.ErrorContains(t, err, "at least one network must be given")
+
+	_, err = isInCIDR[any](target, []string{"10.0.0.0/8", "10.0.0.0"})
+	assert.ErrorContains(t, err, `invalid network "10.0.0.0"`)
+}
+
+func Test_isInCIDR_error(t *testing.T) {
+	target := &ottl.StandardStringGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return 10, nil
+		},
+	}
+	exprFunc, err := isInCIDR[any](target, []string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	_, err = exprFunc(t.Context(), nil)
+	assert.Error(t, err)
+}
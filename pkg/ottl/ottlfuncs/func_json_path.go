@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/goccy/go-json"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type JSONPathArguments[K any] struct {
+	Target ottl.StringGetter[K]
+	Path   string
+}
+
+func NewJSONPathFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("JSONPath", &JSONPathArguments[K]{}, createJSONPathFunction[K])
+}
+
+func createJSONPathFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*JSONPathArguments[K])
+
+	if !ok {
+		return nil, errors.New("JSONPathFactory args must be of type *JSONPathArguments[K]")
+	}
+
+	return jsonPath(args.Target, args.Path)
+}
+
+// jsonPath returns the value selected by the JSONPath in the target JSON string, only decoding the selected values.
+// A path selecting all the elements of arrays with [*] returns a `pcommon.Slice` of the selected values.
+func jsonPath[K any](target ottl.StringGetter[K], path string) (ottl.ExprFunc[K], error) {
+	wildcard, recursive := scanJSONPath(path)
+	if recursive {
+		return nil, fmt.Errorf("JSONPath: invalid path %q: recursive descent is not supported", path)
+	}
+	compiledPath, err := json.CreatePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("JSONPath: invalid path %q: %w", path, err)
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		targetVal, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		extracted, err := compiledPath.Extract([]byte(targetVal))
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]any, 0, len(extracted))
+		for _, raw := range extracted {
+			var value any
+			if err = json.Unmarshal(raw, &value); err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+
+		if wildcard {
+			result := pcommon.NewSlice()
+			err = result.FromRaw(values)
+			return result, err
+		}
+		if len(values) == 0 {
+			return nil, nil
+		}
+		switch v := values[0].(type) {
+		case []any:
+			result := pcommon.NewSlice()
+			err = result.FromRaw(v)
+			return result, err
+		case map[string]any:
+			result := pcommon.NewMap()
+			err = result.FromRaw(v)
+			return result, err
+		default:
+			return v, nil
+		}
+	}, nil
+}
+
+// scanJSONPath reports whether the path selects all the elements of arrays, and whether it uses recursive
+// descent, ignoring the quoted field names.
+func scanJSONPath(path string) (wildcard, recursive bool) {
+	var quote rune
+	var previous rune
+	for _, r := range path {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '*':
+			wildcard = true
+		case r == '.' && previous == '.':
+			recursive = true
+		}
+		previous = r
+	}
+	return wildcard, recursive
+}
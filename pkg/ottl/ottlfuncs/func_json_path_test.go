@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_jsonPath(t *testing.T) {
+	const document = `{"user": {"id": "u-1", "age": 42, "admin": true}, "a.b": "dotted", "tags": ["x", "y"], "items": [{"name": "first"}, {"name": "second", "price": 1.5}], "none": null}`
+
+	tests := []struct {
+		name     string
+		path     string
+		expected func() any
+	}{
+		{
+			name:     "string",
+			path:     "$.user.id",
+			expected: func() any { return "u-1" },
+		},
+		{
+			name:     "number",
+			path:     "$.user.age",
+			expected: func() any { return float64(42) },
+		},
+		{
+			name:     "bool",
+			path:     "$.user.admin",
+			expected: func() any { return true },
+		},
+		{
+			name:     "null",
+			path:     "$.none",
+			expected: func() any { return nil },
+		},
+		{
+			name:     "missing",
+			path:     "$.user.email",
+			expected: func() any { return nil },
+		},
+		{
+			name:     "quoted field",
+			path:     "$['a.b']",
+			expected: func() any { return "dotted" },
+		},
+		{
+			name:     "array index",
+			path:     "$.items[1].name",
+			expected: func() any { return "second" },
+		},
+		{
+			name: "object",
+			path: "$.user",
+			expected: func() any {
+				m := pcommon.NewMap()
+				m.PutStr("id", "u-1")
+				m.PutDouble("age", 42)
+				m.PutBool("admin", true)
+				return m
+			},
+		},
+		{
+			name: "array",
+			path: "$.tags",
+			expected: func() any {
+				s := pcommon.NewSlice()
+				s.AppendEmpty().SetStr("x")
+				s.AppendEmpty().SetStr("y")
+				return s
+			},
+		},
+		{
+			name: "wildcard",
+			path: "$.items[*].name",
+			expected: func() any {
+				s := pcommon.NewSlice()
+				s.AppendEmpty().SetStr("first")
+				s.AppendEmpty().SetStr("second")
+				return s
+			},
+		},
+		{
+			name: "wildcard without match",
+			path: "$.items[*].missing",
+			expected: func() any {
+				return pcommon.NewSlice()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return document, nil
+				},
+			}
+			exprFunc, err := jsonPath[any](target, tt.path)
+			require.NoError(t, err)
+			result, err := exprFunc(t.Context(), nil)
+			require.NoError(t, err)
+			if expected, ok := tt.expected().(pcommon.Map); ok {
+				// the order of the keys of the decoded objects is not preserved
+				resultMap, ok := result.(pcommon.Map)
+				require.True(t, ok)
+				assert.Equal(t, expected.AsRaw(), resultMap.AsRaw())
+				return
+			}
+			assert.Equal(t, tt.expected(), result)
+		})
+	}
+}
+
+func Test_jsonPath_validation(t *testing.T) {
+	target := &ottl.StandardStringGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return `{}`, nil
+		},
+	}
+
+	_, err := jsonPath[any](target, "user.id")
+	assert.ErrorContains(t, err, `JSONPath: invalid path "user.id"`)
+
+	_, err = jsonPath[any](target, "$..id")
+	assert.ErrorContains(t, err, "recursive descent is not supported")
+
+	// dots in quoted field names are not recursive descent
+	_, err = jsonPath[any](target, "$['a..b']")
+	assert.NoError(t, err)
+}
+
+func Test_jsonPath_error(t *testing.T) {
+	target := &ottl.StandardStringGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return `{"user": `, nil
+		},
+	}
+	exprFunc, err := jsonPath[any](target, "$.user")
+	require.NoError(t, err)
+	_, err = exprFunc(t.Context(), nil)
+	assert.Error(t, err)
+}
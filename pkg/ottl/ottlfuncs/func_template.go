@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type TemplateArguments[K any] struct {
+	Template string
+	Values   ottl.PMapGetter[K]
+}
+
+func NewTemplateFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Template", &TemplateArguments[K]{}, createTemplateFunction[K])
+}
+
+func createTemplateFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*TemplateArguments[K])
+
+	if !ok {
+		return nil, errors.New("TemplateFactory args must be of type *TemplateArguments[K]")
+	}
+
+	return renderTemplate(args.Template, args.Values)
+}
+
+// templatePart is either a literal text or a placeholder of the template.
+type templatePart struct {
+	text        string
+	placeholder bool
+}
+
+func renderTemplate[K any](template string, values ottl.PMapGetter[K]) (ottl.ExprFunc[K], error) {
+	parts, err := parseTemplate(template)
+	if err != nil {
+		return nil, fmt.Errorf("Template: invalid template %q: %w", template, err)
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		vals, err := values.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		var sb strings.Builder
+		for _, part := range parts {
+			if !part.placeholder {
+				sb.WriteString(part.text)
+				continue
+			}
+			val, ok := vals.Get(part.text)
+			if !ok {
+				return nil, fmt.Errorf("Template: no value for the placeholder %q", part.text)
+			}
+			sb.WriteString(val.AsString())
+		}
+		return sb.String(), nil
+	}, nil
+}
+
+// parseTemplate splits the template into literal texts and {name} placeholders, "{{" and "}}" being
+// the escaped braces.
+func parseTemplate(template string) ([]templatePart, error) {
+	var parts []templatePart
+	var text strings.Builder
+	for i := 0; i < len(template); i++ {
+		switch c := template[i]; {
+		case c == '{' && i+1 < len(template) && template[i+1] == '{':
+			text.WriteByte('{')
+			i++
+		case c == '}' && i+1 < len(template) && template[i+1] == '}':
+			text.WriteByte('}')
+			i++
+		case c == '{':
+			end := strings.IndexAny(template[i+1:], "{}")
+			if end < 0 || template[i+1+end] != '}' {
+				return nil, fmt.Errorf("unclosed placeholder at position %d", i)
+			}
+			name := template[i+1 : i+1+end]
+			if name == "" {
+				return nil, fmt.Errorf("empty placeholder at position %d", i)
+			}
+			if text.Len() > 0 {
+				parts = append(parts, templatePart{text: text.String()})
+				text.Reset()
+			}
+			parts = append(parts, templatePart{text: name, placeholder: true})
+			i += end + 1
+		case c == '}':
+			return nil, fmt.Errorf("unexpected '}' at position %d, use '}}' to escape it", i)
+		default:
+			text.WriteByte(c)
+		}
+	}
+	if text.Len() > 0 {
+		parts = append(parts, templatePart{text: text.String()})
+	}
+	return parts, nil
+}
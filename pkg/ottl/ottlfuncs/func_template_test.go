@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_template(t *testing.T) {
+	values := pcommon.NewMap()
+	values.PutStr("user", "alice")
+	values.PutInt("count", 3)
+	values.PutEmpty("missing")
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{
+			name:     "placeholders",
+			template: "{user} logged in {count} times",
+			expected: "alice logged in 3 times",
+		},
+		{
+			name:     "repeated placeholder",
+			template: "{user}/{user}",
+			expected: "alice/alice",
+		},
+		{
+			name:     "empty value",
+			template: "user={user} missing={missing}",
+			expected: "user=alice missing=",
+		},
+		{
+			name:     "escaped braces",
+			template: "{{\"user\": \"{user}\"}}",
+			expected: `{"user": "alice"}`,
+		},
+		{
+			name:     "no placeholder",
+			template: "static",
+			expected: "static",
+		},
+		{
+			name:     "empty template",
+			template: "",
+			expected: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getter := &ottl.StandardPMapGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return values, nil
+				},
+			}
+			exprFunc, err := renderTemplate[any](tt.template, getter)
+			require.NoError(t, err)
+			result, err := exprFunc(t.Context(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_template_validation(t *testing.T) {
+	getter := &ottl.StandardPMapGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return pcommon.NewMap(), nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		err      string
+	}{
+		{
+			name:     "unclosed placeholder",
+			template: "hello {user",
+			err:      "unclosed placeholder at position 6",
+		},
+		{
+			name:     "nested placeholder",
+			template: "hello {us{er}",
+			err:      "unclosed placeholder at position 6",
+		},
+		{
+			name:     "empty placeholder",
+			template: "hello {}",
+			err:      "empty placeholder at position 6",
+		},
+		{
+			name:     "unexpected closing brace",
+			template: "hello }",
+			err:      "unexpected '}' at position 6",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := renderTemplate[any](tt.template, getter)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func Test_template_missing_value(t *testing.T) {
+	getter := &ottl.StandardPMapGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return pcommon.NewMap(), nil
+		},
+	}
+	exprFunc, err := renderTemplate[any]("{user}", getter)
+	require.NoError(t, err)
+	_, err = exprFunc(t.Context(), nil)
+	assert.ErrorContains(t, err, `no value for the placeholder "user"`)
+}
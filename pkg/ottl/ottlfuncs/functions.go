@@ -64,6 +64,7 @@ func converters[K any]() []ottl.Factory[K] {
 		NewIsListFactory[K](),
 		NewIsIntFactory[K](),
 		NewIsMapFactory[K](),
+		NewIsInCIDRFactory[K](),
 		NewIsMatchFactory[K](),
 		NewIsStringFactory[K](),
 		NewLenFactory[K](),
@@ -127,6 +128,8 @@ func converters[K any]() []ottl.Factory[K] {
 		NewProfileIDFactory[K](),
 		NewParseIntFactory[K](),
 		NewKeysFactory[K](),
+		NewJSONPathFactory[K](),
+		NewTemplateFactory[K](),
 		NewXXH3Factory[K](),
 		NewXXH128Factory[K](),
 	}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpinput // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/httpinput"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/textutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "http_input"
+
+	// FormatNewline creates an entry from every line of the request body
+	FormatNewline = "newline"
+	// FormatJSONArray creates an entry from every element of the JSON array in the request body
+	FormatJSONArray = "json_array"
+
+	defaultPath = "/"
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new HTTP input config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new HTTP input config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		InputConfig: helper.NewInputConfig(operatorID, operatorType),
+		BaseConfig: BaseConfig{
+			ServerConfig: confighttp.NewDefaultServerConfig(),
+			Path:         defaultPath,
+			Format:       FormatNewline,
+			Encoding:     "utf-8",
+		},
+	}
+}
+
+// Config is the configuration of an HTTP input operator.
+type Config struct {
+	helper.InputConfig `mapstructure:",squash"`
+	BaseConfig         `mapstructure:",squash"`
+}
+
+// BaseConfig is the detailed configuration of an HTTP input operator.
+type BaseConfig struct {
+	ServerConfig  confighttp.ServerConfig `mapstructure:",squash"`
+	Path          string                  `mapstructure:"path,omitempty"`
+	Format        string                  `mapstructure:"format,omitempty"`
+	Encoding      string                  `mapstructure:"encoding,omitempty"`
+	AddAttributes bool                    `mapstructure:"add_attributes,omitempty"`
+}
+
+// Build will build an HTTP input operator.
+func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error) {
+	inputOperator, err := c.InputConfig.Build(set)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ServerConfig.Endpoint == "" {
+		return nil, errors.New("missing required parameter 'endpoint'")
+	}
+
+	if !strings.HasPrefix(c.Path, "/") {
+		return nil, fmt.Errorf("invalid value for parameter 'path', it must start with '/': %q", c.Path)
+	}
+
+	switch c.Format {
+	case FormatNewline, FormatJSONArray:
+	default:
+		return nil, fmt.Errorf("invalid value for parameter 'format', must be '%s' or '%s': %q", FormatNewline, FormatJSONArray, c.Format)
+	}
+
+	enc, err := textutils.LookupEncoding(c.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Input{
+		InputOperator: inputOperator,
+		serverConfig:  c.ServerConfig,
+		telemetry:     set,
+		path:          c.Path,
+		format:        c.Format,
+		encoding:      enc,
+		addAttributes: c.AddAttributes,
+		host:          nopHost{},
+	}, nil
+}
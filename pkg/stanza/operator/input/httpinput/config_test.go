@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpinput
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configtls"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+func TestUnmarshal(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:               "default",
+				ExpectUnmarshalErr: false,
+				Expect:             NewConfig(),
+			},
+			{
+				Name:               "all",
+				ExpectUnmarshalErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ServerConfig.Endpoint = "0.0.0.0:8080"
+					cfg.ServerConfig.MaxRequestBodySize = 1048576
+					cfg.ServerConfig.TLS = configoptional.Some(configtls.ServerConfig{
+						Config: configtls.Config{
+							CertFile: "foo",
+							KeyFile:  "foo2",
+						},
+					})
+					cfg.Path = "/logs"
+					cfg.Format = FormatJSONArray
+					cfg.Encoding = "utf-16le"
+					cfg.AddAttributes = true
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
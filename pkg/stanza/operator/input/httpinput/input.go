@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpinput // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/httpinput"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.uber.org/zap"
+	"golang.org/x/text/encoding"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/textutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// Input is an operator that receives log entries in the body of HTTP requests.
+type Input struct {
+	helper.InputOperator
+	serverConfig  confighttp.ServerConfig
+	telemetry     component.TelemetrySettings
+	path          string
+	format        string
+	encoding      encoding.Encoding
+	addAttributes bool
+
+	// host provides the extensions used by the server, such as the authenticator
+	host     component.Host
+	listener net.Listener
+	server   *http.Server
+	wg       sync.WaitGroup
+}
+
+var _ operator.HostAware = (*Input)(nil)
+
+// SetHost sets the host providing the extensions used by the server.
+func (i *Input) SetHost(host component.Host) {
+	i.host = host
+}
+
+// Start will start listening for log entries over HTTP.
+func (i *Input) Start(_ operator.Persister) error {
+	ctx := context.Background()
+	var err error
+	i.listener, err = i.serverConfig.ToListener(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to listen on interface: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(i.path, i.handleRequest)
+	i.server, err = i.serverConfig.ToServer(ctx, i.host, i.telemetry, mux)
+	if err != nil {
+		_ = i.listener.Close()
+		return fmt.Errorf("failed to create http server: %w", err)
+	}
+
+	i.wg.Add(1)
+	go func() {
+		defer i.wg.Done()
+		if err := i.server.Serve(i.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			i.Logger().Error("HTTP server failed", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (i *Input) handleRequest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST requests are accepted", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read the request body", http.StatusBadRequest)
+		return
+	}
+
+	bodies, err := i.parseBody(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entries := make([]*entry.Entry, 0, len(bodies))
+	for _, b := range bodies {
+		e, err := i.NewEntry(b)
+		if err != nil {
+			i.Logger().Error("Failed to create entry", zap.Error(err))
+			http.Error(w, "failed to create the log entries", http.StatusInternalServerError)
+			return
+		}
+		if i.addAttributes {
+			addRequestAttributes(e, r)
+		}
+		entries = append(entries, e)
+	}
+
+	if len(entries) > 0 {
+		if err := i.WriteBatch(r.Context(), entries); err != nil {
+			i.Logger().Error("Failed to write entries", zap.Error(err))
+			http.Error(w, "failed to process the log entries", http.StatusServiceUnavailable)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// parseBody returns the bodies of the entries in the request body.
+func (i *Input) parseBody(body []byte) ([]any, error) {
+	decoded, err := textutils.DecodeAsString(i.encoding.NewDecoder(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the request body: %w", err)
+	}
+
+	if i.format == FormatJSONArray {
+		var bodies []any
+		if err := json.Unmarshal([]byte(decoded), &bodies); err != nil {
+			return nil, fmt.Errorf("the request body is not a JSON array: %w", err)
+		}
+		return bodies, nil
+	}
+
+	var bodies []any
+	for line := range strings.SplitSeq(decoded, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if line != "" {
+			bodies = append(bodies, line)
+		}
+	}
+	return bodies, nil
+}
+
+func addRequestAttributes(e *entry.Entry, r *http.Request) {
+	e.AddAttribute("http.request.method", r.Method)
+	e.AddAttribute("url.path", r.URL.Path)
+	if userAgent := r.UserAgent(); userAgent != "" {
+		e.AddAttribute("user_agent.original", userAgent)
+	}
+	if host, port, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		e.AddAttribute("client.address", host)
+		e.AddAttribute("client.port", port)
+	}
+}
+
+// Stop will stop listening for log entries over HTTP.
+func (i *Input) Stop() error {
+	if i.server == nil {
+		return nil
+	}
+	err := i.server.Close()
+	i.wg.Wait()
+	return err
+}
+
+// nopHost is used when the operator is not run by a component providing its host,
+// no extension can then be used by the server.
+type nopHost struct{}
+
+func (nopHost) GetExtensions() map[component.ID]component.Component {
+	return nil
+}
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpinput
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestInput(t *testing.T, cfg *Config) (*Input, *testutil.FakeOutput) {
+	cfg.ServerConfig.Endpoint = "127.0.0.1:0"
+	op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	httpInput := op.(*Input)
+	httpInput.OutputOperators = []operator.Operator{fake}
+
+	require.NoError(t, httpInput.Start(testutil.NewUnscopedMockPersister()))
+	t.Cleanup(func() {
+		require.NoError(t, httpInput.Stop(), "expected to stop http input operator without error")
+	})
+	return httpInput, fake
+}
+
+func post(t *testing.T, httpInput *Input, path, body string) *http.Response {
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, "http://"+httpInput.listener.Addr().String()+path, strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp
+}
+
+func TestInputNewline(t *testing.T) {
+	httpInput, fake := newTestInput(t, NewConfigWithID("test_id"))
+
+	resp := post(t, httpInput, "/", "message1\r\nmessage2\n\nmessage3")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	fake.ExpectBody(t, "message1")
+	fake.ExpectBody(t, "message2")
+	fake.ExpectBody(t, "message3")
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestInputJSONArray(t *testing.T) {
+	cfg := NewConfigWithID("test_id")
+	cfg.Format = FormatJSONArray
+	httpInput, fake := newTestInput(t, cfg)
+
+	resp := post(t, httpInput, "/", `[{"message": "first", "level": "info"}, "second"]`)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	fake.ExpectBody(t, map[string]any{"message": "first", "level": "info"})
+	fake.ExpectBody(t, "second")
+
+	resp = post(t, httpInput, "/", `{"message": "not an array"}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestInputAttributes(t *testing.T) {
+	cfg := NewConfigWithID("test_id")
+	cfg.Path = "/logs"
+	cfg.AddAttributes = true
+	httpInput, fake := newTestInput(t, cfg)
+
+	resp := post(t, httpInput, "/logs", "message")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	select {
+	case e := <-fake.Received:
+		assert.Equal(t, "message", e.Body)
+		assert.Equal(t, http.MethodPost, e.Attributes["http.request.method"])
+		assert.Equal(t, "/logs", e.Attributes["url.path"])
+		assert.Equal(t, "127.0.0.1", e.Attributes["client.address"])
+		assert.NotEmpty(t, e.Attributes["client.port"])
+		assert.NotEmpty(t, e.Attributes["user_agent.original"])
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for entry")
+	}
+
+	// Requests to other paths are not handled
+	resp = post(t, httpInput, "/other", "message")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestInputMethodNotAllowed(t *testing.T) {
+	httpInput, fake := newTestInput(t, NewConfigWithID("test_id"))
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://"+httpInput.listener.Addr().String()+"/", http.NoBody)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestInputMissingAuthenticator(t *testing.T) {
+	cfg := NewConfigWithID("test_id")
+	cfg.ServerConfig.Endpoint = "127.0.0.1:0"
+	cfg.ServerConfig.Auth = configoptional.Some(confighttp.AuthConfig{
+		Config: configauth.Config{AuthenticatorID: component.MustNewID("missing")},
+	})
+	op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	httpInput := op.(*Input)
+	httpInput.SetHost(componenttest.NewNopHost())
+	require.ErrorContains(t, httpInput.Start(testutil.NewUnscopedMockPersister()), "failed to create http server")
+	require.NoError(t, httpInput.Stop())
+}
+
+func TestBuild(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(*Config)
+		errMsg string
+	}{
+		{
+			name:   "missing endpoint",
+			modify: func(*Config) {},
+			errMsg: "missing required parameter 'endpoint'",
+		},
+		{
+			name: "invalid path",
+			modify: func(cfg *Config) {
+				cfg.ServerConfig.Endpoint = "localhost:8080"
+				cfg.Path = "logs"
+			},
+			errMsg: "invalid value for parameter 'path'",
+		},
+		{
+			name: "invalid format",
+			modify: func(cfg *Config) {
+				cfg.ServerConfig.Endpoint = "localhost:8080"
+				cfg.Format = "xml"
+			},
+			errMsg: "invalid value for parameter 'format'",
+		},
+		{
+			name: "invalid encoding",
+			modify: func(cfg *Config) {
+				cfg.ServerConfig.Endpoint = "localhost:8080"
+				cfg.Encoding = "unknown"
+			},
+			errMsg: "unsupported encoding",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test_id")
+			tc.modify(cfg)
+			_, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+			require.ErrorContains(t, err, tc.errMsg)
+		})
+	}
+}
@@ -6,6 +6,7 @@ package operator // import "github.com/open-telemetry/opentelemetry-collector-co
 import (
 	"context"
 
+	"go.opentelemetry.io/collector/component"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
@@ -43,3 +44,9 @@ type Operator interface {
 	// Logger returns the operator's logger
 	Logger() *zap.Logger
 }
+
+// HostAware is implemented by the operators that need the host of the component running the pipeline,
+// for example to get an authentication extension. SetHost is called before the operator is started.
+type HostAware interface {
+	SetHost(component.Host)
+}
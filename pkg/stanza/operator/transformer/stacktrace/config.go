@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stacktrace // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/stacktrace"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const operatorType = "stacktrace"
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new stacktrace config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new stacktrace config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		TransformerConfig: helper.NewTransformerConfig(operatorID, operatorType),
+		Languages:         []string{languageJava, languagePython, languageGo, languageDotNet},
+		Field:             entry.NewBodyField(),
+		SourceIdentifier:  entry.NewAttributeField(attrs.LogFilePath),
+		MaxBatchSize:      1000,
+		MaxSources:        1000,
+		ForceFlushTimeout: 5 * time.Second,
+	}
+}
+
+// Config is the configuration of a stacktrace operator
+type Config struct {
+	helper.TransformerConfig `mapstructure:",squash"`
+	Languages                []string        `mapstructure:"languages"`
+	Field                    entry.Field     `mapstructure:"field"`
+	SourceIdentifier         entry.Field     `mapstructure:"source_identifier"`
+	ParseException           bool            `mapstructure:"parse_exception"`
+	MaxBatchSize             int             `mapstructure:"max_batch_size"`
+	MaxSources               int             `mapstructure:"max_sources"`
+	MaxLogSize               helper.ByteSize `mapstructure:"max_log_size,omitempty"`
+	ForceFlushTimeout        time.Duration   `mapstructure:"force_flush_period"`
+}
+
+// Build creates a new Transformer from a config
+func (c *Config) Build(set component.TelemetrySettings) (operator.Operator, error) {
+	transformer, err := c.TransformerConfig.Build(set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transformer config: %w", err)
+	}
+
+	if len(c.Languages) == 0 {
+		return nil, errors.New("at least one language must be set")
+	}
+	langs := make([]*language, 0, len(c.Languages))
+	for _, name := range c.Languages {
+		lang, ok := languages[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported language '%s', must be one of %s, %s, %s or %s", name, languageJava, languagePython, languageGo, languageDotNet)
+		}
+		langs = append(langs, lang)
+	}
+
+	if c.Field.FieldInterface == nil {
+		return nil, errors.New("missing required argument 'field'")
+	}
+
+	if c.MaxBatchSize <= 0 {
+		return nil, errors.New("'max_batch_size' must be greater than 0")
+	}
+
+	if c.ForceFlushTimeout <= 0 {
+		return nil, errors.New("'force_flush_period' must be greater than 0")
+	}
+
+	return &Transformer{
+		TransformerOperator: transformer,
+		languages:           langs,
+		field:               c.Field,
+		sourceIdentifier:    c.SourceIdentifier,
+		parseException:      c.ParseException,
+		maxBatchSize:        c.MaxBatchSize,
+		maxSources:          c.MaxSources,
+		maxLogSize:          int(c.MaxLogSize),
+		forceFlushTimeout:   c.ForceFlushTimeout,
+		ticker:              time.NewTicker(c.ForceFlushTimeout),
+		chClose:             make(chan struct{}),
+		batchMap:            make(map[string]*sourceBatch),
+	}, nil
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stacktrace
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+func TestUnmarshal(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:   "default",
+				Expect: NewConfig(),
+			},
+			{
+				Name: "custom_id",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.OperatorID = "merge-stack-traces"
+					return cfg
+				}(),
+			},
+			{
+				Name: "java_only",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Languages = []string{languageJava}
+					return cfg
+				}(),
+			},
+			{
+				Name: "parse_exception",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ParseException = true
+					cfg.Field = entry.NewAttributeField("message")
+					cfg.SourceIdentifier = entry.NewAttributeField("container_id")
+					cfg.MaxBatchSize = 500
+					cfg.MaxSources = 10
+					cfg.MaxLogSize = helper.ByteSize(256000)
+					cfg.ForceFlushTimeout = time.Second
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
+
+func TestBuildInvalid(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(*Config)
+		errMsg string
+	}{
+		{
+			name:   "no languages",
+			modify: func(cfg *Config) { cfg.Languages = nil },
+			errMsg: "at least one language must be set",
+		},
+		{
+			name:   "unsupported language",
+			modify: func(cfg *Config) { cfg.Languages = []string{"cobol"} },
+			errMsg: "unsupported language 'cobol'",
+		},
+		{
+			name:   "invalid max batch size",
+			modify: func(cfg *Config) { cfg.MaxBatchSize = 0 },
+			errMsg: "'max_batch_size' must be greater than 0",
+		},
+		{
+			name:   "invalid force flush period",
+			modify: func(cfg *Config) { cfg.ForceFlushTimeout = 0 },
+			errMsg: "'force_flush_period' must be greater than 0",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfig()
+			tc.modify(cfg)
+			_, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+			require.ErrorContains(t, err, tc.errMsg)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stacktrace // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/stacktrace"
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	languageJava   = "java"
+	languagePython = "python"
+	languageGo     = "go"
+	languageDotNet = "dotnet"
+)
+
+const (
+	// stateStart is the state before the first line of a stack trace
+	stateStart = "start"
+	// stateEnd is the state after the last line of a stack trace, which can be recognized in some languages
+	stateEnd = "end"
+	// stateNone is the state after a line that does not belong to the stack trace
+	stateNone = ""
+)
+
+// rule is a transition of the state machine recognizing the lines of a stack trace.
+type rule struct {
+	from    []string
+	pattern *regexp.Regexp
+	to      string
+}
+
+func newRule(from []string, pattern, to string) rule {
+	return rule{from: from, pattern: regexp.MustCompile(pattern), to: to}
+}
+
+// language recognizes the stack traces of a language, and extracts the exception they describe.
+type language struct {
+	name  string
+	rules []rule
+	// parse returns the type and the message of the exception in the lines of a stack trace
+	parse func(lines []string) (excType, excMessage string)
+}
+
+// next returns the state after the line, or stateNone if the line does not continue the stack trace.
+func (l *language) next(state, line string) string {
+	for _, r := range l.rules {
+		if slices.Contains(r.from, state) && r.pattern.MatchString(line) {
+			return r.to
+		}
+	}
+	return stateNone
+}
+
+var languages = map[string]*language{
+	languageJava: {
+		name: languageJava,
+		rules: []rule{
+			newRule([]string{stateStart}, `(?:Exception|Error|Throwable)(?::|$)`, "java_after_exception"),
+			newRule([]string{"java_after_exception"}, `^[\t ]*nested exception is:`, "java_after_exception"),
+			newRule([]string{"java_after_exception", "java"}, `^[\t ]+(?:eval )?at `, "java"),
+			newRule([]string{"java"}, `^[\t ]*(?:Caused by|Suppressed):`, "java_after_exception"),
+			newRule([]string{"java"}, `^[\t ]*\.\.\. \d+ (?:more|common frames omitted)`, "java"),
+		},
+		parse: parseFirstLineException,
+	},
+	languagePython: {
+		name: languagePython,
+		rules: []rule{
+			newRule([]string{stateStart}, `^Traceback \(most recent call last\):$`, "python"),
+			newRule([]string{"python", "python_code"}, `^[\t ]+File `, "python_code"),
+			newRule([]string{"python_code"}, `^[\t ]+\S`, "python"),
+			newRule([]string{"python"}, `^[\t ]+[~^]+[\t ]*$`, "python"),
+			newRule([]string{"python", "python_code"}, `^(?:[^\s.():]+\.)*[^\s.():]+(?::|$)`, stateEnd),
+		},
+		parse: parsePythonException,
+	},
+	languageGo: {
+		name: languageGo,
+		rules: []rule{
+			newRule([]string{stateStart}, `\bpanic: `, "go_after_panic"),
+			newRule([]string{stateStart}, `http: panic serving`, "go_goroutine"),
+			newRule([]string{"go_after_panic"}, `^[\t ]+panic: `, "go_after_panic"),
+			newRule([]string{"go_after_panic"}, `^\[signal `, "go_after_signal"),
+			newRule([]string{"go_after_panic", "go_after_signal", "go_frame_1"}, `^$`, "go_goroutine"),
+			newRule([]string{"go_goroutine"}, `^goroutine \d+ \[[^\]]+\]:$`, "go_frame_1"),
+			newRule([]string{"go_frame_1"}, `^(?:[^\s.:]+\.)*[^\s.():]+\(|^created by `, "go_frame_2"),
+			newRule([]string{"go_frame_2"}, `^\s`, "go_frame_1"),
+		},
+		parse: parseGoPanic,
+	},
+	languageDotNet: {
+		name: languageDotNet,
+		rules: []rule{
+			newRule([]string{stateStart}, `^(?:Unhandled [Ee]xception[.:] )?(?:[A-Za-z_]\w*\.)+[A-Za-z_]\w*(?:Exception|Error)\b`, "dotnet_after_exception"),
+			newRule([]string{"dotnet_after_exception"}, `^[\t ]*---> `, "dotnet_after_exception"),
+			newRule([]string{"dotnet_after_exception", "dotnet"}, `^[\t ]+at `, "dotnet"),
+			newRule([]string{"dotnet"}, `^[\t ]*--- End of (?:inner exception stack trace|stack trace from previous location)`, "dotnet"),
+		},
+		parse: parseFirstLineException,
+	},
+}
+
+var (
+	firstLineExceptionRegexp = regexp.MustCompile(`((?:[A-Za-z_$][\w$]*\.)*[A-Za-z_$][\w$]*(?:Exception|Error|Throwable)[\w$]*)(?::[\t ]*(.*))?`)
+	pythonExceptionRegexp    = regexp.MustCompile(`^((?:[^\s.():]+\.)*[^\s.():]+)(?::[\t ]*(.*))?$`)
+	goPanicRegexp            = regexp.MustCompile(`\bpanic: (.*?)(?: \[recovered\])?$`)
+)
+
+// parseFirstLineException parses the exception of the first line, for the languages printing the
+// exception before the frames, such as Java or .NET.
+func parseFirstLineException(lines []string) (string, string) {
+	line := strings.TrimPrefix(lines[0], "Unhandled exception. ")
+	line = strings.TrimPrefix(line, "Unhandled Exception: ")
+	if strings.HasPrefix(line, "Exception in thread ") {
+		// skip the thread name, which can contain anything
+		if _, rest, ok := strings.Cut(line[len("Exception in thread "):], "\" "); ok {
+			line = rest
+		}
+	}
+	match := firstLineExceptionRegexp.FindStringSubmatch(line)
+	if match == nil {
+		return "", ""
+	}
+	return match[1], match[2]
+}
+
+// parsePythonException parses the exception of the last line of a Python traceback.
+func parsePythonException(lines []string) (string, string) {
+	match := pythonExceptionRegexp.FindStringSubmatch(lines[len(lines)-1])
+	if match == nil {
+		return "", ""
+	}
+	return match[1], match[2]
+}
+
+// parseGoPanic parses the value of a Go panic, which is only a message.
+func parseGoPanic(lines []string) (string, string) {
+	match := goPanicRegexp.FindStringSubmatch(lines[0])
+	if match == nil {
+		return "", ""
+	}
+	return "", match[1]
+}
@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stacktrace // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/stacktrace"
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	defaultSourceIdentifier = "DefaultSourceIdentifier"
+
+	attrExceptionType       = "exception.type"
+	attrExceptionMessage    = "exception.message"
+	attrExceptionStacktrace = "exception.stacktrace"
+)
+
+// Transformer is an operator that combines the lines of a stack trace into a single entry
+type Transformer struct {
+	helper.TransformerOperator
+	languages         []*language
+	field             entry.Field
+	sourceIdentifier  entry.Field
+	parseException    bool
+	maxBatchSize      int
+	maxSources        int
+	maxLogSize        int
+	forceFlushTimeout time.Duration
+	ticker            *time.Ticker
+	chClose           chan struct{}
+
+	sync.Mutex
+	batchMap map[string]*sourceBatch
+}
+
+// sourceBatch contains the lines of the stack trace being combined for a source
+type sourceBatch struct {
+	baseEntry              *entry.Entry
+	lines                  []string
+	size                   int
+	firstEntryObservedTime time.Time
+	// states are the states of the stack trace in every language, in the order of t.languages
+	states []string
+}
+
+func (t *Transformer) Start(_ operator.Persister) error {
+	go t.flushLoop()
+	return nil
+}
+
+func (t *Transformer) flushLoop() {
+	for {
+		select {
+		case <-t.ticker.C:
+			t.Lock()
+			timeNow := time.Now()
+			for source, batch := range t.batchMap {
+				if timeNow.Sub(batch.firstEntryObservedTime) < t.forceFlushTimeout {
+					continue
+				}
+				if err := t.flushSource(context.Background(), source); err != nil {
+					t.Logger().Error("there was error flushing combined stack traces", zap.Error(err))
+				}
+			}
+			// check every 1/5 forceFlushTimeout
+			t.ticker.Reset(t.forceFlushTimeout / 5)
+			t.Unlock()
+		case <-t.chClose:
+			t.ticker.Stop()
+			return
+		}
+	}
+}
+
+func (t *Transformer) Stop() error {
+	t.Lock()
+	defer t.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	t.flushAllSources(ctx)
+
+	close(t.chClose)
+	return nil
+}
+
+func (t *Transformer) ProcessBatch(ctx context.Context, entries []*entry.Entry) error {
+	return t.ProcessBatchWith(ctx, entries, t.Process)
+}
+
+func (t *Transformer) Process(ctx context.Context, e *entry.Entry) error {
+	// Lock the stacktrace operator because process can't run concurrently
+	t.Lock()
+	defer t.Unlock()
+
+	var source string
+	if err := e.Read(t.sourceIdentifier, &source); err != nil || source == "" {
+		source = defaultSourceIdentifier
+	}
+
+	var line string
+	if err := e.Read(t.field, &line); err != nil {
+		// The entry is not a line of a stack trace, it is written after the pending stack trace
+		return multierr.Append(t.flushSource(ctx, source), t.Write(ctx, e))
+	}
+
+	if batch, ok := t.batchMap[source]; ok {
+		states, matched, ended := t.nextStates(batch.states, line)
+		if matched {
+			batch.states = states
+			batch.lines = append(batch.lines, line)
+			batch.size += len(line) + 1
+			if ended || len(batch.lines) >= t.maxBatchSize || (t.maxLogSize > 0 && batch.size > t.maxLogSize) {
+				return t.flushSource(ctx, source)
+			}
+			return nil
+		}
+		if err := t.flushSource(ctx, source); err != nil {
+			return err
+		}
+	}
+
+	start := make([]string, len(t.languages))
+	for i := range start {
+		start[i] = stateStart
+	}
+	states, matched, _ := t.nextStates(start, line)
+	if !matched {
+		return t.Write(ctx, e)
+	}
+
+	if len(t.batchMap) >= t.maxSources {
+		t.Logger().Error("Too many sources. Flushing all batched stack traces. Consider increasing max_sources parameter")
+		t.flushAllSources(ctx)
+	}
+	t.batchMap[source] = &sourceBatch{
+		baseEntry:              e,
+		lines:                  []string{line},
+		size:                   len(line),
+		firstEntryObservedTime: e.ObservedTimestamp,
+		states:                 states,
+	}
+	return nil
+}
+
+// nextStates returns the states after the line in every language, whether the line belongs to
+// the stack trace in any language, and whether the stack trace is complete.
+func (t *Transformer) nextStates(states []string, line string) (next []string, matched, ended bool) {
+	next = make([]string, len(states))
+	for i, state := range states {
+		if state == stateNone || state == stateEnd {
+			continue
+		}
+		next[i] = t.languages[i].next(state, line)
+		if next[i] != stateNone {
+			matched = true
+		}
+		if next[i] == stateEnd {
+			ended = true
+		}
+	}
+	return next, matched, ended
+}
+
+// flushAllSources flushes all sources.
+func (t *Transformer) flushAllSources(ctx context.Context) {
+	var errs error
+	for source := range t.batchMap {
+		errs = multierr.Append(errs, t.flushSource(ctx, source))
+	}
+	if errs != nil {
+		t.Logger().Error("there was error flushing combined stack traces", zap.Error(errs))
+	}
+}
+
+// flushSource combines the lines of the stack trace of the source into a single entry,
+// then forwards it to the next operator in the pipeline
+func (t *Transformer) flushSource(ctx context.Context, source string) error {
+	batch, ok := t.batchMap[source]
+	if !ok {
+		return nil
+	}
+	delete(t.batchMap, source)
+
+	// A single line is not a stack trace, the entry is left unchanged
+	if len(batch.lines) == 1 {
+		return t.Write(ctx, batch.baseEntry)
+	}
+
+	stacktrace := strings.Join(batch.lines, "\n")
+	if err := batch.baseEntry.Set(t.field, stacktrace); err != nil {
+		return err
+	}
+
+	if t.parseException {
+		t.addExceptionAttributes(batch, stacktrace)
+	}
+
+	return t.Write(ctx, batch.baseEntry)
+}
+
+// addExceptionAttributes sets the exception attributes of the semantic conventions, using the first
+// language in which the lines are a stack trace.
+func (t *Transformer) addExceptionAttributes(batch *sourceBatch, stacktrace string) {
+	for i, state := range batch.states {
+		if state == stateNone {
+			continue
+		}
+		excType, excMessage := t.languages[i].parse(batch.lines)
+		if excType != "" {
+			batch.baseEntry.AddAttribute(attrExceptionType, excType)
+		}
+		if excMessage != "" {
+			batch.baseEntry.AddAttribute(attrExceptionMessage, excMessage)
+		}
+		batch.baseEntry.AddAttribute(attrExceptionStacktrace, stacktrace)
+		return
+	}
+}
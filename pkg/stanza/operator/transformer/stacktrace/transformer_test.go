@@ -0,0 +1,265 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stacktrace
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+var (
+	javaTrace = []string{
+		`Exception in thread "main" java.lang.IllegalStateException: something failed`,
+		`	at com.example.App.run(App.java:42)`,
+		`	at com.example.App.main(App.java:10)`,
+		`Caused by: java.io.IOException: disk full`,
+		`	at com.example.Store.write(Store.java:7)`,
+		`	... 2 more`,
+	}
+	pythonTrace = []string{
+		`Traceback (most recent call last):`,
+		`  File "app.py", line 10, in <module>`,
+		`    main()`,
+		`  File "app.py", line 6, in main`,
+		`    raise ValueError("invalid value")`,
+		`ValueError: invalid value`,
+	}
+	goTrace = []string{
+		`panic: runtime error: index out of range [3] with length 3`,
+		``,
+		`goroutine 1 [running]:`,
+		`main.lookup(...)`,
+		`	/app/main.go:12`,
+		`main.main()`,
+		`	/app/main.go:8 +0x1d`,
+	}
+	dotNetTrace = []string{
+		`Unhandled exception. System.InvalidOperationException: Sequence contains no elements`,
+		` ---> System.ArgumentException: Value does not fall within the expected range.`,
+		`   at App.Lookup() in /app/Program.cs:line 20`,
+		`   --- End of inner exception stack trace ---`,
+		`   at App.Main(String[] args) in /app/Program.cs:line 8`,
+	}
+)
+
+func TestTransformer(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   func(*Config)
+		input    []string
+		expected []*entry.Entry
+	}{
+		{
+			name:  "plain lines",
+			input: []string{"starting", "listening on :8080"},
+			expected: []*entry.Entry{
+				newEntry("starting"),
+				newEntry("listening on :8080"),
+			},
+		},
+		{
+			name:  "java",
+			input: append([]string{"before"}, append(javaTrace, "after")...),
+			expected: []*entry.Entry{
+				newEntry("before"),
+				newEntry(strings.Join(javaTrace, "\n")),
+				newEntry("after"),
+			},
+		},
+		{
+			name:  "python",
+			input: append(append([]string{}, pythonTrace...), "after"),
+			expected: []*entry.Entry{
+				newEntry(strings.Join(pythonTrace, "\n")),
+				newEntry("after"),
+			},
+		},
+		{
+			name:  "go",
+			input: append(append([]string{}, goTrace...), "exit status 2"),
+			expected: []*entry.Entry{
+				newEntry(strings.Join(goTrace, "\n")),
+				newEntry("exit status 2"),
+			},
+		},
+		{
+			name:  "dotnet",
+			input: append(append([]string{}, dotNetTrace...), "after"),
+			expected: []*entry.Entry{
+				newEntry(strings.Join(dotNetTrace, "\n")),
+				newEntry("after"),
+			},
+		},
+		{
+			name:  "exception without stack trace",
+			input: []string{"Error: connection refused", "retrying"},
+			expected: []*entry.Entry{
+				newEntry("Error: connection refused"),
+				newEntry("retrying"),
+			},
+		},
+		{
+			name:   "disabled language",
+			config: func(cfg *Config) { cfg.Languages = []string{languageJava} },
+			input:  pythonTrace,
+			expected: func() []*entry.Entry {
+				entries := make([]*entry.Entry, 0, len(pythonTrace))
+				for _, line := range pythonTrace {
+					entries = append(entries, newEntry(line))
+				}
+				return entries
+			}(),
+		},
+		{
+			name:   "max batch size",
+			config: func(cfg *Config) { cfg.MaxBatchSize = 3 },
+			input:  javaTrace,
+			expected: []*entry.Entry{
+				newEntry(strings.Join(javaTrace[:3], "\n")),
+				// The cause starts a new stack trace
+				newEntry(strings.Join(javaTrace[3:], "\n")),
+			},
+		},
+		{
+			name:   "java exception",
+			config: func(cfg *Config) { cfg.ParseException = true },
+			input:  javaTrace,
+			expected: []*entry.Entry{
+				newExceptionEntry(javaTrace, "java.lang.IllegalStateException", "something failed"),
+			},
+		},
+		{
+			name:   "python exception",
+			config: func(cfg *Config) { cfg.ParseException = true },
+			input:  pythonTrace,
+			expected: []*entry.Entry{
+				newExceptionEntry(pythonTrace, "ValueError", "invalid value"),
+			},
+		},
+		{
+			name:   "go panic",
+			config: func(cfg *Config) { cfg.ParseException = true },
+			input:  goTrace,
+			expected: []*entry.Entry{
+				newExceptionEntry(goTrace, "", "runtime error: index out of range [3] with length 3"),
+			},
+		},
+		{
+			name:   "dotnet exception",
+			config: func(cfg *Config) { cfg.ParseException = true },
+			input:  dotNetTrace,
+			expected: []*entry.Entry{
+				newExceptionEntry(dotNetTrace, "System.InvalidOperationException", "Sequence contains no elements"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			if tc.config != nil {
+				tc.config(cfg)
+			}
+			op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			for _, line := range tc.input {
+				require.NoError(t, op.Process(t.Context(), newEntry(line)))
+			}
+			// flush the pending stack trace
+			require.NoError(t, op.Stop())
+
+			for _, expected := range tc.expected {
+				fake.ExpectEntry(t, expected)
+			}
+			fake.ExpectNoEntry(t, 10*time.Millisecond)
+		})
+	}
+}
+
+func TestTransformerSources(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+	// The lines of the stack traces of two files are interleaved
+	for i := range javaTrace {
+		for _, file := range []string{"file1", "file2"} {
+			e := newEntry(javaTrace[i])
+			e.AddAttribute(attrs.LogFilePath, file)
+			require.NoError(t, op.Process(t.Context(), e))
+		}
+	}
+	require.NoError(t, op.Stop())
+
+	for range 2 {
+		select {
+		case e := <-fake.Received:
+			require.Equal(t, strings.Join(javaTrace, "\n"), e.Body)
+		case <-time.After(time.Second):
+			require.FailNow(t, "Timed out waiting for entry")
+		}
+	}
+	fake.ExpectNoEntry(t, 10*time.Millisecond)
+}
+
+func TestTransformerTimeout(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	cfg.ForceFlushTimeout = 100 * time.Millisecond
+	op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	require.NoError(t, op.Start(nil))
+
+	for _, line := range javaTrace {
+		require.NoError(t, op.Process(t.Context(), newEntry(line)))
+	}
+
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, strings.Join(javaTrace, "\n"), e.Body)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "The stack trace should be flushed by now")
+	}
+	require.NoError(t, op.Stop())
+}
+
+func newEntry(body string) *entry.Entry {
+	e := entry.New()
+	e.ObservedTimestamp = time.Date(2020, time.April, 11, 21, 34, 1, 0, time.UTC)
+	e.Timestamp = e.ObservedTimestamp
+	e.Body = body
+	return e
+}
+
+func newExceptionEntry(lines []string, excType, excMessage string) *entry.Entry {
+	stacktrace := strings.Join(lines, "\n")
+	e := newEntry(stacktrace)
+	if excType != "" {
+		e.AddAttribute(attrExceptionType, excType)
+	}
+	e.AddAttribute(attrExceptionMessage, excMessage)
+	e.AddAttribute(attrExceptionStacktrace, stacktrace)
+	return e
+}
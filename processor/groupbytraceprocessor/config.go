@@ -4,7 +4,16 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"errors"
 	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	errStorageRequired             = errors.New("option 'store_on_disk' requires a 'storage' extension to be set")
+	errReleasedTracesCacheRequired = errors.New("option 'discard_orphans' requires 'released_traces_cache_size' to be greater than zero")
+	errNegativeReleasedTracesCache = errors.New("option 'released_traces_cache_size' can't be negative")
 )
 
 // Config is the configuration for the processor.
@@ -21,15 +30,40 @@ type Config struct {
 	// Default: 1s.
 	WaitDuration time.Duration `mapstructure:"wait_duration"`
 
-	// DiscardOrphans instructs the processor to discard traces without the root span.
-	// This typically indicates that the trace is incomplete.
+	// DiscardOrphans instructs the processor to discard the spans arriving for traces that have already been
+	// released or evicted, instead of forwarding them immediately with the "groupbytrace.orphan" attribute.
+	// Requires ReleasedTracesCacheSize to be set.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	DiscardOrphans bool `mapstructure:"discard_orphans"`
 
+	// ReleasedTracesCacheSize is the number of released and evicted trace IDs to remember, so that spans
+	// arriving for those traces are handled as orphans instead of being grouped again into a new trace.
+	// Default: 0, meaning that orphans aren't detected.
+	ReleasedTracesCacheSize int `mapstructure:"released_traces_cache_size"`
+
 	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to disk.
 	// Useful when the duration to wait for traces to complete is high.
+	// Requires StorageID to be set.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// StorageID is the ID of the storage extension holding the trace spans when StoreOnDisk is enabled,
+	// such as file_storage or db_storage.
+	StorageID *component.ID `mapstructure:"storage"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.StoreOnDisk && cfg.StorageID == nil {
+		return errStorageRequired
+	}
+	if cfg.ReleasedTracesCacheSize < 0 {
+		return errNegativeReleasedTracesCache
+	}
+	if cfg.DiscardOrphans && cfg.ReleasedTracesCacheSize == 0 {
+		return errReleasedTracesCacheRequired
+	}
+	return nil
 }
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	storageID := component.MustNewID("file_storage")
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+	}{
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				NumTraces:    1000,
+				NumWorkers:   defaultNumWorkers,
+				WaitDuration: 10 * time.Second,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "disk"),
+			expected: &Config{
+				NumTraces:    100_000,
+				NumWorkers:   defaultNumWorkers,
+				WaitDuration: 30 * time.Second,
+				StoreOnDisk:  true,
+				StorageID:    &storageID,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "orphans"),
+			expected: &Config{
+				NumTraces:               1000,
+				NumWorkers:              defaultNumWorkers,
+				WaitDuration:            10 * time.Second,
+				DiscardOrphans:          true,
+				ReleasedTracesCacheSize: 10_000,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := createDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			assert.NoError(t, cfg.(*Config).Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr error
+	}{
+		{
+			name: "store on disk without storage",
+			modify: func(cfg *Config) {
+				cfg.StoreOnDisk = true
+			},
+			expectedErr: errStorageRequired,
+		},
+		{
+			name: "discard orphans without released traces cache",
+			modify: func(cfg *Config) {
+				cfg.DiscardOrphans = true
+			},
+			expectedErr: errReleasedTracesCacheRequired,
+		},
+		{
+			name: "negative released traces cache",
+			modify: func(cfg *Config) {
+				cfg.ReleasedTracesCacheSize = -1
+			},
+			expectedErr: errNegativeReleasedTracesCache,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+
+			assert.ErrorIs(t, cfg.Validate(), tt.expectedErr)
+		})
+	}
+}
@@ -309,6 +309,10 @@ type eventMachineWorker struct {
 	// the ring buffer holds the IDs for all the in-flight traces
 	buffer *ringBuffer
 
+	// the ring buffer holding the IDs of the recently released and evicted traces,
+	// nil when orphans aren't being detected
+	released *ringBuffer
+
 	events chan event
 }
 
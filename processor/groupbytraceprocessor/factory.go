@@ -5,7 +5,6 @@ package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -16,16 +15,12 @@ import (
 )
 
 const (
-	defaultWaitDuration   = time.Second
-	defaultNumTraces      = 1_000_000
-	defaultNumWorkers     = 1
-	defaultDiscardOrphans = false
-	defaultStoreOnDisk    = false
-)
-
-var (
-	errDiskStorageNotSupported    = errors.New("option 'disk storage' not supported in this release")
-	errDiscardOrphansNotSupported = errors.New("option 'discard orphans' not supported in this release")
+	defaultWaitDuration            = time.Second
+	defaultNumTraces               = 1_000_000
+	defaultNumWorkers              = 1
+	defaultDiscardOrphans          = false
+	defaultReleasedTracesCacheSize = 0
+	defaultStoreOnDisk             = false
 )
 
 // NewFactory returns a new factory for the Filter processor.
@@ -43,9 +38,10 @@ func createDefaultConfig() component.Config {
 		NumWorkers:   defaultNumWorkers,
 		WaitDuration: defaultWaitDuration,
 
-		// not supported for now
-		DiscardOrphans: defaultDiscardOrphans,
-		StoreOnDisk:    defaultStoreOnDisk,
+		DiscardOrphans:          defaultDiscardOrphans,
+		ReleasedTracesCacheSize: defaultReleasedTracesCacheSize,
+
+		StoreOnDisk: defaultStoreOnDisk,
 	}
 }
 
@@ -58,17 +54,18 @@ func createTracesProcessor(
 ) (processor.Traces, error) {
 	oCfg := cfg.(*Config)
 
-	var st storage
-	if oCfg.StoreOnDisk {
-		return nil, errDiskStorageNotSupported
-	}
-	if oCfg.DiscardOrphans {
-		return nil, errDiscardOrphansNotSupported
+	if err := oCfg.Validate(); err != nil {
+		return nil, err
 	}
 
 	processor := newGroupByTraceProcessor(params, nextConsumer, *oCfg)
-	// the only supported storage for now
-	st = newMemoryStorage(processor.telemetryBuilder)
+
+	var st storage
+	if oCfg.StoreOnDisk {
+		st = newDiskStorage(*oCfg.StorageID, params.ID, processor.telemetryBuilder)
+	} else {
+		st = newMemoryStorage(processor.telemetryBuilder)
+	}
 	processor.st = st
 	return processor, nil
 }
@@ -7,9 +7,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/processor/processortest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
 )
 
@@ -22,6 +24,7 @@ func TestDefaultConfiguration(t *testing.T) {
 	assert.Equal(t, defaultNumWorkers, c.NumWorkers)
 	assert.Equal(t, defaultWaitDuration, c.WaitDuration)
 	assert.Equal(t, defaultDiscardOrphans, c.DiscardOrphans)
+	assert.Equal(t, defaultReleasedTracesCacheSize, c.ReleasedTracesCacheSize)
 	assert.Equal(t, defaultStoreOnDisk, c.StoreOnDisk)
 }
 
@@ -36,7 +39,22 @@ func TestCreateTestProcessor(t *testing.T) {
 	assert.NotNil(t, p)
 }
 
-func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
+func TestCreateTestProcessorWithDiskStorage(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	storageID := storagetest.NewStorageID("test")
+	c.StoreOnDisk = true
+	c.StorageID = &storageID
+
+	// test
+	p, err := createTracesProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), c, consumertest.NewNop())
+
+	// verify
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.IsType(t, &diskStorage{}, p.(*groupByTraceProcessor).st)
+}
+
+func TestCreateTestProcessorWithInvalidOptions(t *testing.T) {
 	// prepare
 	f := NewFactory()
 
@@ -49,13 +67,13 @@ func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
 			&Config{
 				DiscardOrphans: true,
 			},
-			errDiscardOrphansNotSupported,
+			errReleasedTracesCacheRequired,
 		},
 		{
 			&Config{
 				StoreOnDisk: true,
 			},
-			errDiskStorageNotSupported,
+			errStorageRequired,
 		},
 	} {
 		p, err := f.CreateTraces(t.Context(), processortest.NewNopSettings(metadata.Type), tt.config, consumertest.NewNop())
@@ -31,6 +31,7 @@ type TelemetryBuilder struct {
 	ProcessorGroupbytraceIncompleteReleases metric.Int64Counter
 	ProcessorGroupbytraceNumEventsInQueue   metric.Int64Gauge
 	ProcessorGroupbytraceNumTracesInMemory  metric.Int64Gauge
+	ProcessorGroupbytraceSpansOrphaned      metric.Int64Counter
 	ProcessorGroupbytraceSpansReleased      metric.Int64Counter
 	ProcessorGroupbytraceTracesEvicted      metric.Int64Counter
 	ProcessorGroupbytraceTracesReleased     metric.Int64Counter
@@ -96,6 +97,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("1"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorGroupbytraceSpansOrphaned, err = builder.meter.Int64Counter(
+		"otelcol_processor_groupbytrace_spans_orphaned",
+		metric.WithDescription("Spans received for traces that had already been released or evicted [Development]"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessorGroupbytraceSpansReleased, err = builder.meter.Int64Counter(
 		"otelcol_processor_groupbytrace_spans_released",
 		metric.WithDescription("Spans released to the next consumer [Development]"),
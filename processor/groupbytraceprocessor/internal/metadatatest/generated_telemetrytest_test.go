@@ -25,6 +25,7 @@ func TestSetupTelemetry(t *testing.T) {
 	tb.ProcessorGroupbytraceIncompleteReleases.Add(context.Background(), 1)
 	tb.ProcessorGroupbytraceNumEventsInQueue.Record(context.Background(), 1)
 	tb.ProcessorGroupbytraceNumTracesInMemory.Record(context.Background(), 1)
+	tb.ProcessorGroupbytraceSpansOrphaned.Add(context.Background(), 1)
 	tb.ProcessorGroupbytraceSpansReleased.Add(context.Background(), 1)
 	tb.ProcessorGroupbytraceTracesEvicted.Add(context.Background(), 1)
 	tb.ProcessorGroupbytraceTracesReleased.Add(context.Background(), 1)
@@ -43,6 +44,9 @@ func TestSetupTelemetry(t *testing.T) {
 	AssertEqualProcessorGroupbytraceNumTracesInMemory(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualProcessorGroupbytraceSpansOrphaned(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualProcessorGroupbytraceSpansReleased(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
@@ -46,7 +46,12 @@ type groupByTraceProcessor struct {
 
 var _ processor.Traces = (*groupByTraceProcessor)(nil)
 
-const bufferSize = 10_000
+const (
+	bufferSize = 10_000
+
+	// orphanAttribute is set on the spans forwarded after their trace has been released
+	orphanAttribute = "groupbytrace.orphan"
+)
 
 // newGroupByTraceProcessor returns a new processor.
 func newGroupByTraceProcessor(set processor.Settings, nextConsumer consumer.Traces, config Config) *groupByTraceProcessor {
@@ -66,6 +71,13 @@ func newGroupByTraceProcessor(set processor.Settings, nextConsumer consumer.Trac
 		eventMachine:     eventMachine,
 	}
 
+	if config.ReleasedTracesCacheSize > 0 {
+		releasedPerWorker := max(config.ReleasedTracesCacheSize/config.NumWorkers, 1)
+		for _, worker := range eventMachine.workers {
+			worker.released = newRingBuffer(releasedPerWorker)
+		}
+	}
+
 	// register the callbacks
 	eventMachine.onTraceReceived = sp.onTraceReceived
 	eventMachine.onTraceExpired = sp.onTraceExpired
@@ -88,19 +100,23 @@ func (*groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
 	// start these metrics, as it might take a while for them to receive their first event
 	sp.telemetryBuilder.ProcessorGroupbytraceTracesEvicted.Add(context.Background(), 0)
 	sp.telemetryBuilder.ProcessorGroupbytraceIncompleteReleases.Add(context.Background(), 0)
 	sp.telemetryBuilder.ProcessorGroupbytraceConfNumTraces.Record(context.Background(), (int64(sp.config.NumTraces)))
+	// the storage must be ready before the event machine starts dispatching events to it
+	if err := sp.st.start(ctx, host); err != nil {
+		return err
+	}
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (sp *groupByTraceProcessor) Shutdown(_ context.Context) error {
+func (sp *groupByTraceProcessor) Shutdown(ctx context.Context) error {
 	sp.eventMachine.shutdown()
-	return sp.st.shutdown()
+	return sp.st.shutdown(ctx)
 }
 
 func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eventMachineWorker) error {
@@ -117,12 +133,20 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 		return nil
 	}
 
+	if worker.released != nil && worker.released.contains(traceID) {
+		// the trace has been released or evicted already, so these spans would never make it there
+		sp.onOrphanReceived(trace)
+		return nil
+	}
+
 	// at this point, we determined that we haven't seen the trace yet, so, record the
 	// traceID in the map and the spans to the storage
 
 	// place the trace ID in the buffer, and check if an item had to be evicted
 	evicted := worker.buffer.put(traceID)
 	if !evicted.IsEmpty() {
+		sp.rememberReleased(evicted, worker)
+
 		// delete from the storage
 		worker.fire(event{
 			typ:     traceRemoved,
@@ -164,6 +188,7 @@ func (sp *groupByTraceProcessor) onTraceExpired(traceID pcommon.TraceID, worker
 
 	// delete from the map and erase its memory entry
 	worker.buffer.delete(traceID)
+	sp.rememberReleased(traceID, worker)
 
 	// this might block, but we don't need to wait
 	sp.logger.Debug("marking the trace as released", zap.Stringer("traceID", traceID))
@@ -219,6 +244,45 @@ func (sp *groupByTraceProcessor) onTraceReleased(rss []ptrace.ResourceSpans) err
 	return nil
 }
 
+// rememberReleased keeps track of the given trace ID, so that spans arriving for this trace afterwards are treated as orphans
+func (*groupByTraceProcessor) rememberReleased(traceID pcommon.TraceID, worker *eventMachineWorker) {
+	if worker.released == nil || worker.released.contains(traceID) {
+		return
+	}
+	worker.released.put(traceID)
+}
+
+// onOrphanReceived either discards the spans for a trace that has been released already, or forwards
+// them immediately to the next consumer, marking each span with the orphan attribute
+func (sp *groupByTraceProcessor) onOrphanReceived(trace tracesWithID) {
+	spanCount := trace.td.SpanCount()
+	sp.telemetryBuilder.ProcessorGroupbytraceSpansOrphaned.Add(context.Background(), int64(spanCount))
+
+	if sp.config.DiscardOrphans {
+		sp.logger.Debug("discarding orphaned spans", zap.Stringer("traceID", trace.id), zap.Int("spans", spanCount))
+		return
+	}
+
+	sp.logger.Debug("forwarding orphaned spans", zap.Stringer("traceID", trace.id), zap.Int("spans", spanCount))
+	rss := trace.td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				spans.At(k).Attributes().PutBool(orphanAttribute, true)
+			}
+		}
+	}
+
+	// Do async consuming not to block event worker
+	go func() {
+		if err := sp.nextConsumer.ConsumeTraces(context.Background(), trace.td); err != nil {
+			sp.logger.Error("consume failed", zap.Error(err))
+		}
+	}()
+}
+
 func (sp *groupByTraceProcessor) onTraceRemoved(traceID pcommon.TraceID) error {
 	trace, err := sp.st.delete(traceID)
 	if err != nil {
@@ -7,22 +7,27 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadatatest"
 )
 
 func TestTraceIsDispatchedAfterDuration(t *testing.T) {
@@ -137,6 +142,106 @@ func TestInternalCacheLimit(t *testing.T) {
 	assert.NotContains(t, receivedTraceIDs, traceIDs[0])
 }
 
+func TestOrphansAreForwarded(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		discardOrphans bool
+	}{
+		{
+			name: "forward",
+		},
+		{
+			name:           "discard",
+			discardOrphans: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			config := Config{
+				WaitDuration:            time.Millisecond,
+				NumTraces:               10,
+				NumWorkers:              1,
+				DiscardOrphans:          tt.discardOrphans,
+				ReleasedTracesCacheSize: 10,
+			}
+
+			receivedCh := make(chan ptrace.Traces, 2)
+			next := &mockProcessor{
+				onTraces: func(_ context.Context, received ptrace.Traces) error {
+					receivedCh <- received
+					return nil
+				},
+			}
+
+			tel := componenttest.NewTelemetry()
+			t.Cleanup(func() {
+				require.NoError(t, tel.Shutdown(context.Background()))
+			})
+			set := processortest.NewNopSettings(metadata.Type)
+			set.TelemetrySettings = tel.NewTelemetrySettings()
+
+			p := newGroupByTraceProcessor(set, next, config)
+			p.st = newMemoryStorage(p.telemetryBuilder)
+			ctx := t.Context()
+			require.NoError(t, p.Start(ctx, nil))
+			defer func() {
+				assert.NoError(t, p.Shutdown(ctx))
+			}()
+
+			// test
+			require.NoError(t, p.ConsumeTraces(ctx, simpleTraces()))
+			released := <-receivedCh
+			_, found := released.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Get(orphanAttribute)
+			assert.False(t, found)
+
+			require.NoError(t, p.ConsumeTraces(ctx, simpleTraces()))
+
+			// verify
+			if tt.discardOrphans {
+				select {
+				case <-receivedCh:
+					assert.Fail(t, "orphaned spans should have been discarded")
+				case <-time.After(50 * time.Millisecond):
+				}
+			} else {
+				orphan := <-receivedCh
+				value, found := orphan.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Get(orphanAttribute)
+				require.True(t, found)
+				assert.True(t, value.Bool())
+			}
+
+			metadatatest.AssertEqualProcessorGroupbytraceSpansOrphaned(t, tel,
+				[]metricdata.DataPoint[int64]{{Value: 1}},
+				metricdatatest.IgnoreTimestamp())
+		})
+	}
+}
+
+func TestEvictedTracesAreRememberedAsReleased(t *testing.T) {
+	// prepare
+	config := Config{
+		WaitDuration:            time.Hour,
+		NumTraces:               1,
+		NumWorkers:              1,
+		ReleasedTracesCacheSize: 10,
+	}
+	p := newGroupByTraceProcessor(processortest.NewNopSettings(metadata.Type), consumertest.NewNop(), config)
+	p.st = newMemoryStorage(p.telemetryBuilder)
+	worker := p.eventMachine.workers[0]
+
+	first := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	second := pcommon.TraceID([16]byte{2, 3, 4, 5})
+
+	// test
+	require.NoError(t, p.onTraceReceived(tracesWithID{id: first, td: simpleTracesWithID(first)}, worker))
+	require.NoError(t, p.onTraceReceived(tracesWithID{id: second, td: simpleTracesWithID(second)}, worker))
+
+	// verify
+	assert.True(t, worker.released.contains(first))
+	assert.False(t, worker.released.contains(second))
+	assert.True(t, worker.buffer.contains(second))
+}
+
 func TestProcessorCapabilities(t *testing.T) {
 	// prepare
 	config := Config{
@@ -256,6 +361,47 @@ func TestTraceErrorFromStorageWhileReleasing(t *testing.T) {
 	assert.ErrorIs(t, err, expectedError)
 }
 
+func TestEventMachineNotStartedOnStorageStartFailure(t *testing.T) {
+	// prepare
+	config := Config{
+		WaitDuration: time.Second, // we are not waiting for this whole time
+		NumTraces:    8,
+		NumWorkers:   4,
+	}
+	expectedError := errors.New("some unexpected error")
+	var received atomic.Bool
+	st := &mockStorage{
+		onStart: func() error {
+			return expectedError
+		},
+		onCreateOrAppend: func(pcommon.TraceID, ptrace.Traces) error {
+			received.Store(true)
+			return nil
+		},
+	}
+	next := &mockProcessor{}
+
+	p := newGroupByTraceProcessor(processortest.NewNopSettings(metadata.Type), next, config)
+	require.NotNil(t, p)
+	p.st = st
+	p.eventMachine.shutdownTimeout = 10 * time.Millisecond
+
+	// test
+	err := p.Start(t.Context(), componenttest.NewNopHost())
+
+	// verify
+	assert.ErrorIs(t, err, expectedError)
+
+	// the event machine doesn't dispatch the events to the storage that failed to start
+	trace := ptrace.NewTraces()
+	span := trace.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID(pcommon.TraceID([16]byte{1, 2, 3, 4}))
+	require.NoError(t, p.eventMachine.consume(trace))
+	assert.Never(t, received.Load, 100*time.Millisecond, 10*time.Millisecond)
+
+	assert.NoError(t, p.Shutdown(t.Context()))
+}
+
 func TestTraceErrorFromStorageWhileProcessingTrace(t *testing.T) {
 	// prepare
 	config := Config{
@@ -642,14 +788,14 @@ func (st *mockStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans,
 	return nil, nil
 }
 
-func (st *mockStorage) start() error {
+func (st *mockStorage) start(context.Context, component.Host) error {
 	if st.onStart != nil {
 		return st.onStart()
 	}
 	return nil
 }
 
-func (st *mockStorage) shutdown() error {
+func (st *mockStorage) shutdown(context.Context) error {
 	if st.onShutdown != nil {
 		return st.onShutdown()
 	}
@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	xstorage "go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
+)
+
+var errStorageNotStarted = errors.New("the disk storage hasn't been started")
+
+// diskStorage keeps only the trace IDs in memory, serializing the spans to a storage extension.
+// Each batch of spans received for a trace is stored under its own key, so that appending spans
+// to an existing trace doesn't require reading the trace back from the storage.
+type diskStorage struct {
+	sync.RWMutex
+	// content holds the number of batches stored for each trace
+	content                   map[pcommon.TraceID]int
+	storageID                 component.ID
+	componentID               component.ID
+	client                    xstorage.Client
+	telemetry                 *metadata.TelemetryBuilder
+	marshaler                 ptrace.ProtoMarshaler
+	unmarshaler               ptrace.ProtoUnmarshaler
+	stopped                   bool
+	stoppedLock               sync.RWMutex
+	metricsCollectionInterval time.Duration
+}
+
+var _ storage = (*diskStorage)(nil)
+
+func newDiskStorage(storageID, componentID component.ID, telemetry *metadata.TelemetryBuilder) *diskStorage {
+	return &diskStorage{
+		content:                   make(map[pcommon.TraceID]int),
+		storageID:                 storageID,
+		componentID:               componentID,
+		metricsCollectionInterval: time.Second,
+		telemetry:                 telemetry,
+	}
+}
+
+func (st *diskStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	if st.client == nil {
+		return errStorageNotStarted
+	}
+
+	data, err := st.marshaler.MarshalTraces(td)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal trace %q: %w", traceID, err)
+	}
+
+	st.Lock()
+	defer st.Unlock()
+
+	// getting zero value is fine
+	batches := st.content[traceID]
+	if err := st.client.Set(context.Background(), batchKey(traceID, batches), data); err != nil {
+		return err
+	}
+	st.content[traceID] = batches + 1
+
+	return nil
+}
+
+func (st *diskStorage) get(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	if st.client == nil {
+		return nil, errStorageNotStarted
+	}
+
+	st.RLock()
+	defer st.RUnlock()
+
+	batches, ok := st.content[traceID]
+	if !ok {
+		return nil, nil
+	}
+
+	ops := make([]*xstorage.Operation, batches)
+	for i := range ops {
+		ops[i] = xstorage.GetOperation(batchKey(traceID, i))
+	}
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return nil, err
+	}
+
+	return st.unmarshal(ops)
+}
+
+// delete will return the trace as it was in the storage, removing all of its batches
+func (st *diskStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	if st.client == nil {
+		return nil, errStorageNotStarted
+	}
+
+	st.Lock()
+	defer st.Unlock()
+
+	batches, ok := st.content[traceID]
+	if !ok {
+		return nil, nil
+	}
+	delete(st.content, traceID)
+
+	// read and remove the batches in the same round trip
+	ops := make([]*xstorage.Operation, 0, 2*batches)
+	for i := 0; i < batches; i++ {
+		ops = append(ops, xstorage.GetOperation(batchKey(traceID, i)))
+	}
+	for i := 0; i < batches; i++ {
+		ops = append(ops, xstorage.DeleteOperation(batchKey(traceID, i)))
+	}
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return nil, err
+	}
+
+	return st.unmarshal(ops[:batches])
+}
+
+func (st *diskStorage) start(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[st.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension '%s' not found", st.storageID)
+	}
+
+	storageExt, ok := ext.(xstorage.Extension)
+	if !ok {
+		return fmt.Errorf("non-storage extension '%s' found", st.storageID)
+	}
+
+	client, err := storageExt.GetClient(ctx, component.KindProcessor, st.componentID, "")
+	if err != nil {
+		return fmt.Errorf("couldn't obtain a storage client from '%s': %w", st.storageID, err)
+	}
+	st.client = client
+
+	go st.periodicMetrics()
+	return nil
+}
+
+// shutdown removes the traces that are still held by the storage, as the in-memory index
+// pointing to them is not persisted and they couldn't be released after a restart
+func (st *diskStorage) shutdown(ctx context.Context) error {
+	st.stoppedLock.Lock()
+	st.stopped = true
+	st.stoppedLock.Unlock()
+
+	if st.client == nil {
+		return nil
+	}
+
+	st.Lock()
+	var ops []*xstorage.Operation
+	for traceID, batches := range st.content {
+		for i := 0; i < batches; i++ {
+			ops = append(ops, xstorage.DeleteOperation(batchKey(traceID, i)))
+		}
+	}
+	st.content = make(map[pcommon.TraceID]int)
+	st.Unlock()
+
+	var errs []error
+	if len(ops) > 0 {
+		errs = append(errs, st.client.Batch(ctx, ops...))
+	}
+	errs = append(errs, st.client.Close(ctx))
+	return errors.Join(errs...)
+}
+
+func (st *diskStorage) unmarshal(ops []*xstorage.Operation) ([]ptrace.ResourceSpans, error) {
+	var result []ptrace.ResourceSpans
+	for _, op := range ops {
+		if op.Value == nil {
+			// the batch is gone from the storage, there's nothing we can do about it
+			continue
+		}
+
+		td, err := st.unmarshaler.UnmarshalTraces(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't unmarshal the spans stored under %q: %w", op.Key, err)
+		}
+
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			result = append(result, rss.At(i))
+		}
+	}
+	return result, nil
+}
+
+func (st *diskStorage) periodicMetrics() {
+	numTraces := st.count()
+	st.telemetry.ProcessorGroupbytraceNumTracesInMemory.Record(context.Background(), int64(numTraces))
+
+	st.stoppedLock.RLock()
+	stopped := st.stopped
+	st.stoppedLock.RUnlock()
+	if stopped {
+		return
+	}
+
+	time.AfterFunc(st.metricsCollectionInterval, func() {
+		st.periodicMetrics()
+	})
+}
+
+func (st *diskStorage) count() int {
+	st.RLock()
+	defer st.RUnlock()
+	return len(st.content)
+}
+
+// batchKey returns the key under which the given batch of spans for a trace is stored
+func batchKey(traceID pcommon.TraceID, batch int) string {
+	return fmt.Sprintf("%s/%d", traceID, batch)
+}
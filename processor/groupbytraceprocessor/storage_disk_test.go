@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
+)
+
+func newStartedDiskStorage(t *testing.T) *diskStorage {
+	set := processortest.NewNopSettings(metadata.Type)
+	tel, _ := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	storageID := storagetest.NewStorageID("test")
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("test")
+
+	st := newDiskStorage(storageID, set.ID, tel)
+	require.NoError(t, st.start(t.Context(), host))
+	t.Cleanup(func() {
+		assert.NoError(t, st.shutdown(t.Context()))
+	})
+	return st
+}
+
+func TestDiskCreateAndGetTrace(t *testing.T) {
+	st := newStartedDiskStorage(t)
+
+	traceIDs := []pcommon.TraceID{
+		pcommon.TraceID([16]byte{1, 2, 3, 4}),
+		pcommon.TraceID([16]byte{2, 3, 4, 5}),
+	}
+
+	baseTrace := ptrace.NewTraces()
+	rss := baseTrace.ResourceSpans()
+	rs := rss.AppendEmpty()
+	ils := rs.ScopeSpans().AppendEmpty()
+	span := ils.Spans().AppendEmpty()
+
+	// test
+	for _, traceID := range traceIDs {
+		span.SetTraceID(traceID)
+		assert.NoError(t, st.createOrAppend(traceID, baseTrace))
+	}
+
+	// verify
+	assert.Equal(t, 2, st.count())
+	for _, traceID := range traceIDs {
+		expected := []ptrace.ResourceSpans{baseTrace.ResourceSpans().At(0)}
+		expected[0].ScopeSpans().At(0).Spans().At(0).SetTraceID(traceID)
+
+		retrieved, err := st.get(traceID)
+		require.NoError(t, err)
+		assert.Equal(t, expected, retrieved)
+	}
+}
+
+func TestDiskDeleteTrace(t *testing.T) {
+	st := newStartedDiskStorage(t)
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+
+	trace := ptrace.NewTraces()
+	rss := trace.ResourceSpans()
+	rs := rss.AppendEmpty()
+	ils := rs.ScopeSpans().AppendEmpty()
+	span := ils.Spans().AppendEmpty()
+	span.SetTraceID(traceID)
+
+	assert.NoError(t, st.createOrAppend(traceID, trace))
+
+	// test
+	deleted, err := st.delete(traceID)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []ptrace.ResourceSpans{trace.ResourceSpans().At(0)}, deleted)
+	assert.Equal(t, 0, st.count())
+
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+
+	value, err := st.client.Get(t.Context(), batchKey(traceID, 0))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestDiskAppendSpans(t *testing.T) {
+	st := newStartedDiskStorage(t)
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+
+	trace := ptrace.NewTraces()
+	rss := trace.ResourceSpans()
+	rs := rss.AppendEmpty()
+	ils := rs.ScopeSpans().AppendEmpty()
+	span := ils.Spans().AppendEmpty()
+	span.SetTraceID(traceID)
+	span.SetSpanID([8]byte{1, 2, 3, 4})
+
+	assert.NoError(t, st.createOrAppend(traceID, trace))
+
+	secondTrace := ptrace.NewTraces()
+	secondRss := secondTrace.ResourceSpans()
+	secondRs := secondRss.AppendEmpty()
+	secondIls := secondRs.ScopeSpans().AppendEmpty()
+	secondSpan := secondIls.Spans().AppendEmpty()
+	secondSpan.SetName("second-name")
+	secondSpan.SetTraceID(traceID)
+	secondSpan.SetSpanID([8]byte{5, 6, 7, 8})
+
+	expected := []ptrace.ResourceSpans{
+		ptrace.NewResourceSpans(),
+		ptrace.NewResourceSpans(),
+	}
+	ils.CopyTo(expected[0].ScopeSpans().AppendEmpty())
+	secondIls.CopyTo(expected[1].ScopeSpans().AppendEmpty())
+
+	// test
+	err := st.createOrAppend(traceID, secondTrace)
+	require.NoError(t, err)
+
+	// override something in the second span, to make sure we are storing a copy
+	secondSpan.SetName("changed-second-name")
+
+	// verify
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	require.Len(t, retrieved, 2)
+	assert.Equal(t, "second-name", retrieved[1].ScopeSpans().At(0).Spans().At(0).Name())
+
+	secondSpan.SetName("second-name")
+	assert.Equal(t, expected, retrieved)
+	assert.Equal(t, 1, st.count())
+}
+
+func TestDiskShutdownRemovesPendingTraces(t *testing.T) {
+	set := processortest.NewNopSettings(metadata.Type)
+	tel, _ := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	storageID := storagetest.NewStorageID("test")
+	ext := storagetest.NewFileBackedStorageExtension("test", t.TempDir())
+	host := storagetest.NewStorageHost().WithExtension(storageID, ext)
+
+	st := newDiskStorage(storageID, set.ID, tel)
+	require.NoError(t, st.start(t.Context(), host))
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+
+	// test
+	require.NoError(t, st.shutdown(t.Context()))
+
+	// verify
+	client, err := ext.GetClient(t.Context(), component.KindProcessor, set.ID, "")
+	require.NoError(t, err)
+	value, err := client.Get(t.Context(), batchKey(traceID, 0))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestDiskStartWithMissingExtension(t *testing.T) {
+	set := processortest.NewNopSettings(metadata.Type)
+	tel, _ := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+
+	for _, tt := range []struct {
+		name      string
+		storageID component.ID
+		host      *storagetest.StorageHost
+	}{
+		{
+			name:      "missing",
+			storageID: storagetest.NewStorageID("test"),
+			host:      storagetest.NewStorageHost(),
+		},
+		{
+			name:      "not a storage",
+			storageID: storagetest.NewNonStorageID("test"),
+			host:      storagetest.NewStorageHost().WithNonStorageExtension("test"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newDiskStorage(tt.storageID, set.ID, tel)
+			assert.Error(t, st.start(t.Context(), tt.host))
+		})
+	}
+
+	st := newDiskStorage(storagetest.NewStorageID("test"), set.ID, tel)
+	assert.ErrorIs(t, st.createOrAppend(pcommon.TraceID([16]byte{1}), ptrace.NewTraces()), errStorageNotStarted)
+	assert.NoError(t, st.shutdown(t.Context()))
+}
@@ -8,6 +8,7 @@ package isolationforestprocessor // import "github.com/open-telemetry/openteleme
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -31,6 +32,9 @@ type Config struct {
 
 	// Adaptive window sizing configuration
 	AdaptiveWindow *AdaptiveWindowConfig `mapstructure:"adaptive_window"`
+
+	// Persistence of the trained models
+	Persistence *PersistenceConfig `mapstructure:"persistence"`
 }
 
 // AdaptiveWindowConfig configures automatic window size adjustment based on traffic patterns
@@ -47,6 +51,15 @@ type AdaptiveWindowConfig struct {
 	StabilityCheckInterval string  `mapstructure:"stability_check_interval"` // Check model accuracy interval
 }
 
+// PersistenceConfig configures the snapshots of the trained models, so that they survive restarts
+// and can be trained once and distributed to other collectors
+type PersistenceConfig struct {
+	StorageID        *component.ID `mapstructure:"storage"`           // Storage extension the models are snapshotted to and reloaded from
+	SnapshotInterval string        `mapstructure:"snapshot_interval"` // How often the models are snapshotted, they are also snapshotted on shutdown
+	ExportDirectory  string        `mapstructure:"export_directory"`  // Directory the models are also written to as files on every snapshot
+	ImportDirectory  string        `mapstructure:"import_directory"`  // Directory the models are read from on start when not found in the storage
+}
+
 type FeatureConfig struct {
 	Traces  []string `mapstructure:"traces"`
 	Metrics []string `mapstructure:"metrics"`
@@ -153,6 +166,13 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	// Validate model persistence configuration
+	if cfg.Persistence != nil {
+		if err := cfg.validatePersistence(); err != nil {
+			return fmt.Errorf("persistence validation failed: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -201,6 +221,34 @@ func (cfg *Config) validateAdaptiveWindow() error {
 	return nil
 }
 
+// validatePersistence validates the model persistence configuration
+func (cfg *Config) validatePersistence() error {
+	ps := cfg.Persistence
+
+	if ps.StorageID == nil && ps.ExportDirectory == "" && ps.ImportDirectory == "" {
+		return errors.New("at least one of storage, export_directory or import_directory must be set")
+	}
+
+	// The model names are used as file names in the export and import directories
+	for _, model := range cfg.Models {
+		if model.Name == "" || model.Name == "." || model.Name == ".." || model.Name != filepath.Base(model.Name) {
+			return fmt.Errorf("model name %q cannot be used as a file name", model.Name)
+		}
+	}
+
+	if ps.SnapshotInterval != "" {
+		interval, err := time.ParseDuration(ps.SnapshotInterval)
+		if err != nil {
+			return fmt.Errorf("snapshot_interval is not a valid duration: %w", err)
+		}
+		if interval <= 0 {
+			return errors.New("snapshot_interval must be positive")
+		}
+	}
+
+	return nil
+}
+
 // IsAdaptiveWindowEnabled returns true if adaptive window sizing is enabled
 func (cfg *Config) IsAdaptiveWindowEnabled() bool {
 	return cfg.AdaptiveWindow != nil && cfg.AdaptiveWindow.Enabled
@@ -214,6 +262,14 @@ func (cfg *Config) GetStabilityCheckInterval() (time.Duration, error) {
 	return time.ParseDuration(cfg.AdaptiveWindow.StabilityCheckInterval)
 }
 
+// GetSnapshotInterval returns the model snapshot interval duration
+func (cfg *Config) GetSnapshotInterval() (time.Duration, error) {
+	if cfg.Persistence == nil || cfg.Persistence.SnapshotInterval == "" {
+		return 5 * time.Minute, nil // Default
+	}
+	return time.ParseDuration(cfg.Persistence.SnapshotInterval)
+}
+
 func (cfg *Config) GetTrainingWindowDuration() (time.Duration, error) {
 	return time.ParseDuration(cfg.TrainingWindow)
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 )
 
 func TestCreateDefaultConfig(t *testing.T) {
@@ -494,6 +495,109 @@ func TestAdaptiveWindowHelperMethods(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid duration", "Should contain duration error message")
 }
 
+func TestPersistenceValidation(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+	tests := []struct {
+		name          string
+		persistence   *PersistenceConfig
+		models        []ModelConfig
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name:        "nil persistence config",
+			persistence: nil,
+			expectError: false,
+		},
+		{
+			name:        "storage only",
+			persistence: &PersistenceConfig{StorageID: &storageID},
+			expectError: false,
+		},
+		{
+			name:        "export and import directories",
+			persistence: &PersistenceConfig{ExportDirectory: "/models", ImportDirectory: "/models", SnapshotInterval: "1m"},
+			expectError: false,
+		},
+		{
+			name:          "nothing to persist to",
+			persistence:   &PersistenceConfig{SnapshotInterval: "1m"},
+			expectError:   true,
+			errorContains: "at least one of storage, export_directory or import_directory must be set",
+		},
+		{
+			name:          "invalid snapshot interval",
+			persistence:   &PersistenceConfig{StorageID: &storageID, SnapshotInterval: "soon"},
+			expectError:   true,
+			errorContains: "snapshot_interval is not a valid duration",
+		},
+		{
+			name:          "negative snapshot interval",
+			persistence:   &PersistenceConfig{StorageID: &storageID, SnapshotInterval: "-1m"},
+			expectError:   true,
+			errorContains: "snapshot_interval must be positive",
+		},
+		{
+			name:        "valid model names",
+			persistence: &PersistenceConfig{ExportDirectory: "/models"},
+			models:      []ModelConfig{{Name: "web"}, {Name: "db.primary"}},
+			expectError: false,
+		},
+		{
+			name:          "model name escaping the directory",
+			persistence:   &PersistenceConfig{ExportDirectory: "/models"},
+			models:        []ModelConfig{{Name: "web"}, {Name: "../x"}},
+			expectError:   true,
+			errorContains: `model name "../x" cannot be used as a file name`,
+		},
+		{
+			name:          "parent directory model name",
+			persistence:   &PersistenceConfig{ImportDirectory: "/models"},
+			models:        []ModelConfig{{Name: ".."}},
+			expectError:   true,
+			errorContains: `model name ".." cannot be used as a file name`,
+		},
+		{
+			name:          "empty model name",
+			persistence:   &PersistenceConfig{StorageID: &storageID},
+			models:        []ModelConfig{{Name: ""}},
+			expectError:   true,
+			errorContains: `model name "" cannot be used as a file name`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := createDefaultConfig()
+			cfg, ok := raw.(*Config)
+			require.True(t, ok, "createDefaultConfig should return *Config")
+
+			cfg.Persistence = tt.persistence
+			cfg.Models = tt.models
+			err := cfg.Validate()
+			if tt.expectError {
+				require.Error(t, err, "Expected validation error for %s", tt.name)
+				assert.Contains(t, err.Error(), tt.errorContains)
+			} else {
+				require.NoError(t, err, "Expected no validation error for %s", tt.name)
+			}
+		})
+	}
+
+	// The snapshot interval defaults to 5 minutes
+	raw := createDefaultConfig()
+	cfg, ok := raw.(*Config)
+	require.True(t, ok, "createDefaultConfig should return *Config")
+	interval, err := cfg.GetSnapshotInterval()
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, interval)
+
+	cfg.Persistence = &PersistenceConfig{StorageID: &storageID, SnapshotInterval: "30s"}
+	interval, err = cfg.GetSnapshotInterval()
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, interval)
+}
+
 func TestMultiModelConfiguration(t *testing.T) {
 	raw := createDefaultConfig()
 	cfg, ok := raw.(*Config)
@@ -0,0 +1,430 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// persistence.go - Snapshots of the trained models to a storage extension and to files
+package isolationforestprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/isolationforestprocessor"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.uber.org/zap"
+)
+
+// snapshotVersion is increased whenever the snapshot format changes incompatibly.
+const snapshotVersion = 1
+
+// forestSnapshot is the serialized state of an online isolation forest, which is enough
+// to resume scoring and learning where the forest left off.
+type forestSnapshot struct {
+	Version           int             `json:"version"`
+	Features          []string        `json:"features"` // Features of the samples, in the order of the sample values
+	NumTrees          int             `json:"num_trees"`
+	Trees             []*treeSnapshot `json:"trees"`
+	Window            [][]float64     `json:"window"` // Samples of the sliding window, oldest first
+	ScoreHistory      []float64       `json:"score_history"`
+	Threshold         float64         `json:"threshold"`
+	TotalSamples      uint64          `json:"total_samples"`
+	AnomalyCount      uint64          `json:"anomaly_count"`
+	CurrentWindowSize int             `json:"current_window_size,omitempty"`
+}
+
+type treeSnapshot struct {
+	Root        *nodeSnapshot `json:"root,omitempty"`
+	SampleCount int           `json:"sample_count"`
+	UpdateCount int           `json:"update_count"`
+}
+
+type nodeSnapshot struct {
+	FeatureIndex   int           `json:"feature_index"`
+	SplitValue     float64       `json:"split_value"`
+	SampleCount    int           `json:"sample_count"`
+	Depth          int           `json:"depth"`
+	Left           *nodeSnapshot `json:"left,omitempty"`
+	Right          *nodeSnapshot `json:"right,omitempty"`
+	IsLeaf         bool          `json:"is_leaf"`
+	IsolationScore float64       `json:"isolation_score"`
+}
+
+// snapshot captures the current state of the forest.
+func (oif *onlineIsolationForest) snapshot() *forestSnapshot {
+	snap := &forestSnapshot{
+		Version:  snapshotVersion,
+		NumTrees: oif.numTrees,
+	}
+
+	oif.treesMutex.RLock()
+	snap.Trees = make([]*treeSnapshot, len(oif.trees))
+	for i, tree := range oif.trees {
+		snap.Trees[i] = &treeSnapshot{
+			Root:        snapshotNode(tree.root),
+			SampleCount: tree.sampleCount,
+			UpdateCount: tree.updateCount,
+		}
+	}
+	oif.treesMutex.RUnlock()
+
+	// The samples are never modified once added to the window, so they can be shared
+	oif.windowMutex.RLock()
+	if oif.windowFull {
+		snap.Window = appendSamples(snap.Window, oif.dataWindow[oif.windowIndex:])
+	}
+	snap.Window = appendSamples(snap.Window, oif.dataWindow[:oif.windowIndex])
+	oif.windowMutex.RUnlock()
+
+	oif.thresholdMutex.RLock()
+	snap.ScoreHistory = append([]float64(nil), oif.scoreHistory...)
+	snap.Threshold = oif.threshold
+	oif.thresholdMutex.RUnlock()
+
+	oif.statsMutex.RLock()
+	snap.TotalSamples = oif.totalSamples
+	snap.AnomalyCount = oif.anomalyCount
+	oif.statsMutex.RUnlock()
+
+	if oif.adaptiveConfig != nil && oif.adaptiveConfig.Enabled {
+		oif.adaptiveMutex.RLock()
+		snap.CurrentWindowSize = oif.currentWindowSize
+		oif.adaptiveMutex.RUnlock()
+	}
+
+	return snap
+}
+
+// restore replaces the state of the forest with a snapshot. The snapshot is rejected if it was taken
+// from a forest with a different number of trees.
+func (oif *onlineIsolationForest) restore(snap *forestSnapshot) error {
+	if snap.Version != snapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d", snap.Version)
+	}
+	if snap.NumTrees != oif.numTrees || len(snap.Trees) != oif.numTrees {
+		return fmt.Errorf("snapshot has %d trees, expected %d", len(snap.Trees), oif.numTrees)
+	}
+
+	trees := make([]*onlineIsolationTree, len(snap.Trees))
+	for i, tree := range snap.Trees {
+		trees[i] = &onlineIsolationTree{
+			root:           restoreNode(tree.Root),
+			maxDepth:       oif.maxDepth,
+			sampleCount:    tree.SampleCount,
+			updateCount:    tree.UpdateCount,
+			lastUpdateTime: time.Now(),
+		}
+	}
+
+	// Restore the adaptive window size first, as it determines the size of the window
+	if oif.adaptiveConfig != nil && oif.adaptiveConfig.Enabled && snap.CurrentWindowSize > 0 {
+		oif.adaptiveMutex.Lock()
+		oif.currentWindowSize = min(max(snap.CurrentWindowSize, oif.adaptiveConfig.MinWindowSize), oif.adaptiveConfig.MaxWindowSize)
+		oif.adaptiveMutex.Unlock()
+	}
+
+	oif.treesMutex.Lock()
+	oif.trees = trees
+	oif.treesMutex.Unlock()
+
+	oif.windowMutex.Lock()
+	size := oif.getCurrentWindowSize()
+	samples := snap.Window
+	if len(samples) > size {
+		samples = samples[len(samples)-size:]
+	}
+	oif.dataWindow = make([][]float64, size)
+	copy(oif.dataWindow, samples)
+	oif.windowIndex = len(samples) % size
+	oif.windowFull = len(samples) == size
+	oif.windowMutex.Unlock()
+
+	oif.thresholdMutex.Lock()
+	scores := snap.ScoreHistory
+	if len(scores) > size {
+		scores = scores[len(scores)-size:]
+	}
+	oif.scoreHistory = append(make([]float64, 0, size), scores...)
+	oif.threshold = snap.Threshold
+	oif.thresholdMutex.Unlock()
+
+	oif.statsMutex.Lock()
+	oif.totalSamples = snap.TotalSamples
+	oif.anomalyCount = snap.AnomalyCount
+	oif.statsMutex.Unlock()
+
+	return nil
+}
+
+func appendSamples(dst, samples [][]float64) [][]float64 {
+	for _, sample := range samples {
+		if sample != nil {
+			dst = append(dst, sample)
+		}
+	}
+	return dst
+}
+
+func snapshotNode(node *onlineTreeNode) *nodeSnapshot {
+	if node == nil {
+		return nil
+	}
+	return &nodeSnapshot{
+		FeatureIndex:   node.featureIndex,
+		SplitValue:     node.splitValue,
+		SampleCount:    node.sampleCount,
+		Depth:          node.depth,
+		Left:           snapshotNode(node.left),
+		Right:          snapshotNode(node.right),
+		IsLeaf:         node.isLeaf,
+		IsolationScore: node.isolationScore,
+	}
+}
+
+func restoreNode(node *nodeSnapshot) *onlineTreeNode {
+	if node == nil {
+		return nil
+	}
+	return &onlineTreeNode{
+		featureIndex:   node.FeatureIndex,
+		splitValue:     node.SplitValue,
+		sampleCount:    node.SampleCount,
+		depth:          node.Depth,
+		left:           restoreNode(node.Left),
+		right:          restoreNode(node.Right),
+		isLeaf:         node.IsLeaf,
+		isolationScore: node.IsolationScore,
+	}
+}
+
+// getStorageClient obtains a client named name from the storage extension with the given ID.
+func getStorageClient(ctx context.Context, host component.Host, storageID, componentID component.ID, name string) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	client, err := storageExt.GetClient(ctx, component.KindProcessor, componentID, name)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't obtain a storage client from '%s': %w", storageID, err)
+	}
+	return client, nil
+}
+
+// startPersistence reloads the persisted models and starts snapshotting them periodically.
+// The models of each signal are persisted separately, as their features differ.
+func (p *isolationForestProcessor) startPersistence(ctx context.Context, host component.Host) error {
+	cfg := p.config.Persistence
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.StorageID != nil {
+		client, err := getStorageClient(ctx, host, *cfg.StorageID, p.componentID, p.signal)
+		if err != nil {
+			return err
+		}
+		p.storageClient = client
+	}
+
+	for name, forest := range p.forests() {
+		p.loadModel(ctx, name, forest)
+	}
+
+	if p.storageClient == nil && cfg.ExportDirectory == "" {
+		return nil
+	}
+
+	interval, err := p.config.GetSnapshotInterval()
+	if err != nil {
+		return fmt.Errorf("failed to parse snapshot interval: %w", err)
+	}
+	p.shutdownWG.Add(1)
+	go func() {
+		defer p.shutdownWG.Done()
+		p.snapshotLoop(interval)
+	}()
+	return nil
+}
+
+// shutdownPersistence snapshots the models a last time and releases the storage client.
+// It must be called once the background goroutines have stopped.
+func (p *isolationForestProcessor) shutdownPersistence(ctx context.Context) error {
+	if p.config.Persistence == nil {
+		return nil
+	}
+
+	err := p.saveModels(ctx)
+	if p.storageClient != nil {
+		err = errors.Join(err, p.storageClient.Close(ctx))
+		p.storageClient = nil
+	}
+	return err
+}
+
+// snapshotLoop snapshots the models periodically until the processor is shut down.
+func (p *isolationForestProcessor) snapshotLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.saveModels(context.Background()); err != nil {
+				p.logger.Warn("Failed to snapshot models", zap.Error(err))
+			}
+		case <-p.stopChan:
+			return
+		}
+	}
+}
+
+// forests returns the models by name.
+func (p *isolationForestProcessor) forests() map[string]*onlineIsolationForest {
+	p.forestsMutex.RLock()
+	defer p.forestsMutex.RUnlock()
+
+	if p.defaultForest != nil {
+		return map[string]*onlineIsolationForest{"default": p.defaultForest}
+	}
+	forests := make(map[string]*onlineIsolationForest, len(p.modelForests))
+	for name, forest := range p.modelForests {
+		forests[name] = forest
+	}
+	return forests
+}
+
+// loadModel restores a model from the storage, or from the import directory when it is not
+// found in the storage. A model that cannot be restored is trained from scratch.
+func (p *isolationForestProcessor) loadModel(ctx context.Context, name string, forest *onlineIsolationForest) {
+	var data []byte
+	source := "storage"
+	if p.storageClient != nil {
+		var err error
+		if data, err = p.storageClient.Get(ctx, name); err != nil {
+			p.logger.Warn("Failed to read model from storage", zap.String("model_name", name), zap.Error(err))
+		}
+	}
+
+	if data == nil && p.config.Persistence.ImportDirectory != "" {
+		source = p.modelFile(p.config.Persistence.ImportDirectory, name)
+		var err error
+		if data, err = os.ReadFile(source); err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				p.logger.Warn("Failed to import model", zap.String("model_name", name), zap.Error(err))
+			}
+			return
+		}
+	}
+
+	if data == nil {
+		return
+	}
+
+	// The trees split on feature indexes, which are meaningless if the features changed
+	var snap forestSnapshot
+	err := json.Unmarshal(data, &snap)
+	if err == nil && !slices.Equal(snap.Features, p.features()) {
+		err = fmt.Errorf("snapshot has features %v, expected %v", snap.Features, p.features())
+	}
+	if err == nil {
+		err = forest.restore(&snap)
+	}
+	if err != nil {
+		p.logger.Warn("Ignoring persisted model, training from scratch",
+			zap.String("model_name", name),
+			zap.String("source", source),
+			zap.Error(err),
+		)
+		return
+	}
+
+	p.logger.Info("Restored model",
+		zap.String("model_name", name),
+		zap.String("source", source),
+		zap.Uint64("total_samples", snap.TotalSamples),
+	)
+}
+
+// saveModels snapshots the models to the storage and to the export directory. The models that
+// have not seen any sample yet are skipped, so that they do not overwrite trained models.
+func (p *isolationForestProcessor) saveModels(ctx context.Context) error {
+	var errs error
+	for name, forest := range p.forests() {
+		snap := forest.snapshot()
+		if snap.TotalSamples == 0 {
+			continue
+		}
+		snap.Features = p.features()
+
+		data, err := json.Marshal(snap)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to encode model '%s': %w", name, err))
+			continue
+		}
+
+		if p.storageClient != nil {
+			if err := p.storageClient.Set(ctx, name, data); err != nil {
+				errs = errors.Join(errs, fmt.Errorf("failed to store model '%s': %w", name, err))
+			}
+		}
+
+		if p.config.Persistence.ExportDirectory != "" {
+			if err := writeFileAtomic(p.modelFile(p.config.Persistence.ExportDirectory, name), data); err != nil {
+				errs = errors.Join(errs, fmt.Errorf("failed to export model '%s': %w", name, err))
+			}
+		}
+	}
+	return errs
+}
+
+// features returns the features of the samples of the signal the models are trained on.
+func (p *isolationForestProcessor) features() []string {
+	switch p.signal {
+	case "traces":
+		return p.config.Features.Traces
+	case "metrics":
+		return p.config.Features.Metrics
+	case "logs":
+		return p.config.Features.Logs
+	}
+	return nil
+}
+
+// modelFile returns the path of the file of a model in an export or import directory.
+func (p *isolationForestProcessor) modelFile(directory, name string) string {
+	return filepath.Join(directory, p.signal, name+".json")
+}
+
+// writeFileAtomic writes the file through a temporary file, so that readers never see a partial model.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+	return err
+}
@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// persistence_test.go - Tests for the model snapshots
+package isolationforestprocessor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func trainForest(forest *onlineIsolationForest, n int) {
+	for i := range n {
+		forest.ProcessSample([]float64{float64(i % 10), float64(i % 7)})
+	}
+}
+
+func TestForestSnapshotRoundTrip(t *testing.T) {
+	forest := newOnlineIsolationForest(10, 64, 0)
+	trainForest(forest, 200)
+
+	data, err := json.Marshal(forest.snapshot())
+	require.NoError(t, err)
+	var snap forestSnapshot
+	require.NoError(t, json.Unmarshal(data, &snap))
+
+	restored := newOnlineIsolationForest(10, 64, 0)
+	require.NoError(t, restored.restore(&snap))
+
+	// The restored forest scores like the original one
+	for _, sample := range [][]float64{{1, 2}, {5, 5}, {100, -100}} {
+		assert.Equal(t, forest.calculateAnomalyScore(sample), restored.calculateAnomalyScore(sample))
+	}
+
+	expected := forest.GetStatistics()
+	actual := restored.GetStatistics()
+	assert.Equal(t, expected.TotalSamples, actual.TotalSamples)
+	assert.Equal(t, expected.AnomalyCount, actual.AnomalyCount)
+	assert.Equal(t, expected.CurrentThreshold, actual.CurrentThreshold)
+	assert.Equal(t, expected.WindowUtilization, actual.WindowUtilization)
+
+	// The window keeps the most recent samples in order
+	restored.windowMutex.RLock()
+	window := restored.getWindowData()
+	restored.windowMutex.RUnlock()
+	require.Len(t, window, 64)
+	assert.Equal(t, snap.Window, window)
+	assert.Equal(t, []float64{float64(199 % 10), float64(199 % 7)}, snap.Window[len(snap.Window)-1])
+}
+
+func TestForestRestorePartialWindow(t *testing.T) {
+	forest := newOnlineIsolationForest(5, 64, 0)
+	trainForest(forest, 20)
+
+	restored := newOnlineIsolationForest(5, 16, 0)
+	require.NoError(t, restored.restore(forest.snapshot()))
+
+	// Only the most recent samples fit in the smaller window
+	restored.windowMutex.RLock()
+	assert.Len(t, restored.getWindowData(), 16)
+	assert.True(t, restored.windowFull)
+	assert.Equal(t, 0, restored.windowIndex)
+	restored.windowMutex.RUnlock()
+
+	// Learning resumes after the restore
+	trainForest(restored, 5)
+	assert.Equal(t, uint64(25), restored.GetStatistics().TotalSamples)
+}
+
+func TestForestRestoreIncompatibleSnapshot(t *testing.T) {
+	forest := newOnlineIsolationForest(10, 64, 0)
+	trainForest(forest, 50)
+	snap := forest.snapshot()
+
+	err := newOnlineIsolationForest(20, 64, 0).restore(snap)
+	assert.ErrorContains(t, err, "snapshot has 10 trees, expected 20")
+
+	snap.Version = snapshotVersion + 1
+	err = newOnlineIsolationForest(10, 64, 0).restore(snap)
+	assert.ErrorContains(t, err, "unsupported snapshot version")
+}
+
+func newPersistenceTestProcessor(t *testing.T, cfg *Config) *isolationForestProcessor {
+	p, err := newIsolationForestProcessor(cfg, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	p.componentID = component.MustNewID("isolationforest")
+	p.signal = "metrics"
+	return p
+}
+
+func TestPersistenceStorage(t *testing.T) {
+	storageID := storagetest.NewStorageID("models")
+	storageDir := t.TempDir()
+	cfg := baseTestConfig(t)
+	cfg.Persistence = &PersistenceConfig{StorageID: &storageID}
+
+	p := newPersistenceTestProcessor(t, cfg)
+	require.NoError(t, p.Start(t.Context(), storagetest.NewStorageHost().WithFileBackedStorageExtension("models", storageDir)))
+	trainForest(p.defaultForest, 100)
+	require.NoError(t, p.Shutdown(t.Context()))
+
+	// The model is reloaded by the next instance
+	restarted := newPersistenceTestProcessor(t, cfg)
+	require.NoError(t, restarted.Start(t.Context(), storagetest.NewStorageHost().WithFileBackedStorageExtension("models", storageDir)))
+	assert.Equal(t, uint64(100), restarted.defaultForest.GetStatistics().TotalSamples)
+	assert.Equal(t, p.defaultForest.calculateAnomalyScore([]float64{3, 3}), restarted.defaultForest.calculateAnomalyScore([]float64{3, 3}))
+	require.NoError(t, restarted.Shutdown(t.Context()))
+}
+
+func TestPersistenceStorageNotFound(t *testing.T) {
+	storageID := storagetest.NewStorageID("missing")
+	cfg := baseTestConfig(t)
+	cfg.Persistence = &PersistenceConfig{StorageID: &storageID}
+
+	p := newPersistenceTestProcessor(t, cfg)
+	require.ErrorContains(t, p.Start(t.Context(), componenttest.NewNopHost()), "storage extension 'test_storage/missing' not found")
+
+	nonStorageID := storagetest.NewNonStorageID("models")
+	cfg.Persistence.StorageID = &nonStorageID
+	p = newPersistenceTestProcessor(t, cfg)
+	require.ErrorContains(t, p.Start(t.Context(), storagetest.NewStorageHost().WithNonStorageExtension("models")), "non-storage extension")
+}
+
+func TestPersistenceExportImport(t *testing.T) {
+	dir := t.TempDir()
+	cfg := baseTestConfig(t)
+	cfg.Models = []ModelConfig{
+		{Name: "web", Selector: map[string]string{"service.name": "web"}, ForestSize: 10},
+		{Name: "db", Selector: map[string]string{"service.name": "db"}, ForestSize: 10},
+	}
+	cfg.Persistence = &PersistenceConfig{ExportDirectory: dir}
+
+	trainer := newPersistenceTestProcessor(t, cfg)
+	require.NoError(t, trainer.Start(t.Context(), componenttest.NewNopHost()))
+	trainForest(trainer.modelForests["web"], 100)
+	require.NoError(t, trainer.Shutdown(t.Context()))
+
+	// Only the trained model is exported
+	assert.FileExists(t, filepath.Join(dir, "metrics", "web.json"))
+	assert.NoFileExists(t, filepath.Join(dir, "metrics", "db.json"))
+	entries, err := os.ReadDir(filepath.Join(dir, "metrics"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	cfg.Persistence = &PersistenceConfig{ImportDirectory: dir}
+	fleet := newPersistenceTestProcessor(t, cfg)
+	require.NoError(t, fleet.Start(t.Context(), componenttest.NewNopHost()))
+	assert.Equal(t, uint64(100), fleet.modelForests["web"].GetStatistics().TotalSamples)
+	assert.Equal(t, uint64(0), fleet.modelForests["db"].GetStatistics().TotalSamples)
+	require.NoError(t, fleet.Shutdown(t.Context()))
+}
+
+func TestPersistenceImportInvalidModel(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "metrics"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "metrics", "default.json"), []byte("not json"), 0o600))
+
+	cfg := baseTestConfig(t)
+	cfg.Persistence = &PersistenceConfig{ImportDirectory: dir}
+
+	// The model is trained from scratch
+	p := newPersistenceTestProcessor(t, cfg)
+	require.NoError(t, p.Start(t.Context(), componenttest.NewNopHost()))
+	assert.Equal(t, uint64(0), p.defaultForest.GetStatistics().TotalSamples)
+	require.NoError(t, p.Shutdown(t.Context()))
+}
+
+func TestPersistenceImportDifferentFeatures(t *testing.T) {
+	dir := t.TempDir()
+	cfg := baseTestConfig(t)
+	cfg.Features.Metrics = []string{"value", "rate"}
+	cfg.Persistence = &PersistenceConfig{ExportDirectory: dir}
+
+	exporter := newPersistenceTestProcessor(t, cfg)
+	require.NoError(t, exporter.Start(t.Context(), componenttest.NewNopHost()))
+	trainForest(exporter.defaultForest, 50)
+	require.NoError(t, exporter.Shutdown(t.Context()))
+
+	// The model trained with other features is rejected, and trained from scratch
+	cfg.Features.Metrics = []string{"rate", "value"}
+	cfg.Persistence = &PersistenceConfig{ImportDirectory: dir}
+	core, logs := observer.New(zap.WarnLevel)
+	p, err := newIsolationForestProcessor(cfg, zap.New(core))
+	require.NoError(t, err)
+	p.componentID = component.MustNewID("isolationforest")
+	p.signal = "metrics"
+	require.NoError(t, p.Start(t.Context(), componenttest.NewNopHost()))
+	assert.Equal(t, uint64(0), p.defaultForest.GetStatistics().TotalSamples)
+	require.NoError(t, p.Shutdown(t.Context()))
+
+	warnings := logs.FilterMessage("Ignoring persisted model, training from scratch").All()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "snapshot has features [value rate], expected [rate value]", warnings[0].ContextMap()["error"])
+}
+
+func TestPersistenceStorageTakesPrecedenceOverImport(t *testing.T) {
+	storageID := storagetest.NewStorageID("models")
+	storageDir := t.TempDir()
+	dir := t.TempDir()
+	cfg := baseTestConfig(t)
+
+	// Export a model trained on 50 samples, and store one trained on 100 samples
+	cfg.Persistence = &PersistenceConfig{ExportDirectory: dir}
+	exporter := newPersistenceTestProcessor(t, cfg)
+	require.NoError(t, exporter.Start(t.Context(), componenttest.NewNopHost()))
+	trainForest(exporter.defaultForest, 50)
+	require.NoError(t, exporter.Shutdown(t.Context()))
+
+	cfg.Persistence = &PersistenceConfig{StorageID: &storageID, ImportDirectory: dir}
+	p := newPersistenceTestProcessor(t, cfg)
+	require.NoError(t, p.Start(t.Context(), storagetest.NewStorageHost().WithFileBackedStorageExtension("models", storageDir)))
+	assert.Equal(t, uint64(50), p.defaultForest.GetStatistics().TotalSamples)
+	trainForest(p.defaultForest, 50)
+	require.NoError(t, p.Shutdown(t.Context()))
+
+	restarted := newPersistenceTestProcessor(t, cfg)
+	require.NoError(t, restarted.Start(t.Context(), storagetest.NewStorageHost().WithFileBackedStorageExtension("models", storageDir)))
+	assert.Equal(t, uint64(100), restarted.defaultForest.GetStatistics().TotalSamples)
+	require.NoError(t, restarted.Shutdown(t.Context()))
+}
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -48,6 +49,11 @@ type isolationForestProcessor struct {
 	updateTicker    *time.Ticker
 	stopChan        chan struct{}
 	shutdownWG      sync.WaitGroup
+
+	// Model persistence, the models are persisted per processor and signal
+	componentID   component.ID
+	signal        string
+	storageClient storage.Client
 }
 
 // newIsolationForestProcessor creates a new processor instance with the specified configuration.
@@ -133,9 +139,13 @@ func newIsolationForestProcessor(config *Config, logger *zap.Logger) (*isolation
 }
 
 // Start initializes the processor
-func (p *isolationForestProcessor) Start(_ context.Context, _ component.Host) error {
+func (p *isolationForestProcessor) Start(ctx context.Context, host component.Host) error {
 	p.logger.Info("Starting isolation forest processor")
-	// Any additional initialization logic can go here
+
+	// Reload the persisted models before any sample is processed
+	if err := p.startPersistence(ctx, host); err != nil {
+		return fmt.Errorf("failed to start model persistence: %w", err)
+	}
 
 	// Start the background model update loop
 	p.shutdownWG.Add(1)
@@ -148,7 +158,7 @@ func (p *isolationForestProcessor) Start(_ context.Context, _ component.Host) er
 }
 
 // Shutdown gracefully stops the processor and cleans up resources.
-func (p *isolationForestProcessor) Shutdown(_ context.Context) error {
+func (p *isolationForestProcessor) Shutdown(ctx context.Context) error {
 	p.logger.Info("Shutting down isolation forest processor")
 
 	// Stop the update ticker
@@ -162,6 +172,11 @@ func (p *isolationForestProcessor) Shutdown(_ context.Context) error {
 	// Wait for all background goroutines to complete
 	p.shutdownWG.Wait()
 
+	// Snapshot the models a last time
+	if err := p.shutdownPersistence(ctx); err != nil {
+		return fmt.Errorf("failed to persist models: %w", err)
+	}
+
 	p.logger.Info("Isolation forest processor shutdown complete")
 	return nil
 }
@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cache // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/cache"
+
+import (
+	"context"
+	"encoding/binary"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// StorageSweepInterval is the interval at which the expired decisions should be deleted from the storage,
+// by calling StorageDecisionCache.Sweep. It is also the precision of their expiry.
+const StorageSweepInterval = time.Minute
+
+const traceIDSize = len(pcommon.TraceID{})
+
+// StorageDecisionCache implements Cache on top of a storage extension client, so that the decisions
+// survive restarts and can be shared by the collectors using the same storage.
+// Like the LRU cache, it only records that a decision was made for an ID, so separate caches,
+// with different prefixes, must be used for the sampled and not sampled trace IDs.
+// The decisions are also kept in a local cache, which is looked up before the storage.
+//
+// Each decision is stored with its expiry. As the storage can't list its keys, the IDs are also recorded
+// in buckets of expiry, stored under the same prefix, which Sweep reads to delete the expired decisions.
+type StorageDecisionCache struct {
+	local   Cache[bool]
+	client  storage.Client
+	prefix  string
+	ttl     time.Duration
+	onError func(error)
+
+	now            func() time.Time
+	bucketInterval time.Duration
+
+	mu sync.Mutex
+	// pendingBuckets holds the IDs written since the last sweep, by expiry bucket.
+	pendingBuckets map[int64][]byte
+}
+
+var _ Cache[bool] = (*StorageDecisionCache)(nil)
+
+// NewStorageDecisionCache returns a new StorageDecisionCache.
+// The trace IDs are stored under the given prefix for the given ttl, and the local cache, which may be a no-op cache,
+// holds the decisions already read from or written to the storage. As Cache doesn't return errors,
+// the errors of the storage client are reported to onError, and a failed lookup is a cache miss.
+func NewStorageDecisionCache(client storage.Client, prefix string, ttl time.Duration, local Cache[bool], onError func(error)) *StorageDecisionCache {
+	return &StorageDecisionCache{
+		local:          local,
+		client:         client,
+		prefix:         prefix,
+		ttl:            ttl,
+		onError:        onError,
+		now:            time.Now,
+		bucketInterval: StorageSweepInterval,
+		pendingBuckets: map[int64][]byte{},
+	}
+}
+
+// Get looks the ID up in the local cache, and then in the storage. The expired decisions that were not
+// swept yet are ignored.
+func (c *StorageDecisionCache) Get(id pcommon.TraceID) (bool, bool) {
+	if v, ok := c.local.Get(id); ok {
+		return v, ok
+	}
+
+	value, err := c.client.Get(context.Background(), c.key(id))
+	if err != nil {
+		c.onError(err)
+		return false, false
+	}
+	if value == nil || expired(value, c.now()) {
+		return false, false
+	}
+	c.local.Put(id, true)
+	return true, true
+}
+
+func (c *StorageDecisionCache) Put(id pcommon.TraceID, v bool) {
+	c.local.Put(id, v)
+
+	expiry := c.now().Add(c.ttl)
+	value := binary.BigEndian.AppendUint64(nil, uint64(expiry.UnixNano()))
+	if err := c.client.Set(context.Background(), c.key(id), value); err != nil {
+		c.onError(err)
+		return
+	}
+
+	bucket := c.bucket(expiry)
+	c.mu.Lock()
+	c.pendingBuckets[bucket] = append(c.pendingBuckets[bucket], id[:]...)
+	c.mu.Unlock()
+}
+
+func (c *StorageDecisionCache) Delete(id pcommon.TraceID) {
+	c.local.Delete(id)
+	if err := c.client.Delete(context.Background(), c.key(id)); err != nil {
+		c.onError(err)
+	}
+}
+
+// Sweep stores the expiry buckets of the IDs written since the last sweep, and deletes the decisions of the
+// buckets that expired since the last sweep, which may have been made before a restart.
+func (c *StorageDecisionCache) Sweep(ctx context.Context) {
+	c.mu.Lock()
+	pending := c.pendingBuckets
+	c.pendingBuckets = map[int64][]byte{}
+	c.mu.Unlock()
+
+	for bucket, ids := range pending {
+		key := c.bucketKey(bucket)
+		stored, err := c.client.Get(ctx, key)
+		if err != nil {
+			c.onError(err)
+			continue
+		}
+		if err := c.client.Set(ctx, key, append(stored, ids...)); err != nil {
+			c.onError(err)
+		}
+	}
+
+	now := c.now()
+	current := c.bucket(now) - 1
+	cursorKey := c.prefix + "expiry/swept"
+	cursor, err := c.client.Get(ctx, cursorKey)
+	if err != nil {
+		c.onError(err)
+		return
+	}
+
+	// Without a cursor, no bucket older than the current one was ever written
+	from := current + 1
+	if len(cursor) == 8 {
+		from = int64(binary.BigEndian.Uint64(cursor)) + 1
+	}
+	// The IDs written with a ttl shorter than the interval may land in a bucket that already expired
+	for bucket := range pending {
+		from = min(from, bucket)
+	}
+
+	for bucket := from; bucket <= current; bucket++ {
+		if err := c.sweepBucket(ctx, bucket, now); err != nil {
+			c.onError(err)
+			return
+		}
+	}
+
+	if err := c.client.Set(ctx, cursorKey, binary.BigEndian.AppendUint64(nil, uint64(current))); err != nil {
+		c.onError(err)
+	}
+}
+
+// sweepBucket deletes the expired decisions of a bucket, and the bucket itself. The IDs that were written again
+// since they were added to the bucket have a later expiry, and are swept with a later bucket.
+func (c *StorageDecisionCache) sweepBucket(ctx context.Context, bucket int64, now time.Time) error {
+	bucketKey := c.bucketKey(bucket)
+	ids, err := c.client.Get(ctx, bucketKey)
+	if err != nil || ids == nil {
+		return err
+	}
+
+	gets := make([]*storage.Operation, 0, len(ids)/traceIDSize)
+	for i := 0; i+traceIDSize <= len(ids); i += traceIDSize {
+		gets = append(gets, storage.GetOperation(c.key(pcommon.TraceID(ids[i:i+traceIDSize]))))
+	}
+	if err := c.client.Batch(ctx, gets...); err != nil {
+		return err
+	}
+
+	deletes := []*storage.Operation{storage.DeleteOperation(bucketKey)}
+	for _, op := range gets {
+		if op.Value != nil && expired(op.Value, now) {
+			deletes = append(deletes, storage.DeleteOperation(op.Key))
+		}
+	}
+	return c.client.Batch(ctx, deletes...)
+}
+
+func (c *StorageDecisionCache) key(id pcommon.TraceID) string {
+	return c.prefix + id.String()
+}
+
+func (c *StorageDecisionCache) bucketKey(bucket int64) string {
+	return c.prefix + "expiry/" + strconv.FormatInt(bucket, 10)
+}
+
+// bucket returns the bucket of the given expiry: the decisions of a bucket are all expired once the
+// following bucket started.
+func (c *StorageDecisionCache) bucket(t time.Time) int64 {
+	return t.UnixNano() / int64(c.bucketInterval)
+}
+
+// expired returns whether a stored decision expired. The values that can't be decoded are considered expired.
+func expired(value []byte, now time.Time) bool {
+	return len(value) != 8 || now.UnixNano() >= int64(binary.BigEndian.Uint64(value))
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func TestStorageCacheSharesDecisions(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindProcessor, storagetest.NewStorageID("test"), "decisions")
+	id, err := traceIDFromHex("12341234123412341234123412341234")
+	require.NoError(t, err)
+
+	writer := NewStorageDecisionCache(client, "sampled/", time.Hour, NewNopDecisionCache[bool](), noError(t))
+	writer.Put(id, true)
+
+	// A cache created on the same storage, e.g. after a restart, sees the decision
+	local, err := NewLRUDecisionCache[bool](2)
+	require.NoError(t, err)
+	reader := NewStorageDecisionCache(client, "sampled/", time.Hour, local, noError(t))
+	v, ok := reader.Get(id)
+	assert.True(t, v)
+	assert.True(t, ok)
+
+	// The decision read from the storage is kept in the local cache
+	v, ok = local.Get(id)
+	assert.True(t, v)
+	assert.True(t, ok)
+
+	// The prefix separates the sampled and non-sampled decisions
+	other := NewStorageDecisionCache(client, "not_sampled/", time.Hour, NewNopDecisionCache[bool](), noError(t))
+	_, ok = other.Get(id)
+	assert.False(t, ok)
+
+	writer.Delete(id)
+	_, ok = writer.Get(id)
+	assert.False(t, ok)
+}
+
+func TestStorageCacheExpiry(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindProcessor, storagetest.NewStorageID("test"), "decisions")
+	id1, err := traceIDFromHex("12341234123412341234123412341234")
+	require.NoError(t, err)
+	id2, err := traceIDFromHex("56785678567856785678567856785678")
+	require.NoError(t, err)
+	stored := func(key string) bool {
+		value, err := client.Get(t.Context(), key)
+		require.NoError(t, err)
+		return value != nil
+	}
+
+	now := time.Unix(1000, 0)
+	newCache := func() *StorageDecisionCache {
+		c := NewStorageDecisionCache(client, "sampled/", 10*time.Minute, NewNopDecisionCache[bool](), noError(t))
+		c.now = func() time.Time { return now }
+		return c
+	}
+	c := newCache()
+	c.Sweep(t.Context())
+
+	c.Put(id1, true)
+	now = now.Add(5 * time.Minute)
+	c.Put(id2, true)
+	c.Sweep(t.Context())
+
+	// The expired decision is ignored, and deleted once its whole bucket expired
+	now = now.Add(6 * time.Minute)
+	_, ok := c.Get(id1)
+	assert.False(t, ok)
+	assert.True(t, stored("sampled/"+id1.String()))
+	_, ok = c.Get(id2)
+	assert.True(t, ok)
+
+	// A restarted cache deletes the decisions of the buckets written before the restart
+	c = newCache()
+	now = now.Add(time.Minute)
+	c.Sweep(t.Context())
+	assert.False(t, stored("sampled/"+id1.String()))
+	assert.True(t, stored("sampled/"+id2.String()))
+
+	// A decision written again is kept until its new expiry
+	c.Put(id2, true)
+	now = now.Add(5 * time.Minute)
+	c.Sweep(t.Context())
+	_, ok = c.Get(id2)
+	assert.True(t, ok)
+
+	now = now.Add(10 * time.Minute)
+	c.Sweep(t.Context())
+	assert.False(t, stored("sampled/"+id2.String()))
+
+	// Only the sweep cursor is left
+	for _, bucket := range []string{"26", "31", "38"} {
+		assert.False(t, stored("sampled/expiry/"+bucket), bucket)
+	}
+	assert.True(t, stored("sampled/expiry/swept"))
+}
+
+func TestStorageCacheErrors(t *testing.T) {
+	var errs []error
+	client := &failingClient{}
+	c := NewStorageDecisionCache(client, "sampled/", time.Hour, NewNopDecisionCache[bool](), func(err error) {
+		errs = append(errs, err)
+	})
+	id, err := traceIDFromHex("12341234123412341234123412341234")
+	require.NoError(t, err)
+
+	c.Put(id, true)
+	_, ok := c.Get(id)
+	assert.False(t, ok)
+	c.Delete(id)
+	assert.Len(t, errs, 3)
+}
+
+func noError(t *testing.T) func(error) {
+	return func(err error) {
+		assert.NoError(t, err)
+	}
+}
+
+type failingClient struct {
+	storage.Client
+}
+
+func (*failingClient) Get(context.Context, string) ([]byte, error) {
+	return nil, errors.New("unavailable")
+}
+
+func (*failingClient) Set(context.Context, string, []byte) error {
+	return errors.New("unavailable")
+}
+
+func (*failingClient) Delete(context.Context, string) error {
+	return errors.New("unavailable")
+}
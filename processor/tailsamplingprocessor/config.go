@@ -4,11 +4,20 @@
 package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
 
 import (
+	"errors"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
+var (
+	errSpillWithBlockOnOverflow = errors.New("spill_storage cannot be used with block_on_overflow")
+	errInvalidPollInterval      = errors.New("policy_source::poll_interval must be positive")
+	errInvalidStorageTTL        = errors.New("decision_cache::storage_ttl must be positive")
+)
+
 // PolicyType indicates the type of sampling policy.
 type PolicyType string
 
@@ -279,6 +288,30 @@ type DecisionCacheConfig struct {
 	// For effective use, this value should be at least an order of magnitude greater than Config.NumTraces.
 	// If left as default 0, a no-op DecisionCache will be used.
 	NonSampledCacheSize int `mapstructure:"non_sampled_cache_size"`
+	// StorageID is the ID of a storage extension persisting the decisions, so that they survive restarts and
+	// can be shared by the collectors using the same storage. The caches above are then used as local caches
+	// in front of the storage, and are optional.
+	// If left as default nil, the decisions are only kept in memory.
+	StorageID *component.ID `mapstructure:"storage"`
+	// StorageTTL is the time after which the decisions are deleted from the storage. It should be greater than
+	// the time late spans may arrive after the decision.
+	StorageTTL time.Duration `mapstructure:"storage_ttl"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// PolicySourceConfig configures the sources from which the policies are replaced at runtime. The pending traces
+// and the decision caches are kept when the policies are replaced.
+type PolicySourceConfig struct {
+	// OpAMP is the ID of the extension implementing opampcustommessages.CustomCapabilityRegistry through which
+	// the policies are received, as custom messages of the PolicyMessageType type.
+	// If left as default nil, the policies are not received through OpAMP.
+	OpAMP *component.ID `mapstructure:"opamp"`
+	// File is the path of a YAML file holding the policies, under a policies key. The policies of the file
+	// replace the configured policies at startup, and the file is watched for changes.
+	File string `mapstructure:"file"`
+	// PollInterval is the interval at which File is checked for changes.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -309,4 +342,25 @@ type Config struct {
 	// DropPendingTracesOnShutdown will drop all traces that are part of batches that have not yet reached the decision
 	// wait when the processor is shutdown.
 	DropPendingTracesOnShutdown bool `mapstructure:"drop_pending_traces_on_shutdown"`
+	// SpillStorageID is the ID of a storage extension to which the spans of the oldest pending traces are moved when
+	// the NumTraces limit is reached, instead of evicting the traces. The spans are read back when the decision for
+	// the trace is made. Cannot be used with BlockOnOverflow.
+	SpillStorageID *component.ID `mapstructure:"spill_storage"`
+	// PolicySource configures the replacement of the policies at runtime.
+	PolicySource PolicySourceConfig `mapstructure:"policy_source"`
+}
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	var errs []error
+	if cfg.SpillStorageID != nil && cfg.BlockOnOverflow {
+		errs = append(errs, errSpillWithBlockOnOverflow)
+	}
+	if cfg.PolicySource.File != "" && cfg.PolicySource.PollInterval <= 0 {
+		errs = append(errs, errInvalidPollInterval)
+	}
+	if cfg.DecisionCache.StorageID != nil && cfg.DecisionCache.StorageTTL <= 0 {
+		errs = append(errs, errInvalidStorageTTL)
+	}
+	return errors.Join(errs...)
 }
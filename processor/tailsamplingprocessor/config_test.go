@@ -35,7 +35,8 @@ func TestLoadConfig(t *testing.T) {
 			DecisionWait:            10 * time.Second,
 			NumTraces:               100,
 			ExpectedNewTracesPerSec: 10,
-			DecisionCache:           DecisionCacheConfig{SampledCacheSize: 1_000, NonSampledCacheSize: 10_000},
+			DecisionCache:           DecisionCacheConfig{SampledCacheSize: 1_000, NonSampledCacheSize: 10_000, StorageTTL: time.Hour},
+			PolicySource:            PolicySourceConfig{PollInterval: 30 * time.Second},
 			PolicyCfgs: []PolicyCfg{
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
@@ -193,3 +194,47 @@ func TestLoadConfig(t *testing.T) {
 			},
 		}, cfg)
 }
+
+func TestLoadConfigDecisionStorage(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "decision_storage_config.yaml"))
+	require.NoError(t, err)
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+
+	storageID := component.MustNewIDWithName("file_storage", "decisions")
+	assert.Equal(t, DecisionCacheConfig{SampledCacheSize: 1_000, StorageID: &storageID, StorageTTL: 2 * time.Hour}, cfg.DecisionCache)
+}
+
+func TestValidateSpillStorage(t *testing.T) {
+	storageID := component.MustNewIDWithName("file_storage", "spill")
+	cfg := createDefaultConfig().(*Config)
+	cfg.SpillStorageID = &storageID
+	require.NoError(t, cfg.Validate())
+
+	cfg.BlockOnOverflow = true
+	require.ErrorIs(t, cfg.Validate(), errSpillWithBlockOnOverflow)
+}
+
+func TestValidatePolicySource(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.PolicySource.File = "/etc/otelcol/policies.yaml"
+	require.NoError(t, cfg.Validate())
+
+	cfg.PolicySource.PollInterval = 0
+	require.ErrorIs(t, cfg.Validate(), errInvalidPollInterval)
+}
+
+func TestValidateDecisionStorage(t *testing.T) {
+	storageID := component.MustNewIDWithName("file_storage", "decisions")
+	cfg := createDefaultConfig().(*Config)
+	cfg.DecisionCache.StorageID = &storageID
+	require.NoError(t, cfg.Validate())
+
+	cfg.DecisionCache.StorageTTL = 0
+	require.ErrorIs(t, cfg.Validate(), errInvalidStorageTTL)
+}
@@ -30,6 +30,12 @@ func createDefaultConfig() component.Config {
 		DecisionWait:       30 * time.Second,
 		NumTraces:          50000,
 		SampleOnFirstMatch: false,
+		DecisionCache: DecisionCacheConfig{
+			StorageTTL: time.Hour,
+		},
+		PolicySource: PolicySourceConfig{
+			PollInterval: 30 * time.Second,
+		},
 	}
 }
 
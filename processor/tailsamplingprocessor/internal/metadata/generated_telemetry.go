@@ -39,6 +39,7 @@ type TelemetryBuilder struct {
 	ProcessorTailSamplingSamplingTraceDroppedTooEarly   metric.Int64Counter
 	ProcessorTailSamplingSamplingTraceRemovalAge        metric.Int64Histogram
 	ProcessorTailSamplingSamplingTracesOnMemory         metric.Int64Gauge
+	ProcessorTailSamplingSamplingTracesSpilled          metric.Int64Counter
 }
 
 // TelemetryBuilderOption applies changes to default builder.
@@ -149,5 +150,11 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{traces}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorTailSamplingSamplingTracesSpilled, err = builder.meter.Int64Counter(
+		"otelcol_processor_tail_sampling_sampling_traces_spilled",
+		metric.WithDescription("Count of pending traces whose spans were spilled to the storage to make room in memory [Development]"),
+		metric.WithUnit("{traces}"),
+	)
+	errs = errors.Join(errs, err)
 	return &builder, errs
 }
@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampcustommessages"
+)
+
+const (
+	// PolicyCustomCapability is the OpAMP custom capability through which the policies are replaced.
+	PolicyCustomCapability = "org.opentelemetry.collector.processor.tailsampling"
+	// PolicyMessageType is the type of the custom messages holding the policies.
+	PolicyMessageType = "policies"
+)
+
+var errPolicySourceStopped = errors.New("the policy source was stopped")
+
+// policyDocument is the content of a policy file or custom message.
+type policyDocument struct {
+	Policies []PolicyCfg `mapstructure:"policies"`
+}
+
+// parsePolicies reads the policies from a YAML document holding a policies list, using the same
+// format as the policies setting of the processor.
+func parsePolicies(data []byte) ([]PolicyCfg, error) {
+	retrieved, err := confmap.NewRetrievedFromYAML(data)
+	if err != nil {
+		return nil, err
+	}
+	conf, err := retrieved.AsConf()
+	if err != nil {
+		return nil, err
+	}
+
+	var doc policyDocument
+	if err := conf.Unmarshal(&doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Policies) == 0 {
+		return nil, errors.New("no policies found")
+	}
+	return doc.Policies, nil
+}
+
+// reloadPolicies replaces the evaluators of the processor with the policies of the given document.
+// The pending traces and the decision caches are kept, the new policies apply to the next decisions.
+func (tsp *tailSamplingSpanProcessor) reloadPolicies(data []byte, source string) error {
+	cfgs, err := parsePolicies(data)
+	if err != nil {
+		return fmt.Errorf("couldn't parse the policies from %s: %w", source, err)
+	}
+	policies, err := tsp.loadSamplingPolicies(tsp.host, cfgs)
+	if err != nil {
+		return fmt.Errorf("couldn't load the policies from %s: %w", source, err)
+	}
+
+	select {
+	case tsp.newPolicyChan <- newPolicyCmd{policies: policies}:
+	case <-tsp.policySourceDone:
+		return errPolicySourceStopped
+	}
+	tsp.logger.Info("Sampling policies reloaded", zap.String("source", source), zap.Int("policies.len", len(policies)))
+	return nil
+}
+
+// startPolicySources loads the policy file and starts watching it, and registers the custom capability
+// receiving the policies through OpAMP.
+func (tsp *tailSamplingSpanProcessor) startPolicySources(host component.Host) error {
+	cfg := tsp.cfg.PolicySource
+	tsp.policySourceDone = make(chan struct{})
+
+	if cfg.File != "" {
+		// A policy file that can't be loaded at startup is a configuration error
+		data, err := os.ReadFile(cfg.File)
+		if err != nil {
+			return fmt.Errorf("couldn't read the policy file: %w", err)
+		}
+		cfgs, err := parsePolicies(data)
+		if err != nil {
+			return fmt.Errorf("couldn't parse the policies from %s: %w", cfg.File, err)
+		}
+		policies, err := tsp.loadSamplingPolicies(host, cfgs)
+		if err != nil {
+			return fmt.Errorf("couldn't load the policies from %s: %w", cfg.File, err)
+		}
+		tsp.policies = policies
+
+		tsp.policySourceWG.Add(1)
+		go tsp.watchPolicyFile(cfg.File, cfg.PollInterval, data)
+	}
+
+	if cfg.OpAMP != nil {
+		ext, ok := host.GetExtensions()[*cfg.OpAMP]
+		if !ok {
+			return fmt.Errorf("extension %q does not exist", cfg.OpAMP)
+		}
+		registry, ok := ext.(opampcustommessages.CustomCapabilityRegistry)
+		if !ok {
+			return fmt.Errorf("extension %q is not a custom message registry", cfg.OpAMP)
+		}
+		handler, err := registry.Register(PolicyCustomCapability)
+		if err != nil {
+			return fmt.Errorf("failed to register custom capability: %w", err)
+		}
+		tsp.policyHandler = handler
+
+		tsp.policySourceWG.Add(1)
+		go tsp.receivePolicyMessages(handler)
+	}
+	return nil
+}
+
+// watchPolicyFile polls the policy file, and reloads the policies when its content changes.
+func (tsp *tailSamplingSpanProcessor) watchPolicyFile(path string, interval time.Duration, last []byte) {
+	defer tsp.policySourceWG.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-tsp.policySourceDone:
+			return
+		case <-ticker.C:
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			tsp.logger.Warn("Error reading the policy file, keeping the current policies", zap.Error(err))
+			continue
+		}
+		if bytes.Equal(data, last) {
+			continue
+		}
+		last = data
+
+		if err := tsp.reloadPolicies(data, path); err != nil {
+			if errors.Is(err, errPolicySourceStopped) {
+				return
+			}
+			tsp.logger.Error("Failed to reload the sampling policies, keeping the current policies", zap.Error(err))
+		}
+	}
+}
+
+// receivePolicyMessages reloads the policies sent by the OpAMP server as custom messages.
+func (tsp *tailSamplingSpanProcessor) receivePolicyMessages(handler opampcustommessages.CustomCapabilityHandler) {
+	defer tsp.policySourceWG.Done()
+
+	for {
+		select {
+		case <-tsp.policySourceDone:
+			return
+		case msg, ok := <-handler.Message():
+			if !ok {
+				return
+			}
+			if msg.Type != PolicyMessageType {
+				tsp.logger.Debug("Ignoring custom message", zap.String("type", msg.Type))
+				continue
+			}
+			if err := tsp.reloadPolicies(msg.Data, "OpAMP"); err != nil {
+				if errors.Is(err, errPolicySourceStopped) {
+					return
+				}
+				tsp.logger.Error("Failed to reload the sampling policies, keeping the current policies", zap.Error(err))
+			}
+		}
+	}
+}
+
+// shutdownPolicySources stops watching the policy file and unregisters the custom capability.
+func (tsp *tailSamplingSpanProcessor) shutdownPolicySources() {
+	if tsp.policySourceDone == nil {
+		return
+	}
+	close(tsp.policySourceDone)
+	if tsp.policyHandler != nil {
+		tsp.policyHandler.Unregister()
+	}
+	tsp.policySourceWG.Wait()
+}
@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampcustommessages"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
+)
+
+const (
+	onlyMetricsPolicies = `
+policies:
+  - name: only-metrics
+    type: string_attribute
+    string_attribute: {key: url.path, values: [/metrics]}
+`
+	onlyHealthPolicies = `
+policies:
+  - name: only-health
+    type: string_attribute
+    string_attribute: {key: url.path, values: [/health]}
+`
+)
+
+type fakeCustomCapabilityRegistry struct {
+	component.StartFunc
+	component.ShutdownFunc
+	handler *fakeCustomCapabilityHandler
+}
+
+func (r *fakeCustomCapabilityRegistry) Register(string, ...opampcustommessages.CustomCapabilityRegisterOption) (opampcustommessages.CustomCapabilityHandler, error) {
+	return r.handler, nil
+}
+
+type fakeCustomCapabilityHandler struct {
+	messages     chan *protobufs.CustomMessage
+	unregistered bool
+}
+
+func (h *fakeCustomCapabilityHandler) Message() <-chan *protobufs.CustomMessage {
+	return h.messages
+}
+
+func (*fakeCustomCapabilityHandler) SendMessage(string, []byte) (chan struct{}, error) {
+	return nil, nil
+}
+
+func (h *fakeCustomCapabilityHandler) Unregister() {
+	h.unregistered = true
+}
+
+func TestParsePolicies(t *testing.T) {
+	cfgs, err := parsePolicies([]byte(onlyMetricsPolicies))
+	require.NoError(t, err)
+	require.Len(t, cfgs, 1)
+	assert.Equal(t, "only-metrics", cfgs[0].Name)
+	assert.Equal(t, StringAttributeCfg{Key: "url.path", Values: []string{"/metrics"}}, cfgs[0].StringAttributeCfg)
+
+	_, err = parsePolicies([]byte("policies: []"))
+	require.ErrorContains(t, err, "no policies found")
+
+	_, err = parsePolicies([]byte("policies: [{name: a, unknown: b}]"))
+	require.Error(t, err)
+}
+
+// assertSampledPaths sends a trace for the /metrics and /health paths, and checks which one was sampled
+func assertSampledPaths(t *testing.T, p *tailSamplingSpanProcessor, controller *testTSPController, msp *consumertest.TracesSink, firstID uint64, expectedPath string) {
+	msp.Reset()
+	for i, path := range []string{"/metrics", "/health"} {
+		td := simpleTracesWithID(uInt64ToTraceID(firstID + uint64(i)))
+		td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutStr("url.path", path)
+		require.NoError(t, p.ConsumeTraces(t.Context(), td))
+	}
+	controller.waitForTick()
+	controller.waitForTick()
+
+	require.Len(t, msp.AllTraces(), 1)
+	path, _ := msp.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Get("url.path")
+	assert.Equal(t, expectedPath, path.Str())
+}
+
+func newPolicySourceTestProcessor(t *testing.T, cfg Config, host component.Host) (*tailSamplingSpanProcessor, *testTSPController, *consumertest.TracesSink, *observer.ObservedLogs) {
+	controller := newTestTSPController()
+	msp := new(consumertest.TracesSink)
+	zc, logs := observer.New(zap.InfoLevel)
+	set := processortest.NewNopSettings(metadata.Type)
+	set.Logger = zap.New(zc)
+
+	cfg.DecisionWait = defaultTestDecisionWait
+	cfg.NumTraces = defaultNumTraces
+	cfg.Options = []Option{withTestController(controller)}
+	p, err := newTracesProcessor(t.Context(), set, msp, cfg)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(t.Context(), host))
+	t.Cleanup(func() {
+		require.NoError(t, p.Shutdown(t.Context()))
+	})
+	return p.(*tailSamplingSpanProcessor), controller, msp, logs
+}
+
+func TestPolicyFileReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policies.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(onlyMetricsPolicies), 0o600))
+
+	cfg := Config{PolicySource: PolicySourceConfig{File: path, PollInterval: 10 * time.Millisecond}}
+	p, controller, msp, logs := newPolicySourceTestProcessor(t, cfg, storagetest.NewStorageHost())
+
+	// The policies of the file are used from the start
+	assertSampledPaths(t, p, controller, msp, 1, "/metrics")
+
+	require.NoError(t, os.WriteFile(path, []byte(onlyHealthPolicies), 0o600))
+	require.Eventually(t, func() bool {
+		return logs.FilterMessage("Sampling policies reloaded").Len() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	controller.waitForTick()
+
+	assertSampledPaths(t, p, controller, msp, 3, "/health")
+}
+
+func TestPolicyFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policies.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("policies: []"), 0o600))
+
+	p, err := newTracesProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), consumertest.NewNop(), Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    defaultNumTraces,
+		PolicySource: PolicySourceConfig{File: path, PollInterval: time.Second},
+	})
+	require.NoError(t, err)
+	require.ErrorContains(t, p.Start(t.Context(), storagetest.NewStorageHost()), "no policies found")
+}
+
+func TestPolicyOpAMPReload(t *testing.T) {
+	handler := &fakeCustomCapabilityHandler{messages: make(chan *protobufs.CustomMessage)}
+	// Runs after the shutdown of the processor
+	t.Cleanup(func() {
+		assert.True(t, handler.unregistered)
+	})
+	opampID := component.MustNewID("opamp")
+	host := storagetest.NewStorageHost().WithExtension(opampID, &fakeCustomCapabilityRegistry{handler: handler})
+
+	cfg := Config{
+		PolicyCfgs: []PolicyCfg{{sharedPolicyCfg: sharedPolicyCfg{
+			Name:               "only-metrics",
+			Type:               StringAttribute,
+			StringAttributeCfg: StringAttributeCfg{Key: "url.path", Values: []string{"/metrics"}},
+		}}},
+		PolicySource: PolicySourceConfig{OpAMP: &opampID},
+	}
+	p, controller, msp, logs := newPolicySourceTestProcessor(t, cfg, host)
+	assertSampledPaths(t, p, controller, msp, 1, "/metrics")
+
+	// Messages of other types and invalid policies are ignored
+	handler.messages <- &protobufs.CustomMessage{Capability: PolicyCustomCapability, Type: "other", Data: []byte(onlyHealthPolicies)}
+	handler.messages <- &protobufs.CustomMessage{Capability: PolicyCustomCapability, Type: PolicyMessageType, Data: []byte("policies: [{type: unknown}]")}
+	handler.messages <- &protobufs.CustomMessage{Capability: PolicyCustomCapability, Type: PolicyMessageType, Data: []byte(onlyHealthPolicies)}
+	require.Eventually(t, func() bool {
+		return logs.FilterMessage("Sampling policies reloaded").Len() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, logs.FilterMessage("Failed to reload the sampling policies, keeping the current policies").Len())
+	controller.waitForTick()
+
+	assertSampledPaths(t, p, controller, msp, 3, "/health")
+}
@@ -11,10 +11,12 @@ import (
 	"math"
 	"runtime"
 	"slices"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
@@ -22,6 +24,7 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampcustommessages"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
@@ -56,10 +59,27 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher    idbatcher.Batcher
 	sampledIDCache     cache.Cache[bool]
 	nonSampledIDCache  cache.Cache[bool]
+	decisionStorage    storage.Client
+	spillStorage       storage.Client
 	recordPolicy       bool
 	sampleOnFirstMatch bool
 	blockOnOverflow    bool
 
+	// storageCaches are the decision caches backed by the decision storage, and decisionSweepDone stops
+	// the goroutine deleting their expired decisions.
+	storageCaches     []*cache.StorageDecisionCache
+	decisionSweepDone chan struct{}
+	decisionSweepWG   sync.WaitGroup
+
+	// spilledTraces holds the IDs of the pending traces whose spans were moved to the spill storage.
+	spilledTraces map[pcommon.TraceID]struct{}
+
+	// policyHandler receives the policies sent through OpAMP, and policySourceDone stops the
+	// goroutines reloading the policies.
+	policyHandler    opampcustommessages.CustomCapabilityHandler
+	policySourceDone chan struct{}
+	policySourceWG   sync.WaitGroup
+
 	cfg  Config
 	host component.Host
 
@@ -104,6 +124,7 @@ func newTracesProcessor(ctx context.Context, set processor.Settings, nextConsume
 		nonSampledIDCache:  nonSampledDecisions,
 		logger:             set.Logger,
 		idToTrace:          make(map[pcommon.TraceID]*samplingpolicy.TraceData),
+		spilledTraces:      make(map[pcommon.TraceID]struct{}),
 		deleteTraceQueue:   list.New(),
 		sampleOnFirstMatch: cfg.SampleOnFirstMatch,
 		blockOnOverflow:    cfg.BlockOnOverflow,
@@ -130,8 +151,21 @@ func (*tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(_ context.Context, host component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
 	tsp.host = host
+	if tsp.cfg.DecisionCache.StorageID != nil {
+		if err := tsp.startDecisionStorage(ctx, host, *tsp.cfg.DecisionCache.StorageID); err != nil {
+			return err
+		}
+	}
+	if tsp.cfg.SpillStorageID != nil {
+		client, err := getStorageClient(ctx, host, *tsp.cfg.SpillStorageID, tsp.set.ID, "spill")
+		if err != nil {
+			return err
+		}
+		tsp.spillStorage = client
+	}
+
 	policies, err := tsp.loadSamplingPolicies(host, tsp.cfg.PolicyCfgs)
 	if err != nil {
 		return err
@@ -143,6 +177,10 @@ func (tsp *tailSamplingSpanProcessor) Start(_ context.Context, host component.Ho
 		tsp.policies = policies
 	}
 
+	if err := tsp.startPolicySources(host); err != nil {
+		return err
+	}
+
 	if tsp.decisionBatcher == nil {
 		// this will start a goroutine in the background, so we run it only if everything went
 		// well in creating the policies, and only when the processor starts.
@@ -159,6 +197,59 @@ func (tsp *tailSamplingSpanProcessor) Start(_ context.Context, host component.Ho
 	return nil
 }
 
+// startDecisionStorage obtains a client from the storage extension holding the sampling decisions,
+// and puts it behind the decision caches.
+func (tsp *tailSamplingSpanProcessor) startDecisionStorage(ctx context.Context, host component.Host, storageID component.ID) error {
+	client, err := getStorageClient(ctx, host, storageID, tsp.set.ID, "decisions")
+	if err != nil {
+		return err
+	}
+	tsp.decisionStorage = client
+
+	onError := func(err error) {
+		tsp.logger.Warn("Error accessing the decision storage", zap.Error(err))
+	}
+	ttl := tsp.cfg.DecisionCache.StorageTTL
+	sampled := cache.NewStorageDecisionCache(client, "sampled/", ttl, tsp.sampledIDCache, onError)
+	nonSampled := cache.NewStorageDecisionCache(client, "not_sampled/", ttl, tsp.nonSampledIDCache, onError)
+	tsp.sampledIDCache = sampled
+	tsp.nonSampledIDCache = nonSampled
+	tsp.storageCaches = []*cache.StorageDecisionCache{sampled, nonSampled}
+
+	tsp.decisionSweepDone = make(chan struct{})
+	tsp.decisionSweepWG.Add(1)
+	go tsp.sweepDecisionStorage()
+	return nil
+}
+
+// sweepDecisionStorage periodically deletes the expired decisions from the storage.
+func (tsp *tailSamplingSpanProcessor) sweepDecisionStorage() {
+	defer tsp.decisionSweepWG.Done()
+	ticker := time.NewTicker(cache.StorageSweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-tsp.decisionSweepDone:
+			return
+		case <-ticker.C:
+			for _, c := range tsp.storageCaches {
+				c.Sweep(tsp.ctx)
+			}
+		}
+	}
+}
+
+// shutdownDecisionStorage stops the sweeps, records the expiry of the decisions written since the last sweep,
+// and closes the storage client.
+func (tsp *tailSamplingSpanProcessor) shutdownDecisionStorage(ctx context.Context) error {
+	close(tsp.decisionSweepDone)
+	tsp.decisionSweepWG.Wait()
+	for _, c := range tsp.storageCaches {
+		c.Sweep(ctx)
+	}
+	return tsp.decisionStorage.Close(ctx)
+}
+
 // ConsumeTraces is required by the processor.Traces interface.
 func (tsp *tailSamplingSpanProcessor) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
 	for _, rss := range td.ResourceSpans().All() {
@@ -450,7 +541,7 @@ func (tsp *tailSamplingSpanProcessor) iter(tickChan <-chan time.Time, workChan <
 			}
 
 			_, ok = tsp.idToTrace[trace.id]
-			if !ok && uint64(len(tsp.idToTrace)) >= tsp.cfg.NumTraces {
+			if !ok && tsp.tracesInMemory() >= tsp.cfg.NumTraces {
 				tsp.waitForSpace(tickChan)
 			}
 
@@ -501,7 +592,7 @@ func (tsp *tailSamplingSpanProcessor) waitForSpace(tickChan <-chan time.Time) {
 	if tsp.blockOnOverflow {
 		// Ticks are not guaranteed to drop data, since they may process an
 		// empty batch. We loop until we have space for a new trace.
-		for uint64(len(tsp.idToTrace)) >= tsp.cfg.NumTraces {
+		for tsp.tracesInMemory() >= tsp.cfg.NumTraces {
 			// Recursively iter with a nil workChan to wait for space.
 			tsp.iter(tickChan, nil)
 		}
@@ -514,8 +605,18 @@ func (tsp *tailSamplingSpanProcessor) waitForSpace(tickChan <-chan time.Time) {
 		tsp.logger.Error("deleteTraceQueue is empty, but we're waiting for space. This is a bug!")
 		return
 	}
-	tsp.dropTrace(front.Value.(pcommon.TraceID), time.Now())
+	id := front.Value.(pcommon.TraceID)
 	tsp.deleteTraceQueue.Remove(front)
+
+	// When spilling, the oldest pending trace is moved to the storage instead of being evicted.
+	if trace, ok := tsp.idToTrace[id]; ok && tsp.spillStorage != nil && trace.FinalDecision == samplingpolicy.Unspecified {
+		err := tsp.spillTrace(id, trace)
+		if err == nil {
+			return
+		}
+		tsp.logger.Warn("Error spilling a trace, evicting it instead", zap.Stringer("id", id), zap.Error(err))
+	}
+	tsp.dropTrace(id, time.Now())
 }
 
 // samplingPolicyOnTick takes the next batch and process all traces in that batch. Returns if there are more batches in the batcher.
@@ -537,6 +638,7 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() bool {
 			continue
 		}
 		trace.DecisionTime = time.Now()
+		rehydrated := tsp.spillStorage != nil && tsp.rehydrateTrace(id, trace)
 
 		decision := tsp.makeDecision(id, trace, metrics)
 		globalTracesSampledByDecision[decision]++
@@ -551,10 +653,15 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() bool {
 		} else {
 			tsp.releaseNotSampledTrace(id)
 		}
+
+		// A spilled trace left the delete queue, it's queued again if the decision is kept in memory.
+		if _, ok := tsp.idToTrace[id]; ok && rehydrated && !tsp.blockOnOverflow {
+			tsp.deleteTraceQueue.PushBack(id)
+		}
 	}
 
 	tsp.telemetry.ProcessorTailSamplingSamplingDecisionTimerLatency.Record(tsp.ctx, time.Since(startTime).Milliseconds())
-	tsp.telemetry.ProcessorTailSamplingSamplingTracesOnMemory.Record(tsp.ctx, int64(len(tsp.idToTrace)))
+	tsp.telemetry.ProcessorTailSamplingSamplingTracesOnMemory.Record(tsp.ctx, int64(tsp.tracesInMemory()))
 	tsp.telemetry.ProcessorTailSamplingSamplingTraceDroppedTooEarly.Add(tsp.ctx, metrics.idNotFoundOnMapCount)
 	tsp.telemetry.ProcessorTailSamplingSamplingPolicyEvaluationError.Add(tsp.ctx, metrics.evaluateErrorCount)
 
@@ -750,13 +857,24 @@ func extensions(host component.Host) map[string]samplingpolicy.Extension {
 }
 
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
+	// The policies are no longer reloaded once the processing loop is stopped.
+	tsp.shutdownPolicySources()
+
 	// All receivers will be shutdown before processors so no sends will be done anymore.
 	close(tsp.workChan)
 	if tsp.doneChan != nil {
 		<-tsp.doneChan
 	}
-	return nil
+	var errs []error
+	if tsp.spillStorage != nil {
+		errs = append(errs, tsp.shutdownSpillStorage(ctx))
+	}
+	// The decisions taken for the pending traces while shutting down are stored before closing the client.
+	if tsp.decisionStorage != nil {
+		errs = append(errs, tsp.shutdownDecisionStorage(ctx))
+	}
+	return errors.Join(errs...)
 }
 
 func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletionTime time.Time) {
@@ -767,6 +885,7 @@ func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletio
 	}
 
 	delete(tsp.idToTrace, traceID)
+	delete(tsp.spilledTraces, traceID)
 	tsp.telemetry.ProcessorTailSamplingSamplingTraceRemovalAge.Record(tsp.ctx, int64(deletionTime.Sub(trace.ArrivalTime)/time.Second))
 }
 
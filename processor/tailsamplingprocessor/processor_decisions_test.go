@@ -5,6 +5,7 @@ package tailsamplingprocessor
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -17,6 +18,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/pkg/samplingpolicy"
@@ -640,6 +642,80 @@ func TestLateSpanUsesNonSampledDecisionCache(t *testing.T) {
 	require.Equal(t, 0, nextConsumer.SpanCount(), "original final decision not honored")
 }
 
+func TestDecisionStorageSurvivesRestart(t *testing.T) {
+	nextConsumer := new(consumertest.TracesSink)
+	controller := newTestTSPController()
+
+	mpe := &mockPolicyEvaluator{}
+	policies := []*policy{
+		{name: "mock-policy-1", evaluator: mpe, attribute: metric.WithAttributes(attribute.String("policy", "mock-policy-1"))},
+	}
+
+	storageID := storagetest.NewStorageID("decisions")
+	host := storagetest.NewStorageHost().
+		WithExtension(storageID, storagetest.NewFileBackedStorageExtension("decisions", t.TempDir()))
+
+	// The in-memory caches are left disabled, so that the decisions can only come from the storage
+	cfg := Config{
+		DecisionWait:  defaultTestDecisionWait * 10,
+		NumTraces:     defaultNumTraces,
+		DecisionCache: DecisionCacheConfig{StorageID: &storageID, StorageTTL: time.Hour},
+		Options: []Option{
+			withTestController(controller),
+			withPolicies(policies),
+		},
+	}
+	p, err := newTracesProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), nextConsumer, cfg)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(t.Context(), host))
+
+	sampledID := uInt64ToTraceID(1)
+	notSampledID := uInt64ToTraceID(2)
+	mpe.NextDecision = samplingpolicy.Sampled
+	require.NoError(t, p.ConsumeTraces(t.Context(), spanToTraces(sampledID, 1)))
+	controller.waitForTick()
+	controller.waitForTick()
+	require.Equal(t, 1, mpe.EvaluationCount)
+	require.Equal(t, 1, nextConsumer.SpanCount())
+
+	mpe.NextDecision = samplingpolicy.NotSampled
+	require.NoError(t, p.ConsumeTraces(t.Context(), spanToTraces(notSampledID, 1)))
+	controller.waitForTick()
+	controller.waitForTick()
+	require.Equal(t, 2, mpe.EvaluationCount)
+	require.Equal(t, 1, nextConsumer.SpanCount())
+
+	require.NoError(t, p.Shutdown(t.Context()))
+
+	// A restarted processor honors the decisions read back from the storage
+	p, err = newTracesProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), nextConsumer, cfg)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(t.Context(), host))
+	defer func(p processor.Traces) {
+		require.NoError(t, p.Shutdown(t.Context()))
+	}(p)
+
+	mpe.NextDecision = samplingpolicy.Dropped
+	require.NoError(t, p.ConsumeTraces(t.Context(), spanToTraces(sampledID, 2)))
+	require.NoError(t, p.ConsumeTraces(t.Context(), spanToTraces(notSampledID, 2)))
+	controller.waitForTick()
+
+	require.Equal(t, 2, mpe.EvaluationCount)
+	require.Equal(t, 2, nextConsumer.SpanCount(), "stored decisions not honored")
+}
+
+func TestDecisionStorageNotFound(t *testing.T) {
+	storageID := storagetest.NewStorageID("missing")
+	cfg := Config{
+		DecisionWait:  defaultTestDecisionWait,
+		NumTraces:     defaultNumTraces,
+		DecisionCache: DecisionCacheConfig{StorageID: &storageID, StorageTTL: time.Hour},
+	}
+	p, err := newTracesProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	require.ErrorContains(t, p.Start(t.Context(), componenttest.NewNopHost()), "storage extension 'test_storage/missing' not found")
+}
+
 func TestSampleOnFirstMatch(t *testing.T) {
 	nextConsumer := new(consumertest.TracesSink)
 	controller := newTestTSPController()
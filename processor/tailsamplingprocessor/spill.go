@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/pkg/samplingpolicy"
+)
+
+// getStorageClient obtains a client named name from the storage extension with the given ID.
+func getStorageClient(ctx context.Context, host component.Host, storageID, componentID component.ID, name string) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	client, err := storageExt.GetClient(ctx, component.KindProcessor, componentID, name)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't obtain a storage client from '%s': %w", storageID, err)
+	}
+	return client, nil
+}
+
+// tracesInMemory returns the number of traces whose spans are held in memory, which excludes
+// the spilled traces.
+func (tsp *tailSamplingSpanProcessor) tracesInMemory() uint64 {
+	return uint64(len(tsp.idToTrace) - len(tsp.spilledTraces))
+}
+
+// spillTrace moves the spans received so far for a pending trace to the spill storage. The trace
+// stays in idToTrace, so that its decision still fires after decision_wait, and the spans
+// received after the spill are kept in memory until then.
+func (tsp *tailSamplingSpanProcessor) spillTrace(id pcommon.TraceID, trace *samplingpolicy.TraceData) error {
+	var marshaler ptrace.ProtoMarshaler
+	data, err := marshaler.MarshalTraces(trace.ReceivedBatches)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal trace %q: %w", id, err)
+	}
+	if err := tsp.spillStorage.Set(tsp.ctx, id.String(), data); err != nil {
+		return err
+	}
+
+	trace.ReceivedBatches = ptrace.NewTraces()
+	tsp.spilledTraces[id] = struct{}{}
+	tsp.telemetry.ProcessorTailSamplingSamplingTracesSpilled.Add(tsp.ctx, 1)
+	return nil
+}
+
+// rehydrateTrace reads back the spans of a spilled trace from the storage, placing them before the
+// spans received after the spill. The spans are removed from the storage. It returns false if the
+// trace wasn't spilled.
+func (tsp *tailSamplingSpanProcessor) rehydrateTrace(id pcommon.TraceID, trace *samplingpolicy.TraceData) bool {
+	if _, ok := tsp.spilledTraces[id]; !ok {
+		return false
+	}
+	delete(tsp.spilledTraces, id)
+
+	get := storage.GetOperation(id.String())
+	if err := tsp.spillStorage.Batch(tsp.ctx, get, storage.DeleteOperation(id.String())); err != nil {
+		tsp.logger.Warn("Error reading a spilled trace, the decision is made on the spans in memory",
+			zap.Stringer("id", id), zap.Error(err))
+		return true
+	}
+	if get.Value == nil {
+		// the spans are gone from the storage, there's nothing we can do about it
+		return true
+	}
+
+	var unmarshaler ptrace.ProtoUnmarshaler
+	td, err := unmarshaler.UnmarshalTraces(get.Value)
+	if err != nil {
+		tsp.logger.Warn("Error unmarshaling a spilled trace, the decision is made on the spans in memory",
+			zap.Stringer("id", id), zap.Error(err))
+		return true
+	}
+	trace.ReceivedBatches.ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+	trace.ReceivedBatches = td
+	return true
+}
+
+// shutdownSpillStorage removes the spans of the traces that are still spilled, as the in-memory
+// index pointing to them is not persisted, and closes the storage client.
+func (tsp *tailSamplingSpanProcessor) shutdownSpillStorage(ctx context.Context) error {
+	ops := make([]*storage.Operation, 0, len(tsp.spilledTraces))
+	for id := range tsp.spilledTraces {
+		ops = append(ops, storage.DeleteOperation(id.String()))
+	}
+	clear(tsp.spilledTraces)
+
+	var errs []error
+	if len(ops) > 0 {
+		errs = append(errs, tsp.spillStorage.Batch(ctx, ops...))
+	}
+	errs = append(errs, tsp.spillStorage.Close(ctx))
+	return errors.Join(errs...)
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/pkg/samplingpolicy"
+)
+
+func newSpillTestProcessor(t *testing.T, nextConsumer *consumertest.TracesSink, mpe *mockPolicyEvaluator, controller *testTSPController, host component.Host, storageID component.ID) *tailSamplingSpanProcessor {
+	cfg := Config{
+		DecisionWait:   defaultTestDecisionWait,
+		NumTraces:      1,
+		SpillStorageID: &storageID,
+		Options: []Option{
+			withTestController(controller),
+			withPolicies([]*policy{
+				{name: "mock-policy-1", evaluator: mpe, attribute: metric.WithAttributes(attribute.String("policy", "mock-policy-1"))},
+			}),
+		},
+	}
+	p, err := newTracesProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), nextConsumer, cfg)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(t.Context(), host))
+	t.Cleanup(func() {
+		require.NoError(t, p.Shutdown(t.Context()))
+	})
+	return p.(*tailSamplingSpanProcessor)
+}
+
+func spanToTraces(traceID pcommon.TraceID, spanIndex uint64) ptrace.Traces {
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID(traceID)
+	span.SetSpanID(uInt64ToSpanID(spanIndex))
+	return traces
+}
+
+func TestSpillPendingTraces(t *testing.T) {
+	nextConsumer := new(consumertest.TracesSink)
+	controller := newTestTSPController()
+	mpe := &mockPolicyEvaluator{NextDecision: samplingpolicy.Sampled}
+
+	storageID := storagetest.NewStorageID("spill")
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("spill")
+	p := newSpillTestProcessor(t, nextConsumer, mpe, controller, host, storageID)
+
+	first := uInt64ToTraceID(1)
+	second := uInt64ToTraceID(2)
+
+	// The second trace exceeds num_traces, so the first one is spilled instead of being evicted
+	require.NoError(t, p.ConsumeTraces(t.Context(), spanToTraces(first, 1)))
+	require.NoError(t, p.ConsumeTraces(t.Context(), spanToTraces(second, 1)))
+	// A span arriving after the spill is kept in memory until the decision
+	require.NoError(t, p.ConsumeTraces(t.Context(), spanToTraces(first, 2)))
+
+	controller.waitForTick()
+	controller.waitForTick()
+
+	require.Equal(t, 2, mpe.EvaluationCount)
+	require.Equal(t, 3, nextConsumer.SpanCount(), "spilled spans were not released")
+
+	spanIDs := map[pcommon.TraceID][]pcommon.SpanID{}
+	for _, td := range nextConsumer.AllTraces() {
+		for _, rs := range td.ResourceSpans().All() {
+			for _, ss := range rs.ScopeSpans().All() {
+				for _, span := range ss.Spans().All() {
+					spanIDs[span.TraceID()] = append(spanIDs[span.TraceID()], span.SpanID())
+				}
+			}
+		}
+	}
+	assert.Equal(t, []pcommon.SpanID{uInt64ToSpanID(1), uInt64ToSpanID(2)}, spanIDs[first])
+	assert.Equal(t, []pcommon.SpanID{uInt64ToSpanID(1)}, spanIDs[second])
+	assert.Empty(t, p.spilledTraces)
+}
+
+func TestSpillStorageNotFound(t *testing.T) {
+	storageID := storagetest.NewNonStorageID("spill")
+	host := storagetest.NewStorageHost().WithNonStorageExtension("spill")
+	cfg := Config{
+		DecisionWait:   defaultTestDecisionWait,
+		NumTraces:      defaultNumTraces,
+		SpillStorageID: &storageID,
+	}
+	p, err := newTracesProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	require.ErrorContains(t, p.Start(t.Context(), host), "non-storage extension")
+}
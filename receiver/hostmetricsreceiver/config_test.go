@@ -16,6 +16,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cpuscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/diskscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/filesystemscraper"
@@ -24,8 +25,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/networkscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/nfsscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pagingscraper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processesscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/sensorsscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemscraper"
 )
 
@@ -61,6 +64,14 @@ func TestLoadConfig(t *testing.T) {
 					InitialDelay:       time.Second,
 				},
 				Scrapers: map[component.Type]component.Config{
+					component.MustNewType("cgroup"): (func() component.Config {
+						cfg := cgroupscraper.NewFactory().CreateDefaultConfig()
+						cfg.(*cgroupscraper.Config).Include = cgroupscraper.MatchConfig{
+							Paths:  []string{"/kubepods.slice/.*"},
+							Config: filterset.Config{MatchType: "regexp"},
+						}
+						return cfg
+					})(),
 					component.MustNewType("cpu"):  cpuscraper.NewFactory().CreateDefaultConfig(),
 					component.MustNewType("disk"): diskscraper.NewFactory().CreateDefaultConfig(),
 					component.MustNewType("load"): (func() component.Config {
@@ -81,6 +92,7 @@ func TestLoadConfig(t *testing.T) {
 					component.MustNewType("nfs"):       nfsscraper.NewFactory().CreateDefaultConfig(),
 					component.MustNewType("processes"): processesscraper.NewFactory().CreateDefaultConfig(),
 					component.MustNewType("paging"):    pagingscraper.NewFactory().CreateDefaultConfig(),
+					component.MustNewType("pressure"):  pressurescraper.NewFactory().CreateDefaultConfig(),
 					component.MustNewType("process"): (func() component.Config {
 						cfg := processscraper.NewFactory().CreateDefaultConfig()
 						cfg.(*processscraper.Config).Include = processscraper.MatchConfig{
@@ -89,7 +101,8 @@ func TestLoadConfig(t *testing.T) {
 						}
 						return cfg
 					})(),
-					component.MustNewType("system"): systemscraper.NewFactory().CreateDefaultConfig(),
+					component.MustNewType("sensors"): sensorsscraper.NewFactory().CreateDefaultConfig(),
+					component.MustNewType("system"):  systemscraper.NewFactory().CreateDefaultConfig(),
 				},
 			},
 		},
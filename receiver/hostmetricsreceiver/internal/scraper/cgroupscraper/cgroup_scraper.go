@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cgroupscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper/internal/metadata"
+)
+
+// 2 cpu metrics + 3 memory metrics + 2 io metrics + 1 pids metric = 8 metrics
+const cgroupMetricsLen = 8
+
+// cgroupStats are the resource usage statistics of a cgroup. The statistics of the controllers that are not
+// enabled for the cgroup are nil.
+type cgroupStats struct {
+	path   string
+	cpu    *cpuStats
+	memory *memoryStats
+	io     []ioStats
+	pids   *pidsStats
+}
+
+// cpuStats are read from cpu.stat, in microseconds.
+type cpuStats struct {
+	userUsec      uint64
+	systemUsec    uint64
+	throttledUsec uint64
+	// hasThrottled is false when the cpu controller is not enabled, cpu.stat then only reports the usage
+	hasThrottled bool
+}
+
+// memoryStats are read from memory.current, memory.max and memory.events.
+type memoryStats struct {
+	current uint64
+	// max is nil when the memory of the cgroup is not limited
+	max    *uint64
+	events map[metadata.AttributeType]uint64
+}
+
+// ioStats are read from a line of io.stat, for a block device.
+type ioStats struct {
+	device string
+	rbytes uint64
+	wbytes uint64
+	rios   uint64
+	wios   uint64
+}
+
+// pidsStats are read from pids.current.
+type pidsStats struct {
+	current uint64
+}
+
+// cgroupScraper for Cgroup Metrics
+type cgroupScraper struct {
+	settings  scraper.Settings
+	config    *Config
+	mb        *metadata.MetricsBuilder
+	includeFS filterset.FilterSet
+	excludeFS filterset.FilterSet
+
+	// getCgroupStats returns the statistics of the cgroups whose path is included, and an error for each statistic
+	// that could not be read
+	getCgroupStats func(ctx context.Context, include func(path string) bool) ([]*cgroupStats, error)
+}
+
+// newCgroupScraper creates a metric scraper for Cgroup metrics
+func newCgroupScraper(settings scraper.Settings, cfg *Config) (*cgroupScraper, error) {
+	scraper := &cgroupScraper{
+		settings:       settings,
+		config:         cfg,
+		getCgroupStats: getOSCgroupStats,
+	}
+
+	var err error
+
+	if len(cfg.Include.Paths) > 0 {
+		scraper.includeFS, err = filterset.CreateFilterSet(cfg.Include.Paths, &cfg.Include.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cgroup path include filters: %w", err)
+		}
+	}
+
+	if len(cfg.Exclude.Paths) > 0 {
+		scraper.excludeFS, err = filterset.CreateFilterSet(cfg.Exclude.Paths, &cfg.Exclude.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cgroup path exclude filters: %w", err)
+		}
+	}
+
+	return scraper, nil
+}
+
+func (s *cgroupScraper) start(context.Context, component.Host) error {
+	s.mb = metadata.NewMetricsBuilder(s.config.MetricsBuilderConfig, s.settings)
+	return nil
+}
+
+func (s *cgroupScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	var errs scrapererror.ScrapeErrors
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	cgroups, err := s.getCgroupStats(ctx, s.includePath)
+	if err != nil {
+		if len(cgroups) == 0 {
+			errs.AddPartial(cgroupMetricsLen, err)
+		} else {
+			for _, readErr := range multierr.Errors(err) {
+				errs.AddPartial(1, readErr)
+			}
+		}
+	}
+
+	for _, cgroup := range cgroups {
+		s.recordCPUMetrics(now, cgroup.cpu)
+		s.recordMemoryMetrics(now, cgroup.memory)
+		s.recordIOMetrics(now, cgroup.io)
+		s.recordPidsMetrics(now, cgroup.pids)
+
+		rb := s.mb.NewResourceBuilder()
+		rb.SetCgroupPath(cgroup.path)
+		s.mb.EmitForResource(metadata.WithResource(rb.Emit()))
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+func (s *cgroupScraper) includePath(path string) bool {
+	return (s.includeFS == nil || s.includeFS.Matches(path)) &&
+		(s.excludeFS == nil || !s.excludeFS.Matches(path))
+}
+
+func (s *cgroupScraper) recordCPUMetrics(now pcommon.Timestamp, stats *cpuStats) {
+	if stats == nil {
+		return
+	}
+
+	s.mb.RecordCgroupCPUTimeDataPoint(now, float64(stats.userUsec)/1e6, metadata.AttributeStateUser)
+	s.mb.RecordCgroupCPUTimeDataPoint(now, float64(stats.systemUsec)/1e6, metadata.AttributeStateSystem)
+	if stats.hasThrottled {
+		s.mb.RecordCgroupCPUThrottledTimeDataPoint(now, float64(stats.throttledUsec)/1e6)
+	}
+}
+
+func (s *cgroupScraper) recordMemoryMetrics(now pcommon.Timestamp, stats *memoryStats) {
+	if stats == nil {
+		return
+	}
+
+	s.mb.RecordCgroupMemoryUsageDataPoint(now, int64(stats.current))
+	if stats.max != nil {
+		s.mb.RecordCgroupMemoryLimitDataPoint(now, int64(*stats.max))
+	}
+	for eventType, count := range stats.events {
+		s.mb.RecordCgroupMemoryEventsDataPoint(now, int64(count), eventType)
+	}
+}
+
+func (s *cgroupScraper) recordIOMetrics(now pcommon.Timestamp, stats []ioStats) {
+	for _, device := range stats {
+		s.mb.RecordCgroupIoBytesDataPoint(now, int64(device.rbytes), device.device, metadata.AttributeDirectionRead)
+		s.mb.RecordCgroupIoBytesDataPoint(now, int64(device.wbytes), device.device, metadata.AttributeDirectionWrite)
+		s.mb.RecordCgroupIoOperationsDataPoint(now, int64(device.rios), device.device, metadata.AttributeDirectionRead)
+		s.mb.RecordCgroupIoOperationsDataPoint(now, int64(device.wios), device.device, metadata.AttributeDirectionWrite)
+	}
+}
+
+func (s *cgroupScraper) recordPidsMetrics(now pcommon.Timestamp, stats *pidsStats) {
+	if stats == nil {
+		return
+	}
+
+	s.mb.RecordCgroupPidsCountDataPoint(now, int64(stats.current))
+}
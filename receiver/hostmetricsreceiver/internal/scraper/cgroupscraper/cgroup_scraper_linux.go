@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package cgroupscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v4/common"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/gopsutilenv"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper/internal/metadata"
+)
+
+// getOSCgroupStats walks the cgroup v2 hierarchy mounted at /sys/fs/cgroup, and reads the statistics of the
+// included cgroups. The cgroups removed while walking the hierarchy are skipped.
+func getOSCgroupStats(ctx context.Context, include func(path string) bool) ([]*cgroupStats, error) {
+	root := gopsutilenv.GetEnvWithContext(ctx, string(common.HostSysEnvKey), "/sys", "fs", "cgroup")
+	if _, err := os.Stat(filepath.Join(root, "cgroup.controllers")); err != nil {
+		return nil, fmt.Errorf("cgroup v2 is not mounted at %s: %w", root, err)
+	}
+
+	var cgroups []*cgroupStats
+	var errs error
+	err := filepath.WalkDir(root, func(dir string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if dir == root {
+				return err
+			}
+			if !errors.Is(err, fs.ErrNotExist) {
+				errs = multierr.Append(errs, err)
+			}
+			return nil
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+
+		path := "/" + strings.TrimPrefix(filepath.ToSlash(strings.TrimPrefix(dir, root)), "/")
+		if !include(path) {
+			return nil
+		}
+		stats, err := readCgroupStats(dir, path)
+		cgroups = append(cgroups, stats)
+		errs = multierr.Append(errs, err)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cgroups, errs
+}
+
+// readCgroupStats reads the statistics of the cgroup. The statistics of the controllers that are not enabled
+// for the cgroup, whose files are missing, are left nil.
+func readCgroupStats(dir, path string) (*cgroupStats, error) {
+	stats := &cgroupStats{path: path}
+	var errs, err error
+
+	stats.cpu, err = readCPUStats(dir)
+	errs = multierr.Append(errs, err)
+	stats.memory, err = readMemoryStats(dir)
+	errs = multierr.Append(errs, err)
+	stats.io, err = readIOStats(dir)
+	errs = multierr.Append(errs, err)
+	stats.pids, err = readPidsStats(dir)
+	errs = multierr.Append(errs, err)
+
+	return stats, errs
+}
+
+func readCPUStats(dir string) (*cpuStats, error) {
+	values, err := readKeyValues(filepath.Join(dir, "cpu.stat"))
+	if err != nil || values == nil {
+		return nil, err
+	}
+
+	stats := &cpuStats{
+		userUsec:   values["user_usec"],
+		systemUsec: values["system_usec"],
+	}
+	stats.throttledUsec, stats.hasThrottled = values["throttled_usec"]
+	return stats, nil
+}
+
+func readMemoryStats(dir string) (*memoryStats, error) {
+	current, err := readUint(filepath.Join(dir, "memory.current"))
+	if err != nil || current == nil {
+		return nil, err
+	}
+	stats := &memoryStats{current: *current}
+
+	// memory.max contains "max" when the memory is not limited
+	stats.max, err = readUint(filepath.Join(dir, "memory.max"))
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := readKeyValues(filepath.Join(dir, "memory.events"))
+	if err != nil {
+		return nil, err
+	}
+	stats.events = make(map[metadata.AttributeType]uint64, len(events))
+	for key, count := range events {
+		if eventType, ok := metadata.MapAttributeType[key]; ok {
+			stats.events[eventType] = count
+		}
+	}
+	return stats, nil
+}
+
+// readIOStats parses io.stat, whose lines are formatted as:
+//
+//	8:0 rbytes=90112 wbytes=0 rios=3 wios=0 dbytes=0 dios=0
+func readIOStats(dir string) ([]ioStats, error) {
+	lines, err := readLines(filepath.Join(dir, "io.stat"))
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []ioStats
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		device := ioStats{device: fields[0]}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			var dst *uint64
+			switch key {
+			case "rbytes":
+				dst = &device.rbytes
+			case "wbytes":
+				dst = &device.wbytes
+			case "rios":
+				dst = &device.rios
+			case "wios":
+				dst = &device.wios
+			default:
+				continue
+			}
+			if *dst, err = strconv.ParseUint(value, 10, 64); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(dir, "io.stat"), err)
+			}
+		}
+		stats = append(stats, device)
+	}
+	return stats, nil
+}
+
+func readPidsStats(dir string) (*pidsStats, error) {
+	current, err := readUint(filepath.Join(dir, "pids.current"))
+	if err != nil || current == nil {
+		return nil, err
+	}
+	return &pidsStats{current: *current}, nil
+}
+
+// readLines returns the non-empty lines of the file, or nil if the file does not exist.
+func readLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// readKeyValues parses the files whose lines are formatted as "<key> <value>", such as cpu.stat,
+// or returns nil if the file does not exist.
+func readKeyValues(path string) (map[string]uint64, error) {
+	lines, err := readLines(path)
+	if err != nil || lines == nil {
+		return nil, err
+	}
+
+	values := make(map[string]uint64, len(lines))
+	for _, line := range lines {
+		key, value, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, fmt.Errorf("failed to parse %s: invalid line %q", path, line)
+		}
+		if values[key], err = strconv.ParseUint(strings.TrimSpace(value), 10, 64); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+	return values, nil
+}
+
+// readUint parses the files containing a single value, such as memory.current, or returns nil if the file
+// does not exist or its value is "max".
+func readUint(path string) (*uint64, error) {
+	lines, err := readLines(path)
+	if err != nil || len(lines) == 0 || lines[0] == "max" {
+		return nil, err
+	}
+
+	value, err := strconv.ParseUint(lines[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &value, nil
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cgroupscraper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shirou/gopsutil/v4/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper/internal/metadata"
+)
+
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}
+
+func TestGetOSCgroupStatsRootPath(t *testing.T) {
+	ctx := context.WithValue(t.Context(), common.EnvKey, common.EnvMap{common.HostSysEnvKey: "testdata/sys"})
+
+	cgroups, err := getOSCgroupStats(ctx, func(string) bool { return true })
+	require.NoError(t, err)
+	assert.Equal(t, []*cgroupStats{
+		{
+			path: "/",
+			cpu:  &cpuStats{userUsec: 3000000, systemUsec: 2000000},
+			io: []ioStats{
+				{device: "8:0", rbytes: 4096, wbytes: 8192, rios: 1, wios: 2},
+			},
+		},
+		{
+			path: "/system.slice",
+			cpu:  &cpuStats{userUsec: 1000000, systemUsec: 500000, throttledUsec: 250000, hasThrottled: true},
+			memory: &memoryStats{
+				current: 104857600,
+				events: map[metadata.AttributeType]uint64{
+					metadata.AttributeTypeLow:          0,
+					metadata.AttributeTypeHigh:         0,
+					metadata.AttributeTypeMax:          0,
+					metadata.AttributeTypeOom:          0,
+					metadata.AttributeTypeOomKill:      0,
+					metadata.AttributeTypeOomGroupKill: 0,
+				},
+			},
+			io: []ioStats{
+				{device: "8:0", rbytes: 2048, wbytes: 4096, rios: 1, wios: 1},
+				{device: "259:0", rbytes: 1024, rios: 1},
+			},
+			pids: &pidsStats{current: 42},
+		},
+		{
+			path: "/system.slice/docker-abc.scope",
+			cpu:  &cpuStats{userUsec: 600000, systemUsec: 100000, hasThrottled: true},
+			memory: &memoryStats{
+				current: 52428800,
+				max:     uint64Ptr(536870912),
+				events: map[metadata.AttributeType]uint64{
+					metadata.AttributeTypeLow:          0,
+					metadata.AttributeTypeHigh:         3,
+					metadata.AttributeTypeMax:          2,
+					metadata.AttributeTypeOom:          1,
+					metadata.AttributeTypeOomKill:      1,
+					metadata.AttributeTypeOomGroupKill: 0,
+				},
+			},
+			pids: &pidsStats{current: 5},
+		},
+		{
+			path: "/user.slice",
+			cpu:  &cpuStats{userUsec: 200, systemUsec: 100},
+		},
+	}, cgroups)
+}
+
+func TestGetOSCgroupStatsFilter(t *testing.T) {
+	ctx := context.WithValue(t.Context(), common.EnvKey, common.EnvMap{common.HostSysEnvKey: "testdata/sys"})
+
+	var paths []string
+	cgroups, err := getOSCgroupStats(ctx, func(path string) bool {
+		return strings.HasPrefix(path, "/system.slice/")
+	})
+	require.NoError(t, err)
+	for _, cgroup := range cgroups {
+		paths = append(paths, cgroup.path)
+	}
+	assert.Equal(t, []string{"/system.slice/docker-abc.scope"}, paths)
+}
+
+func TestGetOSCgroupStatsErrors(t *testing.T) {
+	root := t.TempDir()
+	ctx := context.WithValue(t.Context(), common.EnvKey, common.EnvMap{common.HostSysEnvKey: root})
+	includeAll := func(string) bool { return true }
+
+	_, err := getOSCgroupStats(ctx, includeAll)
+	assert.ErrorContains(t, err, "cgroup v2 is not mounted at "+filepath.Join(root, "fs", "cgroup"))
+
+	cgroupRoot := filepath.Join(root, "fs", "cgroup")
+	require.NoError(t, os.MkdirAll(filepath.Join(cgroupRoot, "broken.slice"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(cgroupRoot, "cgroup.controllers"), []byte("memory pids\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(cgroupRoot, "broken.slice", "memory.current"), []byte("1024\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(cgroupRoot, "broken.slice", "memory.max"), []byte("unknown\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(cgroupRoot, "broken.slice", "pids.current"), []byte("7\n"), 0o600))
+
+	cgroups, err := getOSCgroupStats(ctx, includeAll)
+	assert.ErrorContains(t, err, "failed to parse "+filepath.Join(cgroupRoot, "broken.slice", "memory.max"))
+	require.Len(t, cgroups, 2)
+	assert.Equal(t, &cgroupStats{path: "/broken.slice", pids: &pidsStats{current: 7}}, cgroups[1])
+}
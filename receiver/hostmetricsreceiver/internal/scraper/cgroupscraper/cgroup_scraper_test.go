@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cgroupscraper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.opentelemetry.io/collector/scraper/scrapertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper/internal/metadata"
+)
+
+func mockGetCgroupStats(_ context.Context, include func(string) bool) ([]*cgroupStats, error) {
+	memoryMax := uint64(536870912)
+	all := []*cgroupStats{
+		{
+			path: "/",
+			cpu:  &cpuStats{userUsec: 3000000, systemUsec: 2000000},
+		},
+		{
+			path: "/system.slice/docker-abc.scope",
+			cpu:  &cpuStats{userUsec: 600000, systemUsec: 100000, throttledUsec: 50000, hasThrottled: true},
+			memory: &memoryStats{
+				current: 52428800,
+				max:     &memoryMax,
+				events: map[metadata.AttributeType]uint64{
+					metadata.AttributeTypeOom:     1,
+					metadata.AttributeTypeOomKill: 1,
+				},
+			},
+			io: []ioStats{
+				{device: "8:0", rbytes: 2048, wbytes: 4096, rios: 1, wios: 1},
+			},
+			pids: &pidsStats{current: 5},
+		},
+	}
+
+	var cgroups []*cgroupStats
+	for _, cgroup := range all {
+		if include(cgroup.path) {
+			cgroups = append(cgroups, cgroup)
+		}
+	}
+	return cgroups, nil
+}
+
+func TestScrape(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          *Config
+		expectedMetrics map[string]map[string]int
+	}{
+		{
+			name:   "all cgroups",
+			config: &Config{},
+			expectedMetrics: map[string]map[string]int{
+				"/": {
+					"cgroup.cpu.time": 2,
+				},
+				"/system.slice/docker-abc.scope": {
+					"cgroup.cpu.time":           2,
+					"cgroup.cpu.throttled.time": 1,
+					"cgroup.memory.usage":       1,
+					"cgroup.memory.limit":       1,
+					"cgroup.memory.events":      2,
+					"cgroup.io.bytes":           2,
+					"cgroup.io.operations":      2,
+					"cgroup.pids.count":         1,
+				},
+			},
+		},
+		{
+			name: "include",
+			config: &Config{
+				Include: MatchConfig{
+					Paths:  []string{"/system.slice/.*"},
+					Config: filterset.Config{MatchType: filterset.Regexp},
+				},
+			},
+			expectedMetrics: map[string]map[string]int{
+				"/system.slice/docker-abc.scope": {
+					"cgroup.cpu.time":           2,
+					"cgroup.cpu.throttled.time": 1,
+					"cgroup.memory.usage":       1,
+					"cgroup.memory.limit":       1,
+					"cgroup.memory.events":      2,
+					"cgroup.io.bytes":           2,
+					"cgroup.io.operations":      2,
+					"cgroup.pids.count":         1,
+				},
+			},
+		},
+		{
+			name: "exclude",
+			config: &Config{
+				Exclude: MatchConfig{
+					Paths:  []string{"/system.slice/docker-abc.scope"},
+					Config: filterset.Config{MatchType: filterset.Strict},
+				},
+			},
+			expectedMetrics: map[string]map[string]int{
+				"/": {
+					"cgroup.cpu.time": 2,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.MetricsBuilderConfig = metadata.DefaultMetricsBuilderConfig()
+			scraper, err := newCgroupScraper(scrapertest.NewNopSettings(metadata.Type), tt.config)
+			require.NoError(t, err)
+			scraper.getCgroupStats = mockGetCgroupStats
+			require.NoError(t, scraper.start(t.Context(), componenttest.NewNopHost()))
+
+			md, err := scraper.scrape(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedMetrics, dataPointCounts(t, md))
+		})
+	}
+}
+
+func TestScrapeErrors(t *testing.T) {
+	scraper, err := newCgroupScraper(scrapertest.NewNopSettings(metadata.Type), &Config{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+	})
+	require.NoError(t, err)
+	scraper.getCgroupStats = func(context.Context, func(string) bool) ([]*cgroupStats, error) {
+		return nil, errors.New("cgroup v2 is not mounted at /sys/fs/cgroup")
+	}
+	require.NoError(t, scraper.start(t.Context(), componenttest.NewNopHost()))
+
+	md, err := scraper.scrape(t.Context())
+	var partialErr scrapererror.PartialScrapeError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, cgroupMetricsLen, partialErr.Failed)
+	assert.Equal(t, 0, md.DataPointCount())
+}
+
+func TestNewCgroupScraperInvalidFilter(t *testing.T) {
+	_, err := newCgroupScraper(scrapertest.NewNopSettings(metadata.Type), &Config{
+		Include: MatchConfig{
+			Paths:  []string{"/system.slice/.*"},
+			Config: filterset.Config{MatchType: "invalid"},
+		},
+	})
+	assert.ErrorContains(t, err, "error creating cgroup path include filters")
+
+	_, err = newCgroupScraper(scrapertest.NewNopSettings(metadata.Type), &Config{
+		Exclude: MatchConfig{
+			Paths:  []string{"/system.slice/.*"},
+			Config: filterset.Config{MatchType: "invalid"},
+		},
+	})
+	assert.ErrorContains(t, err, "error creating cgroup path exclude filters")
+}
+
+// dataPointCounts returns the number of data points of every metric, by cgroup path.
+func dataPointCounts(t *testing.T, md pmetric.Metrics) map[string]map[string]int {
+	counts := map[string]map[string]int{}
+	for _, rm := range md.ResourceMetrics().All() {
+		path, ok := rm.Resource().Attributes().Get("cgroup.path")
+		require.True(t, ok)
+		metrics := map[string]int{}
+		for _, sm := range rm.ScopeMetrics().All() {
+			for _, metric := range sm.Metrics().All() {
+				switch metric.Type() {
+				case pmetric.MetricTypeSum:
+					metrics[metric.Name()] += metric.Sum().DataPoints().Len()
+				case pmetric.MetricTypeGauge:
+					metrics[metric.Name()] += metric.Gauge().DataPoints().Len()
+				}
+			}
+		}
+		counts[path.Str()] = metrics
+	}
+	return counts
+}
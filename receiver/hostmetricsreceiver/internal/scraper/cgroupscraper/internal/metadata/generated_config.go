@@ -0,0 +1,120 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/filter"
+)
+
+// MetricConfig provides common config for a particular metric.
+type MetricConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	enabledSetByUser bool
+}
+
+func (ms *MetricConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(ms)
+	if err != nil {
+		return err
+	}
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// MetricsConfig provides config for cgroup metrics.
+type MetricsConfig struct {
+	CgroupCPUThrottledTime MetricConfig `mapstructure:"cgroup.cpu.throttled.time"`
+	CgroupCPUTime          MetricConfig `mapstructure:"cgroup.cpu.time"`
+	CgroupIoBytes          MetricConfig `mapstructure:"cgroup.io.bytes"`
+	CgroupIoOperations     MetricConfig `mapstructure:"cgroup.io.operations"`
+	CgroupMemoryEvents     MetricConfig `mapstructure:"cgroup.memory.events"`
+	CgroupMemoryLimit      MetricConfig `mapstructure:"cgroup.memory.limit"`
+	CgroupMemoryUsage      MetricConfig `mapstructure:"cgroup.memory.usage"`
+	CgroupPidsCount        MetricConfig `mapstructure:"cgroup.pids.count"`
+}
+
+func DefaultMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		CgroupCPUThrottledTime: MetricConfig{
+			Enabled: true,
+		},
+		CgroupCPUTime: MetricConfig{
+			Enabled: true,
+		},
+		CgroupIoBytes: MetricConfig{
+			Enabled: true,
+		},
+		CgroupIoOperations: MetricConfig{
+			Enabled: true,
+		},
+		CgroupMemoryEvents: MetricConfig{
+			Enabled: true,
+		},
+		CgroupMemoryLimit: MetricConfig{
+			Enabled: true,
+		},
+		CgroupMemoryUsage: MetricConfig{
+			Enabled: true,
+		},
+		CgroupPidsCount: MetricConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// ResourceAttributeConfig provides common config for a particular resource attribute.
+type ResourceAttributeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Experimental: MetricsInclude defines a list of filters for attribute values.
+	// If the list is not empty, only metrics with matching resource attribute values will be emitted.
+	MetricsInclude []filter.Config `mapstructure:"metrics_include"`
+	// Experimental: MetricsExclude defines a list of filters for attribute values.
+	// If the list is not empty, metrics with matching resource attribute values will not be emitted.
+	// MetricsInclude has higher priority than MetricsExclude.
+	MetricsExclude []filter.Config `mapstructure:"metrics_exclude"`
+
+	enabledSetByUser bool
+}
+
+func (rac *ResourceAttributeConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(rac)
+	if err != nil {
+		return err
+	}
+	rac.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// ResourceAttributesConfig provides config for cgroup resource attributes.
+type ResourceAttributesConfig struct {
+	CgroupPath ResourceAttributeConfig `mapstructure:"cgroup.path"`
+}
+
+func DefaultResourceAttributesConfig() ResourceAttributesConfig {
+	return ResourceAttributesConfig{
+		CgroupPath: ResourceAttributeConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// MetricsBuilderConfig is a configuration for cgroup metrics builder.
+type MetricsBuilderConfig struct {
+	Metrics            MetricsConfig            `mapstructure:"metrics"`
+	ResourceAttributes ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+func DefaultMetricsBuilderConfig() MetricsBuilderConfig {
+	return MetricsBuilderConfig{
+		Metrics:            DefaultMetricsConfig(),
+		ResourceAttributes: DefaultResourceAttributesConfig(),
+	}
+}
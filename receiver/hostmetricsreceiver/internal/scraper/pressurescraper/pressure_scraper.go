@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+// 2 metrics are recorded for every resource
+const pressureMetricsLen = 2
+
+// resources are the resources for which the kernel reports the pressure stall information
+var resources = []metadata.AttributeResource{
+	metadata.AttributeResourceCpu,
+	metadata.AttributeResourceMemory,
+	metadata.AttributeResourceIo,
+}
+
+// pressureStats are the pressure stall information of a resource.
+type pressureStats struct {
+	some *stallStats
+	// full is nil when the kernel does not report it, as for the cpu before Linux 5.13
+	full *stallStats
+}
+
+// stallStats are the stall ratios averaged over 10, 60 and 300 seconds, in percents, and the total stall time,
+// in microseconds.
+type stallStats struct {
+	avg10  float64
+	avg60  float64
+	avg300 float64
+	total  uint64
+}
+
+// pressureScraper for Pressure Metrics
+type pressureScraper struct {
+	settings scraper.Settings
+	config   *Config
+	mb       *metadata.MetricsBuilder
+
+	getPressureStats func(ctx context.Context, resource string) (*pressureStats, error)
+}
+
+// newPressureScraper creates a metric scraper for Pressure metrics
+func newPressureScraper(settings scraper.Settings, cfg *Config) *pressureScraper {
+	return &pressureScraper{
+		settings:         settings,
+		config:           cfg,
+		getPressureStats: getOSPressureStats,
+	}
+}
+
+func (s *pressureScraper) start(context.Context, component.Host) error {
+	s.mb = metadata.NewMetricsBuilder(s.config.MetricsBuilderConfig, s.settings)
+	return nil
+}
+
+func (s *pressureScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	var errs scrapererror.ScrapeErrors
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	for _, resource := range resources {
+		stats, err := s.getPressureStats(ctx, resource.String())
+		if err != nil {
+			errs.AddPartial(pressureMetricsLen, err)
+			continue
+		}
+		s.recordPressureMetrics(now, resource, stats)
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+func (s *pressureScraper) recordPressureMetrics(now pcommon.Timestamp, resource metadata.AttributeResource, stats *pressureStats) {
+	if stats == nil {
+		return
+	}
+
+	s.recordStallMetrics(now, resource, metadata.AttributeStallSome, stats.some)
+	s.recordStallMetrics(now, resource, metadata.AttributeStallFull, stats.full)
+}
+
+func (s *pressureScraper) recordStallMetrics(now pcommon.Timestamp, resource metadata.AttributeResource, stall metadata.AttributeStall, stats *stallStats) {
+	if stats == nil {
+		return
+	}
+
+	s.mb.RecordSystemPressureStallTimeDataPoint(now, float64(stats.total)/1e6, resource, stall)
+	s.mb.RecordSystemPressureStallRatioDataPoint(now, stats.avg10/100, resource, stall, metadata.AttributeWindow10s)
+	s.mb.RecordSystemPressureStallRatioDataPoint(now, stats.avg60/100, resource, stall, metadata.AttributeWindow60s)
+	s.mb.RecordSystemPressureStallRatioDataPoint(now, stats.avg300/100, resource, stall, metadata.AttributeWindow300s)
+}
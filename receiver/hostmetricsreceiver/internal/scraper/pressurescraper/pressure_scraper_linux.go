@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package pressurescraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v4/common"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/gopsutilenv"
+)
+
+// getOSPressureStats reads the pressure stall information of the resource from /proc/pressure/<resource>,
+// which is available since Linux 4.20 when the kernel is built with CONFIG_PSI.
+func getOSPressureStats(ctx context.Context, resource string) (*pressureStats, error) {
+	path := gopsutilenv.GetEnvWithContext(ctx, string(common.HostProcEnvKey), "/proc", "pressure", resource)
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	stats, err := parsePressureStats(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return stats, nil
+}
+
+// parsePressureStats parses the lines of a pressure file, which are formatted as:
+//
+//	some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+func parsePressureStats(r io.Reader) (*pressureStats, error) {
+	stats := &pressureStats{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		stall, err := parseStallStats(fields[1:])
+		if err != nil {
+			return nil, err
+		}
+		switch fields[0] {
+		case "some":
+			stats.some = stall
+		case "full":
+			stats.full = stall
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if stats.some == nil {
+		return nil, fmt.Errorf("missing %q line", "some")
+	}
+	return stats, nil
+}
+
+func parseStallStats(fields []string) (*stallStats, error) {
+	stats := &stallStats{}
+	var found int
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid field %q", field)
+		}
+
+		var err error
+		switch key {
+		case "avg10":
+			stats.avg10, err = strconv.ParseFloat(value, 64)
+		case "avg60":
+			stats.avg60, err = strconv.ParseFloat(value, 64)
+		case "avg300":
+			stats.avg300, err = strconv.ParseFloat(value, 64)
+		case "total":
+			stats.total, err = strconv.ParseUint(value, 10, 64)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid field %q: %w", field, err)
+		}
+		found++
+	}
+
+	if found != 4 {
+		return nil, fmt.Errorf("expected the avg10, avg60, avg300 and total fields, got %q", strings.Join(fields, " "))
+	}
+	return stats, nil
+}
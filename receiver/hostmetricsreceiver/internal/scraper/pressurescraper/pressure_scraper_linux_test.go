@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shirou/gopsutil/v4/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePressureStats(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected *pressureStats
+		err      string
+	}{
+		{
+			name: "some and full",
+			content: `some avg10=1.50 avg60=0.75 avg300=0.25 total=1234567
+full avg10=0.10 avg60=0.20 avg300=0.30 total=42
+`,
+			expected: &pressureStats{
+				some: &stallStats{avg10: 1.5, avg60: 0.75, avg300: 0.25, total: 1234567},
+				full: &stallStats{avg10: 0.1, avg60: 0.2, avg300: 0.3, total: 42},
+			},
+		},
+		{
+			name:    "some only",
+			content: "some avg10=0.00 avg60=0.00 avg300=0.00 total=0\n",
+			expected: &pressureStats{
+				some: &stallStats{},
+			},
+		},
+		{
+			name:    "missing some",
+			content: "full avg10=0.00 avg60=0.00 avg300=0.00 total=0\n",
+			err:     `missing "some" line`,
+		},
+		{
+			name:    "missing field",
+			content: "some avg10=0.00 avg60=0.00 avg300=0.00\n",
+			err:     "expected the avg10, avg60, avg300 and total fields",
+		},
+		{
+			name:    "invalid field",
+			content: "some avg10=0.00 avg60 avg300=0.00 total=0\n",
+			err:     `invalid field "avg60"`,
+		},
+		{
+			name:    "invalid value",
+			content: "some avg10=0.00 avg60=0.00 avg300=0.00 total=-1\n",
+			err:     `invalid field "total=-1"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := parsePressureStats(strings.NewReader(tt.content))
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, stats)
+		})
+	}
+}
+
+func TestGetOSPressureStatsRootPath(t *testing.T) {
+	ctx := context.WithValue(t.Context(), common.EnvKey, common.EnvMap{common.HostProcEnvKey: "testdata/proc"})
+
+	stats, err := getOSPressureStats(ctx, "io")
+	require.NoError(t, err)
+	assert.Equal(t, &pressureStats{
+		some: &stallStats{avg10: 10, avg60: 5, avg300: 2.5, total: 30000000},
+		full: &stallStats{avg10: 8, avg60: 4, avg300: 2, total: 25000000},
+	}, stats)
+
+	_, err = getOSPressureStats(ctx, "irq")
+	assert.ErrorContains(t, err, "testdata/proc/pressure/irq")
+}
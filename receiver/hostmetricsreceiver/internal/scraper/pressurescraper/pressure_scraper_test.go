@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.opentelemetry.io/collector/scraper/scrapertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+func mockGetPressureStats(_ context.Context, resource string) (*pressureStats, error) {
+	switch resource {
+	case "cpu":
+		return &pressureStats{
+			some: &stallStats{avg10: 1.5, avg60: 0.75, avg300: 0.25, total: 1234567},
+		}, nil
+	case "memory":
+		return &pressureStats{
+			some: &stallStats{avg10: 2, avg60: 1, avg300: 0.5, total: 2000000},
+			full: &stallStats{avg10: 1, avg60: 0.5, avg300: 0.1, total: 500000},
+		}, nil
+	default:
+		return nil, errors.New("no such file or directory")
+	}
+}
+
+func TestScrape(t *testing.T) {
+	scraper := newPressureScraper(scrapertest.NewNopSettings(metadata.Type), &Config{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+	})
+	scraper.getPressureStats = mockGetPressureStats
+	require.NoError(t, scraper.start(t.Context(), componenttest.NewNopHost()))
+
+	md, err := scraper.scrape(t.Context())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	var partialErr scrapererror.PartialScrapeError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, pressureMetricsLen, partialErr.Failed)
+
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+
+	stallTime := findMetric(t, metrics, "system.pressure.stall.time")
+	assert.Equal(t, map[string]float64{
+		"cpu/some":    1.234567,
+		"memory/some": 2,
+		"memory/full": 0.5,
+	}, dataPointValues(stallTime.Sum().DataPoints(), "resource", "stall"))
+
+	stallRatio := findMetric(t, metrics, "system.pressure.stall.ratio")
+	assert.Equal(t, map[string]float64{
+		"cpu/some/10s":     0.015,
+		"cpu/some/60s":     0.0075,
+		"cpu/some/300s":    0.0025,
+		"memory/some/10s":  0.02,
+		"memory/some/60s":  0.01,
+		"memory/some/300s": 0.005,
+		"memory/full/10s":  0.01,
+		"memory/full/60s":  0.005,
+		"memory/full/300s": 0.001,
+	}, dataPointValues(stallRatio.Gauge().DataPoints(), "resource", "stall", "window"))
+}
+
+func findMetric(t *testing.T, metrics pmetric.MetricSlice, name string) pmetric.Metric {
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() == name {
+			return metrics.At(i)
+		}
+	}
+	require.Failf(t, "missing metric", "metric %q not found", name)
+	return pmetric.Metric{}
+}
+
+// dataPointValues returns the values of the data points, keyed by the values of the given attributes.
+func dataPointValues(dps pmetric.NumberDataPointSlice, attributes ...string) map[string]float64 {
+	values := map[string]float64{}
+	for i := 0; i < dps.Len(); i++ {
+		var key string
+		for j, attribute := range attributes {
+			if j > 0 {
+				key += "/"
+			}
+			val, _ := dps.At(i).Attributes().Get(attribute)
+			key += val.AsString()
+		}
+		values[key] = roundValue(dps.At(i).DoubleValue())
+	}
+	return values
+}
+
+// roundValue removes the floating point errors of the conversions from percents and microseconds.
+func roundValue(v float64) float64 {
+	return math.Round(v*1e9) / 1e9
+}
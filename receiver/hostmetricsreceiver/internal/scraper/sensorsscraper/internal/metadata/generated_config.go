@@ -0,0 +1,58 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// MetricConfig provides common config for a particular metric.
+type MetricConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	enabledSetByUser bool
+}
+
+func (ms *MetricConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(ms)
+	if err != nil {
+		return err
+	}
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// MetricsConfig provides config for sensors metrics.
+type MetricsConfig struct {
+	SystemSensorFanSpeed    MetricConfig `mapstructure:"system.sensor.fan.speed"`
+	SystemSensorTemperature MetricConfig `mapstructure:"system.sensor.temperature"`
+	SystemSensorVoltage     MetricConfig `mapstructure:"system.sensor.voltage"`
+}
+
+func DefaultMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		SystemSensorFanSpeed: MetricConfig{
+			Enabled: true,
+		},
+		SystemSensorTemperature: MetricConfig{
+			Enabled: true,
+		},
+		SystemSensorVoltage: MetricConfig{
+			Enabled: false,
+		},
+	}
+}
+
+// MetricsBuilderConfig is a configuration for sensors metrics builder.
+type MetricsBuilderConfig struct {
+	Metrics MetricsConfig `mapstructure:"metrics"`
+}
+
+func DefaultMetricsBuilderConfig() MetricsBuilderConfig {
+	return MetricsBuilderConfig{
+		Metrics: DefaultMetricsConfig(),
+	}
+}
@@ -0,0 +1,347 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper"
+	conventions "go.opentelemetry.io/otel/semconv/v1.9.0"
+)
+
+var MetricsInfo = metricsInfo{
+	SystemSensorFanSpeed: metricInfo{
+		Name: "system.sensor.fan.speed",
+	},
+	SystemSensorTemperature: metricInfo{
+		Name: "system.sensor.temperature",
+	},
+	SystemSensorVoltage: metricInfo{
+		Name: "system.sensor.voltage",
+	},
+}
+
+type metricsInfo struct {
+	SystemSensorFanSpeed    metricInfo
+	SystemSensorTemperature metricInfo
+	SystemSensorVoltage     metricInfo
+}
+
+type metricInfo struct {
+	Name string
+}
+
+type metricSystemSensorFanSpeed struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills system.sensor.fan.speed metric with initial data.
+func (m *metricSystemSensorFanSpeed) init() {
+	m.data.SetName("system.sensor.fan.speed")
+	m.data.SetDescription("Speed of the fan.")
+	m.data.SetUnit("rpm")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemSensorFanSpeed) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, chipAttributeValue string, deviceAttributeValue string, sensorAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("chip", chipAttributeValue)
+	dp.Attributes().PutStr("device", deviceAttributeValue)
+	dp.Attributes().PutStr("sensor", sensorAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemSensorFanSpeed) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemSensorFanSpeed) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemSensorFanSpeed(cfg MetricConfig) metricSystemSensorFanSpeed {
+	m := metricSystemSensorFanSpeed{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemSensorTemperature struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills system.sensor.temperature metric with initial data.
+func (m *metricSystemSensorTemperature) init() {
+	m.data.SetName("system.sensor.temperature")
+	m.data.SetDescription("Temperature measured by the sensor.")
+	m.data.SetUnit("Cel")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemSensorTemperature) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, chipAttributeValue string, deviceAttributeValue string, sensorAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("chip", chipAttributeValue)
+	dp.Attributes().PutStr("device", deviceAttributeValue)
+	dp.Attributes().PutStr("sensor", sensorAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemSensorTemperature) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemSensorTemperature) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemSensorTemperature(cfg MetricConfig) metricSystemSensorTemperature {
+	m := metricSystemSensorTemperature{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemSensorVoltage struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills system.sensor.voltage metric with initial data.
+func (m *metricSystemSensorVoltage) init() {
+	m.data.SetName("system.sensor.voltage")
+	m.data.SetDescription("Voltage measured by the sensor.")
+	m.data.SetUnit("V")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemSensorVoltage) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, chipAttributeValue string, deviceAttributeValue string, sensorAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("chip", chipAttributeValue)
+	dp.Attributes().PutStr("device", deviceAttributeValue)
+	dp.Attributes().PutStr("sensor", sensorAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemSensorVoltage) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemSensorVoltage) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemSensorVoltage(cfg MetricConfig) metricSystemSensorVoltage {
+	m := metricSystemSensorVoltage{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+// MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
+// required to produce metric representation defined in metadata and user config.
+type MetricsBuilder struct {
+	config                        MetricsBuilderConfig // config of the metrics builder.
+	startTime                     pcommon.Timestamp    // start time that will be applied to all recorded data points.
+	metricsCapacity               int                  // maximum observed number of metrics per resource.
+	metricsBuffer                 pmetric.Metrics      // accumulates metrics data before emitting.
+	buildInfo                     component.BuildInfo  // contains version information.
+	metricSystemSensorFanSpeed    metricSystemSensorFanSpeed
+	metricSystemSensorTemperature metricSystemSensorTemperature
+	metricSystemSensorVoltage     metricSystemSensorVoltage
+}
+
+// MetricBuilderOption applies changes to default metrics builder.
+type MetricBuilderOption interface {
+	apply(*MetricsBuilder)
+}
+
+type metricBuilderOptionFunc func(mb *MetricsBuilder)
+
+func (mbof metricBuilderOptionFunc) apply(mb *MetricsBuilder) {
+	mbof(mb)
+}
+
+// WithStartTime sets startTime on the metrics builder.
+func WithStartTime(startTime pcommon.Timestamp) MetricBuilderOption {
+	return metricBuilderOptionFunc(func(mb *MetricsBuilder) {
+		mb.startTime = startTime
+	})
+}
+func NewMetricsBuilder(mbc MetricsBuilderConfig, settings scraper.Settings, options ...MetricBuilderOption) *MetricsBuilder {
+	mb := &MetricsBuilder{
+		config:                        mbc,
+		startTime:                     pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                 pmetric.NewMetrics(),
+		buildInfo:                     settings.BuildInfo,
+		metricSystemSensorFanSpeed:    newMetricSystemSensorFanSpeed(mbc.Metrics.SystemSensorFanSpeed),
+		metricSystemSensorTemperature: newMetricSystemSensorTemperature(mbc.Metrics.SystemSensorTemperature),
+		metricSystemSensorVoltage:     newMetricSystemSensorVoltage(mbc.Metrics.SystemSensorVoltage),
+	}
+
+	for _, op := range options {
+		op.apply(mb)
+	}
+	return mb
+}
+
+// updateCapacity updates max length of metrics and resource attributes that will be used for the slice capacity.
+func (mb *MetricsBuilder) updateCapacity(rm pmetric.ResourceMetrics) {
+	if mb.metricsCapacity < rm.ScopeMetrics().At(0).Metrics().Len() {
+		mb.metricsCapacity = rm.ScopeMetrics().At(0).Metrics().Len()
+	}
+}
+
+// ResourceMetricsOption applies changes to provided resource metrics.
+type ResourceMetricsOption interface {
+	apply(pmetric.ResourceMetrics)
+}
+
+type resourceMetricsOptionFunc func(pmetric.ResourceMetrics)
+
+func (rmof resourceMetricsOptionFunc) apply(rm pmetric.ResourceMetrics) {
+	rmof(rm)
+}
+
+// WithResource sets the provided resource on the emitted ResourceMetrics.
+// It's recommended to use ResourceBuilder to create the resource.
+func WithResource(res pcommon.Resource) ResourceMetricsOption {
+	return resourceMetricsOptionFunc(func(rm pmetric.ResourceMetrics) {
+		res.CopyTo(rm.Resource())
+	})
+}
+
+// WithStartTimeOverride overrides start time for all the resource metrics data points.
+// This option should be only used if different start time has to be set on metrics coming from different resources.
+func WithStartTimeOverride(start pcommon.Timestamp) ResourceMetricsOption {
+	return resourceMetricsOptionFunc(func(rm pmetric.ResourceMetrics) {
+		var dps pmetric.NumberDataPointSlice
+		metrics := rm.ScopeMetrics().At(0).Metrics()
+		for i := 0; i < metrics.Len(); i++ {
+			switch metrics.At(i).Type() {
+			case pmetric.MetricTypeGauge:
+				dps = metrics.At(i).Gauge().DataPoints()
+			case pmetric.MetricTypeSum:
+				dps = metrics.At(i).Sum().DataPoints()
+			}
+			for j := 0; j < dps.Len(); j++ {
+				dps.At(j).SetStartTimestamp(start)
+			}
+		}
+	})
+}
+
+// EmitForResource saves all the generated metrics under a new resource and updates the internal state to be ready for
+// recording another set of data points as part of another resource. This function can be helpful when one scraper
+// needs to emit metrics from several resources. Otherwise calling this function is not required,
+// just `Emit` function can be called instead.
+// Resource attributes should be provided as ResourceMetricsOption arguments.
+func (mb *MetricsBuilder) EmitForResource(options ...ResourceMetricsOption) {
+	rm := pmetric.NewResourceMetrics()
+	rm.SetSchemaUrl(conventions.SchemaURL)
+	ils := rm.ScopeMetrics().AppendEmpty()
+	ils.Scope().SetName(ScopeName)
+	ils.Scope().SetVersion(mb.buildInfo.Version)
+	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricSystemSensorFanSpeed.emit(ils.Metrics())
+	mb.metricSystemSensorTemperature.emit(ils.Metrics())
+	mb.metricSystemSensorVoltage.emit(ils.Metrics())
+
+	for _, op := range options {
+		op.apply(rm)
+	}
+
+	if ils.Metrics().Len() > 0 {
+		mb.updateCapacity(rm)
+		rm.MoveTo(mb.metricsBuffer.ResourceMetrics().AppendEmpty())
+	}
+}
+
+// Emit returns all the metrics accumulated by the metrics builder and updates the internal state to be ready for
+// recording another set of metrics. This function will be responsible for applying all the transformations required to
+// produce metric representation defined in metadata and user config, e.g. delta or cumulative.
+func (mb *MetricsBuilder) Emit(options ...ResourceMetricsOption) pmetric.Metrics {
+	mb.EmitForResource(options...)
+	metrics := mb.metricsBuffer
+	mb.metricsBuffer = pmetric.NewMetrics()
+	return metrics
+}
+
+// RecordSystemSensorFanSpeedDataPoint adds a data point to system.sensor.fan.speed metric.
+func (mb *MetricsBuilder) RecordSystemSensorFanSpeedDataPoint(ts pcommon.Timestamp, val int64, chipAttributeValue string, deviceAttributeValue string, sensorAttributeValue string) {
+	mb.metricSystemSensorFanSpeed.recordDataPoint(mb.startTime, ts, val, chipAttributeValue, deviceAttributeValue, sensorAttributeValue)
+}
+
+// RecordSystemSensorTemperatureDataPoint adds a data point to system.sensor.temperature metric.
+func (mb *MetricsBuilder) RecordSystemSensorTemperatureDataPoint(ts pcommon.Timestamp, val float64, chipAttributeValue string, deviceAttributeValue string, sensorAttributeValue string) {
+	mb.metricSystemSensorTemperature.recordDataPoint(mb.startTime, ts, val, chipAttributeValue, deviceAttributeValue, sensorAttributeValue)
+}
+
+// RecordSystemSensorVoltageDataPoint adds a data point to system.sensor.voltage metric.
+func (mb *MetricsBuilder) RecordSystemSensorVoltageDataPoint(ts pcommon.Timestamp, val float64, chipAttributeValue string, deviceAttributeValue string, sensorAttributeValue string) {
+	mb.metricSystemSensorVoltage.recordDataPoint(mb.startTime, ts, val, chipAttributeValue, deviceAttributeValue, sensorAttributeValue)
+}
+
+// Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
+// and metrics builder should update its startTime and reset it's internal state accordingly.
+func (mb *MetricsBuilder) Reset(options ...MetricBuilderOption) {
+	mb.startTime = pcommon.NewTimestampFromTime(time.Now())
+	for _, op := range options {
+		op.apply(mb)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sensorsscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/sensorsscraper"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/sensorsscraper/internal/metadata"
+)
+
+// 3 metrics: fan speed, temperature and voltage
+const sensorsMetricsLen = 3
+
+type sensorType int
+
+const (
+	sensorTypeFan sensorType = iota
+	sensorTypeTemperature
+	sensorTypeVoltage
+)
+
+// sensorReading is the value of a sensor, converted to rpm for the fans, degrees Celsius for the temperatures,
+// and volts for the voltages.
+type sensorReading struct {
+	chip       string
+	device     string
+	sensor     string
+	sensorType sensorType
+	value      float64
+}
+
+// sensorsScraper for Sensors Metrics
+type sensorsScraper struct {
+	settings scraper.Settings
+	config   *Config
+	mb       *metadata.MetricsBuilder
+
+	// getSensorReadings returns the readings of the sensors that could be read, and an error for each sensor that
+	// could not be read
+	getSensorReadings func(ctx context.Context) ([]sensorReading, error)
+}
+
+// newSensorsScraper creates a metric scraper for Sensors metrics
+func newSensorsScraper(settings scraper.Settings, cfg *Config) *sensorsScraper {
+	return &sensorsScraper{
+		settings:          settings,
+		config:            cfg,
+		getSensorReadings: getOSSensorReadings,
+	}
+}
+
+func (s *sensorsScraper) start(context.Context, component.Host) error {
+	s.mb = metadata.NewMetricsBuilder(s.config.MetricsBuilderConfig, s.settings)
+	return nil
+}
+
+func (s *sensorsScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	var errs scrapererror.ScrapeErrors
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	readings, err := s.getSensorReadings(ctx)
+	if err != nil {
+		if len(readings) == 0 {
+			errs.AddPartial(sensorsMetricsLen, err)
+		} else {
+			for _, readErr := range multierr.Errors(err) {
+				errs.AddPartial(1, readErr)
+			}
+		}
+	}
+
+	for _, reading := range readings {
+		switch reading.sensorType {
+		case sensorTypeFan:
+			s.mb.RecordSystemSensorFanSpeedDataPoint(now, int64(reading.value), reading.chip, reading.device, reading.sensor)
+		case sensorTypeTemperature:
+			s.mb.RecordSystemSensorTemperatureDataPoint(now, reading.value, reading.chip, reading.device, reading.sensor)
+		case sensorTypeVoltage:
+			s.mb.RecordSystemSensorVoltageDataPoint(now, reading.value, reading.chip, reading.device, reading.sensor)
+		}
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
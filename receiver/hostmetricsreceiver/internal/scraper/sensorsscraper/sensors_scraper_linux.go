@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package sensorsscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/sensorsscraper"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v4/common"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/gopsutilenv"
+)
+
+// sensorInputRegexp matches the input files of the fans, temperatures and voltages of a hwmon device,
+// see https://docs.kernel.org/hwmon/sysfs-interface.html
+var sensorInputRegexp = regexp.MustCompile(`^(fan|temp|in)(\d+)_input$`)
+
+// sensorTypes maps the prefixes of the input files to the sensor types, with the scale of their values:
+// the fans are reported in rpm, the temperatures in millidegrees Celsius and the voltages in millivolts.
+var sensorTypes = map[string]struct {
+	sensorType sensorType
+	scale      float64
+}{
+	"fan":  {sensorType: sensorTypeFan, scale: 1},
+	"temp": {sensorType: sensorTypeTemperature, scale: 1000},
+	"in":   {sensorType: sensorTypeVoltage, scale: 1000},
+}
+
+// getOSSensorReadings reads the sensors of the hwmon devices in /sys/class/hwmon.
+func getOSSensorReadings(ctx context.Context) ([]sensorReading, error) {
+	hwmonPath := gopsutilenv.GetEnvWithContext(ctx, string(common.HostSysEnvKey), "/sys", "class", "hwmon")
+	devices, err := os.ReadDir(hwmonPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var readings []sensorReading
+	var errs error
+	for _, device := range devices {
+		deviceReadings, deviceErr := readHwmonDevice(filepath.Join(hwmonPath, device.Name()))
+		readings = append(readings, deviceReadings...)
+		errs = multierr.Append(errs, deviceErr)
+	}
+	return readings, errs
+}
+
+// readHwmonDevice reads the sensors of a hwmon device. Some drivers expose the sensor files in the
+// device directory of the hwmon device instead of the hwmon device directory itself.
+func readHwmonDevice(devicePath string) ([]sensorReading, error) {
+	sensorsPath := devicePath
+	chip, err := readSysfsString(filepath.Join(sensorsPath, "name"))
+	if os.IsNotExist(err) {
+		sensorsPath = filepath.Join(devicePath, "device")
+		chip, err = readSysfsString(filepath.Join(sensorsPath, "name"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := os.ReadDir(sensorsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	device := filepath.Base(devicePath)
+	var readings []sensorReading
+	var errs error
+	for _, file := range files {
+		match := sensorInputRegexp.FindStringSubmatch(file.Name())
+		if match == nil {
+			continue
+		}
+
+		value, err := readSysfsInt(filepath.Join(sensorsPath, file.Name()))
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+
+		sensor := match[1] + match[2]
+		if label, err := readSysfsString(filepath.Join(sensorsPath, sensor+"_label")); err == nil && label != "" {
+			sensor = label
+		}
+
+		typ := sensorTypes[match[1]]
+		readings = append(readings, sensorReading{
+			chip:       chip,
+			device:     device,
+			sensor:     sensor,
+			sensorType: typ.sensorType,
+			value:      float64(value) / typ.scale,
+		})
+	}
+	return readings, errs
+}
+
+func readSysfsString(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+func readSysfsInt(path string) (int64, error) {
+	content, err := readSysfsString(path)
+	if err != nil {
+		return 0, err
+	}
+	value, err := strconv.ParseInt(content, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return value, nil
+}
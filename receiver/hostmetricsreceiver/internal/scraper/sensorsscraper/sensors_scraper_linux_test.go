@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sensorsscraper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shirou/gopsutil/v4/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+func TestGetOSSensorReadingsRootPath(t *testing.T) {
+	ctx := context.WithValue(t.Context(), common.EnvKey, common.EnvMap{common.HostSysEnvKey: "testdata/sys"})
+
+	readings, err := getOSSensorReadings(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []sensorReading{
+		{chip: "coretemp", device: "hwmon0", sensor: "Package id 0", sensorType: sensorTypeTemperature, value: 45},
+		{chip: "coretemp", device: "hwmon0", sensor: "Core 0", sensorType: sensorTypeTemperature, value: 43.5},
+		{chip: "nct6775", device: "hwmon1", sensor: "fan1", sensorType: sensorTypeFan, value: 1200},
+		{chip: "nct6775", device: "hwmon1", sensor: "fan2", sensorType: sensorTypeFan, value: 0},
+		{chip: "nct6775", device: "hwmon1", sensor: "Vcore", sensorType: sensorTypeVoltage, value: 1.024},
+		{chip: "nct6775", device: "hwmon1", sensor: "in1", sensorType: sensorTypeVoltage, value: 3.312},
+		{chip: "legacy", device: "hwmon2", sensor: "temp1", sensorType: sensorTypeTemperature, value: 30},
+	}, readings)
+}
+
+func TestGetOSSensorReadingsErrors(t *testing.T) {
+	root := t.TempDir()
+	hwmonPath := filepath.Join(root, "class", "hwmon")
+	ctx := context.WithValue(t.Context(), common.EnvKey, common.EnvMap{common.HostSysEnvKey: root})
+
+	_, err := getOSSensorReadings(ctx)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	writeFile := func(path, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	writeFile(filepath.Join(hwmonPath, "hwmon0", "name"), "acpitz\n")
+	writeFile(filepath.Join(hwmonPath, "hwmon0", "temp1_input"), "27800\n")
+	writeFile(filepath.Join(hwmonPath, "hwmon0", "temp2_input"), "N/A\n")
+	writeFile(filepath.Join(hwmonPath, "hwmon1", "temp1_input"), "27800\n")
+
+	readings, err := getOSSensorReadings(ctx)
+	assert.Equal(t, []sensorReading{
+		{chip: "acpitz", device: "hwmon0", sensor: "temp1", sensorType: sensorTypeTemperature, value: 27.8},
+	}, readings)
+	errs := multierr.Errors(err)
+	require.Len(t, errs, 2)
+	assert.ErrorContains(t, errs[0], "temp2_input")
+	assert.ErrorIs(t, errs[1], os.ErrNotExist)
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sensorsscraper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.opentelemetry.io/collector/scraper/scrapertest"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/sensorsscraper/internal/metadata"
+)
+
+func TestScrape(t *testing.T) {
+	tests := []struct {
+		name              string
+		getSensorReadings func(context.Context) ([]sensorReading, error)
+		voltage           bool
+		expectedMetrics   map[string]int
+		expectedFailed    int
+	}{
+		{
+			name: "default metrics",
+			getSensorReadings: func(context.Context) ([]sensorReading, error) {
+				return []sensorReading{
+					{chip: "coretemp", device: "hwmon0", sensor: "Core 0", sensorType: sensorTypeTemperature, value: 43.5},
+					{chip: "coretemp", device: "hwmon0", sensor: "Core 1", sensorType: sensorTypeTemperature, value: 44},
+					{chip: "nct6775", device: "hwmon1", sensor: "fan1", sensorType: sensorTypeFan, value: 1200},
+					{chip: "nct6775", device: "hwmon1", sensor: "Vcore", sensorType: sensorTypeVoltage, value: 1.024},
+				}, nil
+			},
+			expectedMetrics: map[string]int{
+				"system.sensor.temperature": 2,
+				"system.sensor.fan.speed":   1,
+			},
+		},
+		{
+			name: "voltage enabled",
+			getSensorReadings: func(context.Context) ([]sensorReading, error) {
+				return []sensorReading{
+					{chip: "nct6775", device: "hwmon1", sensor: "Vcore", sensorType: sensorTypeVoltage, value: 1.024},
+				}, nil
+			},
+			voltage: true,
+			expectedMetrics: map[string]int{
+				"system.sensor.voltage": 1,
+			},
+		},
+		{
+			name: "unreadable sensors",
+			getSensorReadings: func(context.Context) ([]sensorReading, error) {
+				return []sensorReading{
+					{chip: "coretemp", device: "hwmon0", sensor: "Core 0", sensorType: sensorTypeTemperature, value: 43.5},
+				}, multierr.Combine(errors.New("temp2_input: no data"), errors.New("temp3_input: no data"))
+			},
+			expectedMetrics: map[string]int{
+				"system.sensor.temperature": 1,
+			},
+			expectedFailed: 2,
+		},
+		{
+			name: "hwmon unavailable",
+			getSensorReadings: func(context.Context) ([]sensorReading, error) {
+				return nil, errors.New("open /sys/class/hwmon: no such file or directory")
+			},
+			expectedMetrics: map[string]int{},
+			expectedFailed:  sensorsMetricsLen,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig()}
+			cfg.Metrics.SystemSensorVoltage.Enabled = tt.voltage
+			scraper := newSensorsScraper(scrapertest.NewNopSettings(metadata.Type), cfg)
+			scraper.getSensorReadings = tt.getSensorReadings
+			require.NoError(t, scraper.start(t.Context(), componenttest.NewNopHost()))
+
+			md, err := scraper.scrape(t.Context())
+			if tt.expectedFailed == 0 {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				var partialErr scrapererror.PartialScrapeError
+				require.ErrorAs(t, err, &partialErr)
+				assert.Equal(t, tt.expectedFailed, partialErr.Failed)
+			}
+
+			assert.Equal(t, tt.expectedMetrics, dataPointCounts(md))
+		})
+	}
+}
+
+// dataPointCounts returns the number of data points of every metric.
+func dataPointCounts(md pmetric.Metrics) map[string]int {
+	counts := map[string]int{}
+	for _, rm := range md.ResourceMetrics().All() {
+		for _, sm := range rm.ScopeMetrics().All() {
+			for _, metric := range sm.Metrics().All() {
+				counts[metric.Name()] += metric.Gauge().DataPoints().Len()
+			}
+		}
+	}
+	return counts
+}
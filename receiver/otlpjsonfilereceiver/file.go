@@ -5,6 +5,7 @@ package otlpjsonfilereceiver // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -12,7 +13,6 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
-	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
@@ -42,11 +42,20 @@ type Config struct {
 	fileconsumer.Config `mapstructure:",squash"`
 	StorageID           *component.ID `mapstructure:"storage"`
 	ReplayFile          bool          `mapstructure:"replay_file"`
+	// Format is the encoding of the messages, json or proto, as written by the file exporter.
+	Format string `mapstructure:"format"`
+	// MessageCompression is the compression of every message, as written by the file exporter.
+	// Only zstd is supported.
+	MessageCompression string `mapstructure:"message_compression"`
+	// Pacing re-emits the messages respecting their original timing.
+	Pacing PacingConfig `mapstructure:"pacing"`
 }
 
 func createDefaultConfig() component.Config {
 	return &Config{
 		Config: *fileconsumer.NewConfig(),
+		Format: formatJSON,
+		Pacing: PacingConfig{SpeedMultiplier: 1},
 	}
 }
 
@@ -69,7 +78,6 @@ func (f *otlpjsonfilereceiver) Shutdown(_ context.Context) error {
 }
 
 func createLogsReceiver(_ context.Context, settings receiver.Settings, configuration component.Config, logs consumer.Logs) (receiver.Logs, error) {
-	logsUnmarshaler := &plog.JSONUnmarshaler{}
 	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
 		ReceiverID:             settings.ID,
 		Transport:              transport,
@@ -79,15 +87,15 @@ func createLogsReceiver(_ context.Context, settings receiver.Settings, configura
 		return nil, err
 	}
 	cfg := configuration.(*Config)
-	opts := make([]fileconsumer.Option, 0)
-	if cfg.ReplayFile {
-		opts = append(opts, fileconsumer.WithNoTracking())
-	}
-	input, err := cfg.Build(settings.TelemetrySettings, func(ctx context.Context, tokens [][]byte, attributes map[string]any, _ int64, _ []int64) error {
+	logsUnmarshaler := cfg.logsUnmarshaler()
+	p := newPacer(cfg.Pacing)
+	input, err := cfg.buildInput(settings.TelemetrySettings, func(ctx context.Context, tokens [][]byte, attributes map[string]any, _ int64, _ []int64) error {
 		for _, token := range tokens {
 			ctx = obsrecv.StartLogsOp(ctx)
 			var l plog.Logs
-			l, err = logsUnmarshaler.UnmarshalLogs(token)
+			if token, err = cfg.decodeMessage(token); err == nil {
+				l, err = logsUnmarshaler.UnmarshalLogs(token)
+			}
 			if err != nil {
 				obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, err)
 			} else {
@@ -104,13 +112,15 @@ func createLogsReceiver(_ context.Context, settings receiver.Settings, configura
 							}
 						}
 					}
-					err = logs.ConsumeLogs(ctx, l)
+					if err = p.pace(ctx, logsTimestamp(l), func(offset time.Duration) { shiftLogs(l, offset) }); err == nil {
+						err = logs.ConsumeLogs(ctx, l)
+					}
 				}
 				obsrecv.EndLogsOp(ctx, metadata.Type.String(), logRecordCount, err)
 			}
 		}
 		return nil
-	}, opts...)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +129,6 @@ func createLogsReceiver(_ context.Context, settings receiver.Settings, configura
 }
 
 func createMetricsReceiver(_ context.Context, settings receiver.Settings, configuration component.Config, metrics consumer.Metrics) (receiver.Metrics, error) {
-	metricsUnmarshaler := &pmetric.JSONUnmarshaler{}
 	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
 		ReceiverID:             settings.ID,
 		Transport:              transport,
@@ -129,15 +138,15 @@ func createMetricsReceiver(_ context.Context, settings receiver.Settings, config
 		return nil, err
 	}
 	cfg := configuration.(*Config)
-	opts := make([]fileconsumer.Option, 0)
-	if cfg.ReplayFile {
-		opts = append(opts, fileconsumer.WithNoTracking())
-	}
-	input, err := cfg.Build(settings.TelemetrySettings, func(ctx context.Context, tokens [][]byte, attributes map[string]any, _ int64, _ []int64) error {
+	metricsUnmarshaler := cfg.metricsUnmarshaler()
+	p := newPacer(cfg.Pacing)
+	input, err := cfg.buildInput(settings.TelemetrySettings, func(ctx context.Context, tokens [][]byte, attributes map[string]any, _ int64, _ []int64) error {
 		for _, token := range tokens {
 			ctx = obsrecv.StartMetricsOp(ctx)
 			var m pmetric.Metrics
-			m, err = metricsUnmarshaler.UnmarshalMetrics(token)
+			if token, err = cfg.decodeMessage(token); err == nil {
+				m, err = metricsUnmarshaler.UnmarshalMetrics(token)
+			}
 			if err != nil {
 				obsrecv.EndMetricsOp(ctx, metadata.Type.String(), 0, err)
 			} else {
@@ -153,13 +162,15 @@ func createMetricsReceiver(_ context.Context, settings receiver.Settings, config
 							}
 						}
 					}
-					err = metrics.ConsumeMetrics(ctx, m)
+					if err = p.pace(ctx, metricsTimestamp(m), func(offset time.Duration) { shiftMetrics(m, offset) }); err == nil {
+						err = metrics.ConsumeMetrics(ctx, m)
+					}
 				}
 				obsrecv.EndMetricsOp(ctx, metadata.Type.String(), m.MetricCount(), err)
 			}
 		}
 		return nil
-	}, opts...)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -168,7 +179,6 @@ func createMetricsReceiver(_ context.Context, settings receiver.Settings, config
 }
 
 func createTracesReceiver(_ context.Context, settings receiver.Settings, configuration component.Config, traces consumer.Traces) (receiver.Traces, error) {
-	tracesUnmarshaler := &ptrace.JSONUnmarshaler{}
 	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
 		ReceiverID:             settings.ID,
 		Transport:              transport,
@@ -178,15 +188,15 @@ func createTracesReceiver(_ context.Context, settings receiver.Settings, configu
 		return nil, err
 	}
 	cfg := configuration.(*Config)
-	opts := make([]fileconsumer.Option, 0)
-	if cfg.ReplayFile {
-		opts = append(opts, fileconsumer.WithNoTracking())
-	}
-	input, err := cfg.Build(settings.TelemetrySettings, func(ctx context.Context, tokens [][]byte, attributes map[string]any, _ int64, _ []int64) error {
+	tracesUnmarshaler := cfg.tracesUnmarshaler()
+	p := newPacer(cfg.Pacing)
+	input, err := cfg.buildInput(settings.TelemetrySettings, func(ctx context.Context, tokens [][]byte, attributes map[string]any, _ int64, _ []int64) error {
 		for _, token := range tokens {
 			ctx = obsrecv.StartTracesOp(ctx)
 			var t ptrace.Traces
-			t, err = tracesUnmarshaler.UnmarshalTraces(token)
+			if token, err = cfg.decodeMessage(token); err == nil {
+				t, err = tracesUnmarshaler.UnmarshalTraces(token)
+			}
 			if err != nil {
 				obsrecv.EndTracesOp(ctx, metadata.Type.String(), 0, err)
 			} else {
@@ -202,13 +212,15 @@ func createTracesReceiver(_ context.Context, settings receiver.Settings, configu
 							}
 						}
 					}
-					err = traces.ConsumeTraces(ctx, t)
+					if err = p.pace(ctx, tracesTimestamp(t), func(offset time.Duration) { shiftTraces(t, offset) }); err == nil {
+						err = traces.ConsumeTraces(ctx, t)
+					}
 				}
 				obsrecv.EndTracesOp(ctx, metadata.Type.String(), t.SpanCount(), err)
 			}
 		}
 		return nil
-	}, opts...)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -217,22 +229,26 @@ func createTracesReceiver(_ context.Context, settings receiver.Settings, configu
 }
 
 func createProfilesReceiver(_ context.Context, settings receiver.Settings, configuration component.Config, profiles xconsumer.Profiles) (xreceiver.Profiles, error) {
-	profilesUnmarshaler := &pprofile.JSONUnmarshaler{}
 	cfg := configuration.(*Config)
-	opts := make([]fileconsumer.Option, 0)
-	if cfg.ReplayFile {
-		opts = append(opts, fileconsumer.WithNoTracking())
-	}
-	input, err := cfg.Build(settings.TelemetrySettings, func(ctx context.Context, tokens [][]byte, _ map[string]any, _ int64, _ []int64) error {
+	profilesUnmarshaler := cfg.profilesUnmarshaler()
+	p := newPacer(cfg.Pacing)
+	input, err := cfg.buildInput(settings.TelemetrySettings, func(ctx context.Context, tokens [][]byte, _ map[string]any, _ int64, _ []int64) error {
 		for _, token := range tokens {
-			p, _ := profilesUnmarshaler.UnmarshalProfiles(token)
+			token, err := cfg.decodeMessage(token)
+			if err != nil {
+				continue
+			}
+			pd, _ := profilesUnmarshaler.UnmarshalProfiles(token)
 			// TODO Append token.Attributes
-			if p.ResourceProfiles().Len() != 0 {
-				_ = profiles.ConsumeProfiles(ctx, p)
+			if pd.ResourceProfiles().Len() != 0 {
+				if err = p.pace(ctx, profilesTimestamp(pd), func(offset time.Duration) { shiftProfiles(pd, offset) }); err != nil {
+					return nil
+				}
+				_ = profiles.ConsumeProfiles(ctx, pd)
 			}
 		}
 		return nil
-	}, opts...)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -4,11 +4,13 @@
 package otlpjsonfilereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpjsonfilereceiver"
 
 import (
+	"encoding/binary"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
@@ -214,6 +216,8 @@ func testdataConfigYamlAsMap() *Config {
 				Exclude: []string{"/var/log/example.log"},
 			},
 		},
+		Format: "json",
+		Pacing: PacingConfig{SpeedMultiplier: 1},
 	}
 }
 
@@ -368,3 +372,145 @@ func TestEmptyLine(t *testing.T) {
 		}, time.Second, 10*time.Millisecond)
 	})
 }
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	require.NoError(t, cfg.Validate())
+
+	cfg.Format = "proto"
+	cfg.MessageCompression = "zstd"
+	require.NoError(t, cfg.Validate())
+
+	cfg.Format = "avro"
+	require.EqualError(t, cfg.Validate(), `unsupported format: "avro"`)
+
+	cfg.Format = "proto"
+	cfg.MessageCompression = "gzip"
+	require.EqualError(t, cfg.Validate(), `unsupported message_compression: "gzip"`)
+}
+
+// lengthPrefixed returns the messages preceded by their size, as written by the file exporter
+func lengthPrefixed(t *testing.T, compress bool, messages ...[]byte) []byte {
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	defer encoder.Close()
+
+	var b []byte
+	for _, message := range messages {
+		if compress {
+			message = encoder.EncodeAll(message, nil)
+		}
+		b = binary.BigEndian.AppendUint32(b, uint32(len(message)))
+		b = append(b, message...)
+	}
+	return b
+}
+
+func TestFileTracesReceiverProto(t *testing.T) {
+	tempFolder := t.TempDir()
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = []string{filepath.Join(tempFolder, "*")}
+	cfg.StartAt = "beginning"
+	cfg.IncludeFileName = false
+	cfg.Format = "proto"
+	sink := new(consumertest.TracesSink)
+	receiver, err := NewFactory().CreateTraces(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(t.Context(), nil))
+	t.Cleanup(func() {
+		assert.NoError(t, receiver.Shutdown(t.Context()))
+	})
+
+	// The messages end with new lines and spaces, which must not be trimmed
+	td := testdata.GenerateTraces(2)
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("operation \n")
+	marshaler := &ptrace.ProtoMarshaler{}
+	b, err := marshaler.MarshalTraces(td)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(tempFolder, "traces.proto"), lengthPrefixed(t, false, b, b), 0o600))
+
+	require.EventuallyWithT(t, func(tt *assert.CollectT) {
+		assert.Len(tt, sink.AllTraces(), 2)
+	}, 3*time.Second, 10*time.Millisecond)
+	assert.Equal(t, td, sink.AllTraces()[0])
+	assert.Equal(t, td, sink.AllTraces()[1])
+}
+
+func TestFileMetricsReceiverCompressedProto(t *testing.T) {
+	tempFolder := t.TempDir()
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = []string{filepath.Join(tempFolder, "*")}
+	cfg.StartAt = "beginning"
+	cfg.Format = "proto"
+	cfg.MessageCompression = "zstd"
+	cfg.ReplayFile = true
+	sink := new(consumertest.MetricsSink)
+	receiver, err := NewFactory().CreateMetrics(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(t.Context(), nil))
+	t.Cleanup(func() {
+		assert.NoError(t, receiver.Shutdown(t.Context()))
+	})
+
+	md := testdata.GenerateMetrics(3)
+	marshaler := &pmetric.ProtoMarshaler{}
+	b, err := marshaler.MarshalMetrics(md)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(tempFolder, "metrics.proto.zst"), lengthPrefixed(t, true, b), 0o600))
+
+	// The file is replayed on every poll
+	require.EventuallyWithT(t, func(tt *assert.CollectT) {
+		assert.GreaterOrEqual(tt, len(sink.AllMetrics()), 2)
+	}, 3*time.Second, 10*time.Millisecond)
+	assert.Equal(t, md.MetricCount(), sink.AllMetrics()[0].MetricCount())
+}
+
+func TestFileLogsReceiverCompressedJSON(t *testing.T) {
+	tempFolder := t.TempDir()
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = []string{filepath.Join(tempFolder, "*")}
+	cfg.StartAt = "beginning"
+	cfg.IncludeFileName = false
+	cfg.MessageCompression = "zstd"
+	sink := new(consumertest.LogsSink)
+	receiver, err := NewFactory().CreateLogs(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(t.Context(), nil))
+	t.Cleanup(func() {
+		assert.NoError(t, receiver.Shutdown(t.Context()))
+	})
+
+	ld := testdata.GenerateLogs(5)
+	marshaler := &plog.JSONMarshaler{}
+	b, err := marshaler.MarshalLogs(ld)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(tempFolder, "logs.json.zst"), lengthPrefixed(t, true, b), 0o600))
+
+	require.EventuallyWithT(t, func(tt *assert.CollectT) {
+		assert.Len(tt, sink.AllLogs(), 1)
+	}, 3*time.Second, 10*time.Millisecond)
+	assert.Equal(t, ld, sink.AllLogs()[0])
+}
+
+func TestSplitLengthPrefixed(t *testing.T) {
+	split := splitLengthPrefixed(16)
+	message := lengthPrefixed(t, false, []byte("message"), []byte("next"))
+
+	// The message is only returned once it is entirely written
+	advance, token, err := split(message[:3], true)
+	require.NoError(t, err)
+	assert.Zero(t, advance)
+	assert.Nil(t, token)
+	advance, token, err = split(message[:10], true)
+	require.NoError(t, err)
+	assert.Zero(t, advance)
+	assert.Nil(t, token)
+
+	advance, token, err = split(message, false)
+	require.NoError(t, err)
+	assert.Equal(t, 11, advance)
+	assert.Equal(t, []byte("message"), token)
+
+	_, _, err = split(lengthPrefixed(t, false, make([]byte, 13)), false)
+	require.EqualError(t, err, "message of 13 bytes exceeds max_log_size")
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpjsonfilereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpjsonfilereceiver"
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/klauspost/compress/zstd"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+)
+
+const (
+	formatJSON  = "json"
+	formatProto = "proto"
+
+	compressionZstd = "zstd"
+
+	// messageSizeLength is the length of the big endian size preceding every length-prefixed message
+	messageSizeLength = 4
+)
+
+// zstdDecoder decompresses the messages, it is safe for concurrent use through DecodeAll
+var zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+
+func (c *Config) Validate() error {
+	if c.Format != formatJSON && c.Format != formatProto {
+		return fmt.Errorf("unsupported format: %q", c.Format)
+	}
+	if c.MessageCompression != "" && c.MessageCompression != compressionZstd {
+		return fmt.Errorf("unsupported message_compression: %q", c.MessageCompression)
+	}
+	return nil
+}
+
+// lengthPrefixed reports whether the messages are preceded by their size instead of being separated by new lines,
+// as they are written by the file exporter for protobuf or compressed messages.
+func (c *Config) lengthPrefixed() bool {
+	return c.Format == formatProto || c.MessageCompression != ""
+}
+
+// buildInput builds the file consumer, splitting the files into the messages of the configured format.
+func (c *Config) buildInput(set component.TelemetrySettings, callback emit.Callback) (*fileconsumer.Manager, error) {
+	fcCfg := c.Config
+	opts := make([]fileconsumer.Option, 0)
+	if c.ReplayFile {
+		opts = append(opts, fileconsumer.WithNoTracking())
+	}
+	if c.lengthPrefixed() {
+		// The messages are binary, they must be neither decoded nor trimmed, and a message must not be
+		// flushed before it is entirely written.
+		fcCfg.Encoding = "nop"
+		fcCfg.FlushPeriod = 0
+		opts = append(opts, fileconsumer.WithSplitFunc(splitLengthPrefixed(int(fcCfg.MaxLogSize))))
+	}
+	return fcCfg.Build(set, callback, opts...)
+}
+
+// splitLengthPrefixed splits the messages preceded by their size as a big endian unsigned 32 bit integer.
+func splitLengthPrefixed(maxLogSize int) bufio.SplitFunc {
+	return func(data []byte, _ bool) (int, []byte, error) {
+		if len(data) < messageSizeLength {
+			return 0, nil, nil
+		}
+		size := int(binary.BigEndian.Uint32(data))
+		if messageSizeLength+size > maxLogSize {
+			return 0, nil, fmt.Errorf("message of %d bytes exceeds max_log_size", size)
+		}
+		if len(data) < messageSizeLength+size {
+			return 0, nil, nil
+		}
+		return messageSizeLength + size, data[messageSizeLength : messageSizeLength+size], nil
+	}
+}
+
+// decodeMessage decompresses the message if needed.
+func (c *Config) decodeMessage(token []byte) ([]byte, error) {
+	if c.MessageCompression == compressionZstd {
+		return zstdDecoder.DecodeAll(token, nil)
+	}
+	return token, nil
+}
+
+func (c *Config) logsUnmarshaler() plog.Unmarshaler {
+	if c.Format == formatProto {
+		return &plog.ProtoUnmarshaler{}
+	}
+	return &plog.JSONUnmarshaler{}
+}
+
+func (c *Config) metricsUnmarshaler() pmetric.Unmarshaler {
+	if c.Format == formatProto {
+		return &pmetric.ProtoUnmarshaler{}
+	}
+	return &pmetric.JSONUnmarshaler{}
+}
+
+func (c *Config) tracesUnmarshaler() ptrace.Unmarshaler {
+	if c.Format == formatProto {
+		return &ptrace.ProtoUnmarshaler{}
+	}
+	return &ptrace.JSONUnmarshaler{}
+}
+
+func (c *Config) profilesUnmarshaler() pprofile.Unmarshaler {
+	if c.Format == formatProto {
+		return &pprofile.ProtoUnmarshaler{}
+	}
+	return &pprofile.JSONUnmarshaler{}
+}
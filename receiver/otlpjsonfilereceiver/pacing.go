@@ -0,0 +1,258 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpjsonfilereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpjsonfilereceiver"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// PacingConfig defines how the messages are paced when they are replayed.
+type PacingConfig struct {
+	// Enabled re-emits the messages respecting the time elapsed between their original timestamps,
+	// instead of emitting them as fast as possible.
+	Enabled bool `mapstructure:"enabled"`
+	// SpeedMultiplier speeds up the replay when greater than 1 and slows it down when lower than 1.
+	SpeedMultiplier float64 `mapstructure:"speed_multiplier"`
+	// ShiftTimestamps shifts the timestamps of every message so that it appears to be emitted now,
+	// the time elapsed between the timestamps of a message is preserved.
+	ShiftTimestamps bool `mapstructure:"shift_timestamps"`
+}
+
+func (c PacingConfig) Validate() error {
+	if c.Enabled && c.SpeedMultiplier <= 0 {
+		return errors.New("speed_multiplier must be greater than 0")
+	}
+	return nil
+}
+
+// pacer delays the messages according to their original timestamps. The timeline starts with the first
+// message, and restarts when a message is older than the first message of the timeline, which happens
+// when a file is replayed again.
+type pacer struct {
+	cfg PacingConfig
+	now func() time.Time
+	// wait blocks for the given delay, or returns an error if the context is done before
+	wait func(ctx context.Context, delay time.Duration) error
+
+	mu sync.Mutex
+	// start is the timestamp of the first message of the timeline
+	start pcommon.Timestamp
+	// startTime is the time at which the first message of the timeline was emitted
+	startTime time.Time
+}
+
+// newPacer returns nil if pacing is disabled, the messages are then emitted as fast as possible.
+func newPacer(cfg PacingConfig) *pacer {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &pacer{cfg: cfg, now: time.Now, wait: wait}
+}
+
+func wait(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// pace blocks until the message with the given timestamp is due, or returns an error if the context is done
+// before. The timestamps of the message are then shifted with the shift function if configured.
+func (p *pacer) pace(ctx context.Context, timestamp pcommon.Timestamp, shift func(offset time.Duration)) error {
+	if p == nil || timestamp == 0 {
+		return nil
+	}
+
+	p.mu.Lock()
+	if p.startTime.IsZero() || timestamp < p.start {
+		p.start, p.startTime = timestamp, p.now()
+	}
+	due := p.startTime.Add(time.Duration(float64(timestamp-p.start) / p.cfg.SpeedMultiplier))
+	p.mu.Unlock()
+
+	if delay := due.Sub(p.now()); delay > 0 {
+		if err := p.wait(ctx, delay); err != nil {
+			return err
+		}
+	}
+
+	if p.cfg.ShiftTimestamps {
+		shift(p.now().Sub(timestamp.AsTime()))
+	}
+	return nil
+}
+
+// shiftTimestamp adds the offset to the timestamp, unless it is not set.
+func shiftTimestamp(timestamp pcommon.Timestamp, offset time.Duration) pcommon.Timestamp {
+	if timestamp == 0 {
+		return 0
+	}
+	return pcommon.Timestamp(int64(timestamp) + offset.Nanoseconds())
+}
+
+// earliest returns the earliest of the timestamps that are set.
+func earliest(current, timestamp pcommon.Timestamp) pcommon.Timestamp {
+	if timestamp != 0 && (current == 0 || timestamp < current) {
+		return timestamp
+	}
+	return current
+}
+
+// logsTimestamp returns the earliest timestamp of the log records, or their observed timestamp when it is not set.
+func logsTimestamp(l plog.Logs) pcommon.Timestamp {
+	var timestamp pcommon.Timestamp
+	rangeLogRecords(l, func(lr plog.LogRecord) {
+		if lr.Timestamp() != 0 {
+			timestamp = earliest(timestamp, lr.Timestamp())
+		} else {
+			timestamp = earliest(timestamp, lr.ObservedTimestamp())
+		}
+	})
+	return timestamp
+}
+
+func shiftLogs(l plog.Logs, offset time.Duration) {
+	rangeLogRecords(l, func(lr plog.LogRecord) {
+		lr.SetTimestamp(shiftTimestamp(lr.Timestamp(), offset))
+		lr.SetObservedTimestamp(shiftTimestamp(lr.ObservedTimestamp(), offset))
+	})
+}
+
+func rangeLogRecords(l plog.Logs, fn func(plog.LogRecord)) {
+	for _, rl := range l.ResourceLogs().All() {
+		for _, sl := range rl.ScopeLogs().All() {
+			for _, lr := range sl.LogRecords().All() {
+				fn(lr)
+			}
+		}
+	}
+}
+
+// metricsTimestamp returns the earliest timestamp of the data points.
+func metricsTimestamp(m pmetric.Metrics) pcommon.Timestamp {
+	var timestamp pcommon.Timestamp
+	rangeDataPoints(m, func(dp dataPoint) {
+		timestamp = earliest(timestamp, dp.Timestamp())
+	})
+	return timestamp
+}
+
+func shiftMetrics(m pmetric.Metrics, offset time.Duration) {
+	rangeDataPoints(m, func(dp dataPoint) {
+		dp.SetStartTimestamp(shiftTimestamp(dp.StartTimestamp(), offset))
+		dp.SetTimestamp(shiftTimestamp(dp.Timestamp(), offset))
+		// the summary data points are the only ones without exemplars
+		if dp, ok := dp.(interface{ Exemplars() pmetric.ExemplarSlice }); ok {
+			for _, exemplar := range dp.Exemplars().All() {
+				exemplar.SetTimestamp(shiftTimestamp(exemplar.Timestamp(), offset))
+			}
+		}
+	})
+}
+
+// dataPoint is implemented by the data points of every metric type.
+type dataPoint interface {
+	StartTimestamp() pcommon.Timestamp
+	Timestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	SetTimestamp(pcommon.Timestamp)
+}
+
+func rangeDataPoints(m pmetric.Metrics, fn func(dataPoint)) {
+	for _, rm := range m.ResourceMetrics().All() {
+		for _, sm := range rm.ScopeMetrics().All() {
+			for _, metric := range sm.Metrics().All() {
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					for _, dp := range metric.Gauge().DataPoints().All() {
+						fn(dp)
+					}
+				case pmetric.MetricTypeSum:
+					for _, dp := range metric.Sum().DataPoints().All() {
+						fn(dp)
+					}
+				case pmetric.MetricTypeHistogram:
+					for _, dp := range metric.Histogram().DataPoints().All() {
+						fn(dp)
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					for _, dp := range metric.ExponentialHistogram().DataPoints().All() {
+						fn(dp)
+					}
+				case pmetric.MetricTypeSummary:
+					for _, dp := range metric.Summary().DataPoints().All() {
+						fn(dp)
+					}
+				}
+			}
+		}
+	}
+}
+
+// tracesTimestamp returns the earliest start timestamp of the spans.
+func tracesTimestamp(t ptrace.Traces) pcommon.Timestamp {
+	var timestamp pcommon.Timestamp
+	rangeSpans(t, func(span ptrace.Span) {
+		timestamp = earliest(timestamp, span.StartTimestamp())
+	})
+	return timestamp
+}
+
+func shiftTraces(t ptrace.Traces, offset time.Duration) {
+	rangeSpans(t, func(span ptrace.Span) {
+		span.SetStartTimestamp(shiftTimestamp(span.StartTimestamp(), offset))
+		span.SetEndTimestamp(shiftTimestamp(span.EndTimestamp(), offset))
+		for _, event := range span.Events().All() {
+			event.SetTimestamp(shiftTimestamp(event.Timestamp(), offset))
+		}
+	})
+}
+
+func rangeSpans(t ptrace.Traces, fn func(ptrace.Span)) {
+	for _, rs := range t.ResourceSpans().All() {
+		for _, ss := range rs.ScopeSpans().All() {
+			for _, span := range ss.Spans().All() {
+				fn(span)
+			}
+		}
+	}
+}
+
+// profilesTimestamp returns the earliest time of the profiles.
+func profilesTimestamp(p pprofile.Profiles) pcommon.Timestamp {
+	var timestamp pcommon.Timestamp
+	rangeProfiles(p, func(profile pprofile.Profile) {
+		timestamp = earliest(timestamp, profile.Time())
+	})
+	return timestamp
+}
+
+func shiftProfiles(p pprofile.Profiles, offset time.Duration) {
+	rangeProfiles(p, func(profile pprofile.Profile) {
+		profile.SetTime(shiftTimestamp(profile.Time(), offset))
+	})
+}
+
+func rangeProfiles(p pprofile.Profiles, fn func(pprofile.Profile)) {
+	for _, rp := range p.ResourceProfiles().All() {
+		for _, sp := range rp.ScopeProfiles().All() {
+			for _, profile := range sp.Profiles().All() {
+				fn(profile)
+			}
+		}
+	}
+}
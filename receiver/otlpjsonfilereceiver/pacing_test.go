@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpjsonfilereceiver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpjsonfilereceiver/internal/metadata"
+)
+
+func TestPacingConfigValidate(t *testing.T) {
+	require.NoError(t, PacingConfig{}.Validate())
+	require.NoError(t, PacingConfig{Enabled: true, SpeedMultiplier: 0.5}.Validate())
+	require.EqualError(t, PacingConfig{Enabled: true}.Validate(), "speed_multiplier must be greater than 0")
+}
+
+func TestPacer(t *testing.T) {
+	assert.Nil(t, newPacer(PacingConfig{SpeedMultiplier: 1}))
+
+	p := newPacer(PacingConfig{Enabled: true, SpeedMultiplier: 10, ShiftTimestamps: true})
+	now := time.Now()
+	var delays []time.Duration
+	p.now = func() time.Time { return now }
+	p.wait = func(ctx context.Context, delay time.Duration) error {
+		delays = append(delays, delay)
+		now = now.Add(delay)
+		return ctx.Err()
+	}
+	start := pcommon.NewTimestampFromTime(now.Add(-time.Hour))
+	var offset time.Duration
+	shift := func(o time.Duration) { offset = o }
+
+	// The first message starts the timeline
+	require.NoError(t, p.pace(t.Context(), start, shift))
+	assert.Empty(t, delays)
+	assert.Equal(t, time.Hour, offset)
+
+	// A message 2 seconds later is due 200 milliseconds later
+	now = now.Add(50 * time.Millisecond)
+	require.NoError(t, p.pace(t.Context(), start+pcommon.Timestamp(2*time.Second), shift))
+	assert.Equal(t, []time.Duration{150 * time.Millisecond}, delays)
+	assert.Equal(t, time.Hour-2*time.Second+200*time.Millisecond, offset)
+
+	// A message that is already due is not delayed
+	now = now.Add(time.Second)
+	require.NoError(t, p.pace(t.Context(), start+pcommon.Timestamp(3*time.Second), shift))
+	assert.Len(t, delays, 1)
+
+	// An older message restarts the timeline
+	require.NoError(t, p.pace(t.Context(), start-pcommon.Timestamp(time.Second), shift))
+	require.NoError(t, p.pace(t.Context(), start+pcommon.Timestamp(time.Second), shift))
+	assert.Equal(t, []time.Duration{150 * time.Millisecond, 200 * time.Millisecond}, delays)
+
+	// Messages without timestamp are not delayed
+	require.NoError(t, p.pace(t.Context(), 0, shift))
+	assert.Len(t, delays, 2)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	require.ErrorIs(t, p.pace(ctx, start+pcommon.Timestamp(time.Hour), shift), context.Canceled)
+}
+
+func TestWait(t *testing.T) {
+	require.NoError(t, wait(t.Context(), time.Millisecond))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	require.ErrorIs(t, wait(ctx, time.Hour), context.Canceled)
+}
+
+func TestShiftTimestamps(t *testing.T) {
+	td := testdata.GenerateTraces(2)
+	span := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	timestamp, end := tracesTimestamp(td), span.EndTimestamp()
+	assert.Equal(t, span.StartTimestamp(), timestamp)
+	shiftTraces(td, time.Hour)
+	assert.Equal(t, timestamp+pcommon.Timestamp(time.Hour), tracesTimestamp(td))
+	assert.Equal(t, end+pcommon.Timestamp(time.Hour), span.EndTimestamp())
+
+	md := testdata.GenerateMetrics(5)
+	timestamp = metricsTimestamp(md)
+	require.NotZero(t, timestamp)
+	exemplar := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).Exemplars().AppendEmpty()
+	exemplar.SetTimestamp(timestamp)
+	shiftMetrics(md, -time.Minute)
+	assert.Equal(t, timestamp-pcommon.Timestamp(time.Minute), metricsTimestamp(md))
+	assert.Equal(t, timestamp-pcommon.Timestamp(time.Minute), exemplar.Timestamp())
+
+	ld := testdata.GenerateLogs(2)
+	timestamp = logsTimestamp(ld)
+	require.NotZero(t, timestamp)
+	shiftLogs(ld, time.Second)
+	assert.Equal(t, timestamp+pcommon.Timestamp(time.Second), logsTimestamp(ld))
+}
+
+func TestFileTracesReceiverWithPacing(t *testing.T) {
+	tempFolder := t.TempDir()
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = []string{filepath.Join(tempFolder, "*")}
+	cfg.StartAt = "beginning"
+	cfg.IncludeFileName = false
+	cfg.Pacing = PacingConfig{Enabled: true, SpeedMultiplier: 4, ShiftTimestamps: true}
+	sink := new(consumertest.TracesSink)
+	receiver, err := NewFactory().CreateTraces(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(t.Context(), nil))
+	t.Cleanup(func() {
+		assert.NoError(t, receiver.Shutdown(t.Context()))
+	})
+
+	// The spans were recorded a day ago, one second apart
+	start := time.Now().Add(-24 * time.Hour)
+	var b []byte
+	for i := range 2 {
+		td := ptrace.NewTraces()
+		span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(start.Add(time.Duration(i) * time.Second)))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(time.Duration(i)*time.Second + 100*time.Millisecond)))
+		buf, err := (&ptrace.JSONMarshaler{}).MarshalTraces(td)
+		require.NoError(t, err)
+		b = append(append(b, buf...), '\n')
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(tempFolder, "traces.json"), b, 0o600))
+
+	require.EventuallyWithT(t, func(tt *assert.CollectT) {
+		assert.Len(tt, sink.AllTraces(), 2)
+	}, 3*time.Second, 10*time.Millisecond)
+
+	// The spans are emitted 250 milliseconds apart, and appear to be recorded when they are emitted
+	first := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	second := sink.AllTraces()[1].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.InDelta(t, time.Now().UnixNano(), int64(second.StartTimestamp()), float64(time.Second))
+	assert.GreaterOrEqual(t, second.StartTimestamp().AsTime().Sub(first.StartTimestamp().AsTime()), 250*time.Millisecond)
+	assert.Equal(t, 100*time.Millisecond, second.EndTimestamp().AsTime().Sub(second.StartTimestamp().AsTime()))
+}
+
+func TestFileMetricsReceiverWithPacingDisabled(t *testing.T) {
+	tempFolder := t.TempDir()
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = []string{filepath.Join(tempFolder, "*")}
+	cfg.StartAt = "beginning"
+	sink := new(consumertest.MetricsSink)
+	receiver, err := NewFactory().CreateMetrics(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(t.Context(), nil))
+	t.Cleanup(func() {
+		assert.NoError(t, receiver.Shutdown(t.Context()))
+	})
+
+	md := testdata.GenerateMetrics(1)
+	timestamp := metricsTimestamp(md)
+	b, err := (&pmetric.JSONMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(tempFolder, "metrics.json"), append(b, '\n'), 0o600))
+
+	require.EventuallyWithT(t, func(tt *assert.CollectT) {
+		assert.Len(tt, sink.AllMetrics(), 1)
+	}, 3*time.Second, 10*time.Millisecond)
+	assert.Equal(t, timestamp, metricsTimestamp(sink.AllMetrics()[0]))
+}
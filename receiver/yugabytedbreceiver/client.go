@@ -0,0 +1,316 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package yugabytedbreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/yugabytedbreceiver"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq" // registers the "postgres" driver used to connect to YSQL
+	"go.uber.org/multierr"
+)
+
+// client is the interface used by the scraper to query YugabyteDB
+type client interface {
+	getRunningQueries(ctx context.Context) ([]nodeCount, error)
+	getActiveConnections(ctx context.Context) ([]nodeCount, error)
+	getConnectionsByStateAndUser(ctx context.Context) ([]connectionMetric, error)
+	getActiveUsers(ctx context.Context) ([]userSessions, error)
+	getStatements(ctx context.Context, topN int) ([]statementStats, error)
+	getLockWaits(ctx context.Context) ([]lockWaits, error)
+	getTransactions(ctx context.Context, longRunningThreshold time.Duration) ([]transactionStats, error)
+	getServers(ctx context.Context) ([]nodeInfo, error)
+	Close() error
+}
+
+// newClientFunc creates the client used by the scraper, allowing tests to replace it
+type newClientFunc func(cfg *Config) (client, error)
+
+// nodeInfo identifies the tserver that reported a row of a global view
+type nodeInfo struct {
+	host   string
+	zone   string
+	region string
+	cloud  string
+}
+
+// nodeCount represents a count reported by a single node
+type nodeCount struct {
+	node  nodeInfo
+	count int64
+}
+
+// connectionMetric represents connection count grouped by state and user
+type connectionMetric struct {
+	node  nodeInfo
+	state string
+	user  string
+	count int64
+}
+
+// userSessions represents the number of active sessions of a user
+type userSessions struct {
+	node  nodeInfo
+	user  string
+	count int64
+}
+
+// statementStats represents the pg_stat_statements statistics of a normalized statement
+type statementStats struct {
+	node      nodeInfo
+	queryID   string
+	user      string
+	database  string
+	query     string
+	calls     int64
+	totalTime float64 // milliseconds
+	meanTime  float64 // milliseconds
+	rows      int64
+}
+
+// lockWaits represents the number of locks of a type that are waited for
+type lockWaits struct {
+	node     nodeInfo
+	lockType string
+	count    int64
+}
+
+// transactionStats represents the open transactions of a node
+type transactionStats struct {
+	node        nodeInfo
+	oldestAge   int64 // seconds
+	longRunning int64
+}
+
+// querySet holds the queries used in either local or global-view mode
+type querySet struct {
+	runningQueries            string
+	activeConnections         string
+	connectionsByStateAndUser string
+	activeUserCount           string
+	statements                string
+	lockWaits                 string
+	transactions              string
+}
+
+var (
+	localQueries = querySet{
+		runningQueries:            runningQueriesQuery,
+		activeConnections:         activeConnectionsQuery,
+		connectionsByStateAndUser: connectionsByStateAndUserQuery,
+		activeUserCount:           activeUserCountQuery,
+		statements:                statementsQuery,
+		lockWaits:                 lockWaitsQuery,
+		transactions:              transactionsQuery,
+	}
+	globalViewQueries = querySet{
+		runningQueries:            globalViewRunningQueriesQuery,
+		activeConnections:         globalViewActiveConnectionsQuery,
+		connectionsByStateAndUser: globalViewConnectionsByStateAndUserQuery,
+		activeUserCount:           globalViewActiveUserCountQuery,
+		statements:                globalViewStatementsQuery,
+		lockWaits:                 globalViewLockWaitsQuery,
+		transactions:              globalViewTransactionsQuery,
+	}
+)
+
+// yugabyteDBClient queries YSQL through a connection pool that is kept open across scrapes
+type yugabyteDBClient struct {
+	db            *sql.DB
+	useGlobalView bool
+	queries       querySet
+	// localNode is reported for every row when global views are disabled
+	localNode nodeInfo
+}
+
+var _ client = (*yugabyteDBClient)(nil)
+
+func newYugabyteDBClient(cfg *Config) (client, error) {
+	db, err := sql.Open("postgres", connectionString(cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.ConnectionPool.MaxOpen > 0 {
+		db.SetMaxOpenConns(cfg.ConnectionPool.MaxOpen)
+	}
+	if cfg.ConnectionPool.MaxIdle > 0 {
+		db.SetMaxIdleConns(cfg.ConnectionPool.MaxIdle)
+	}
+	if cfg.ConnectionPool.MaxLifetime > 0 {
+		db.SetConnMaxLifetime(cfg.ConnectionPool.MaxLifetime)
+	}
+	if cfg.ConnectionPool.MaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(cfg.ConnectionPool.MaxIdleTime)
+	}
+
+	c := &yugabyteDBClient{
+		db:            db,
+		useGlobalView: cfg.UseGlobalView,
+		queries:       globalViewQueries,
+	}
+	if !cfg.UseGlobalView {
+		c.queries = localQueries
+		c.localNode = nodeInfo{host: cfg.Host}
+	}
+	return c, nil
+}
+
+// connectionString builds the YSQL data source name. It contains the password and must never be logged.
+func connectionString(cfg *Config) string {
+	params := []string{
+		"host=" + quoteValue(cfg.Host),
+		fmt.Sprintf("port=%d", cfg.Port),
+		"user=" + quoteValue(cfg.User),
+		"password=" + quoteValue(string(cfg.Password)),
+		"dbname=" + quoteValue(cfg.Database),
+		"sslmode=" + quoteValue(cfg.SSLMode),
+	}
+
+	if cfg.CAFile != "" {
+		params = append(params, "sslrootcert="+quoteValue(cfg.CAFile))
+	}
+	if cfg.CertFile != "" {
+		params = append(params, "sslcert="+quoteValue(cfg.CertFile))
+	}
+	if cfg.KeyFile != "" {
+		params = append(params, "sslkey="+quoteValue(cfg.KeyFile))
+	}
+
+	return strings.Join(params, " ")
+}
+
+// quoteValue quotes a connection string value, so that spaces and quotes are preserved
+func quoteValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
+
+func (c *yugabyteDBClient) getRunningQueries(ctx context.Context) ([]nodeCount, error) {
+	return c.queryNodeCounts(ctx, c.queries.runningQueries)
+}
+
+func (c *yugabyteDBClient) getActiveConnections(ctx context.Context) ([]nodeCount, error) {
+	return c.queryNodeCounts(ctx, c.queries.activeConnections)
+}
+
+func (c *yugabyteDBClient) queryNodeCounts(ctx context.Context, query string) (result []nodeCount, err error) {
+	err = c.query(ctx, query, func(rows *sql.Rows) error {
+		nc := nodeCount{node: c.localNode}
+		if err := rows.Scan(c.scanDest(&nc.node, &nc.count)...); err != nil {
+			return err
+		}
+		result = append(result, nc)
+		return nil
+	})
+	return result, err
+}
+
+func (c *yugabyteDBClient) getConnectionsByStateAndUser(ctx context.Context) (result []connectionMetric, err error) {
+	err = c.query(ctx, c.queries.connectionsByStateAndUser, func(rows *sql.Rows) error {
+		cm := connectionMetric{node: c.localNode}
+		if err := rows.Scan(c.scanDest(&cm.node, &cm.state, &cm.user, &cm.count)...); err != nil {
+			return err
+		}
+		result = append(result, cm)
+		return nil
+	})
+	return result, err
+}
+
+func (c *yugabyteDBClient) getActiveUsers(ctx context.Context) (result []userSessions, err error) {
+	err = c.query(ctx, c.queries.activeUserCount, func(rows *sql.Rows) error {
+		us := userSessions{node: c.localNode}
+		if err := rows.Scan(c.scanDest(&us.node, &us.user, &us.count)...); err != nil {
+			return err
+		}
+		result = append(result, us)
+		return nil
+	})
+	return result, err
+}
+
+func (c *yugabyteDBClient) getStatements(ctx context.Context, topN int) (result []statementStats, err error) {
+	err = c.query(ctx, c.queries.statements, func(rows *sql.Rows) error {
+		ss := statementStats{node: c.localNode}
+		dest := c.scanDest(&ss.node, &ss.queryID, &ss.user, &ss.database, &ss.query, &ss.calls, &ss.totalTime, &ss.meanTime, &ss.rows)
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		result = append(result, ss)
+		return nil
+	}, topN)
+	return result, err
+}
+
+func (c *yugabyteDBClient) getLockWaits(ctx context.Context) (result []lockWaits, err error) {
+	err = c.query(ctx, c.queries.lockWaits, func(rows *sql.Rows) error {
+		lw := lockWaits{node: c.localNode}
+		if err := rows.Scan(c.scanDest(&lw.node, &lw.lockType, &lw.count)...); err != nil {
+			return err
+		}
+		result = append(result, lw)
+		return nil
+	})
+	return result, err
+}
+
+func (c *yugabyteDBClient) getTransactions(ctx context.Context, longRunningThreshold time.Duration) (result []transactionStats, err error) {
+	err = c.query(ctx, c.queries.transactions, func(rows *sql.Rows) error {
+		ts := transactionStats{node: c.localNode}
+		if err := rows.Scan(c.scanDest(&ts.node, &ts.oldestAge, &ts.longRunning)...); err != nil {
+			return err
+		}
+		result = append(result, ts)
+		return nil
+	}, longRunningThreshold.Seconds())
+	return result, err
+}
+
+func (c *yugabyteDBClient) getServers(ctx context.Context) (result []nodeInfo, err error) {
+	err = c.query(ctx, serversQuery, func(rows *sql.Rows) error {
+		var node nodeInfo
+		if err := rows.Scan(&node.host, &node.zone, &node.region, &node.cloud); err != nil {
+			return err
+		}
+		result = append(result, node)
+		return nil
+	})
+	return result, err
+}
+
+// query runs the query with the given arguments and calls scan for each returned row
+func (c *yugabyteDBClient) query(ctx context.Context, query string, scan func(rows *sql.Rows) error, args ...any) (err error) {
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = multierr.Append(err, rows.Close())
+	}()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// scanDest prepends the gv$host, gv$zone, gv$region and gv$cloud columns returned by the
+// global-view queries to dest. The local queries don't return them.
+func (c *yugabyteDBClient) scanDest(node *nodeInfo, dest ...any) []any {
+	if !c.useGlobalView {
+		return dest
+	}
+	return append([]any{&node.host, &node.zone, &node.region, &node.cloud}, dest...)
+}
+
+func (c *yugabyteDBClient) Close() error {
+	return c.db.Close()
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package yugabytedbreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionString(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		modify   func(cfg *Config)
+		expected string
+	}{
+		{
+			desc:     "default",
+			modify:   func(*Config) {},
+			expected: "host='localhost' port=5433 user='yugabyte' password='yugabyte' dbname='yugabyte' sslmode='require'",
+		},
+		{
+			desc: "special characters in password",
+			modify: func(cfg *Config) {
+				cfg.Password = `p@ss w'ord\`
+				cfg.SSLMode = sslModeDisable
+			},
+			expected: `host='localhost' port=5433 user='yugabyte' password='p@ss w\'ord\\' dbname='yugabyte' sslmode='disable'`,
+		},
+		{
+			desc: "certificates",
+			modify: func(cfg *Config) {
+				cfg.SSLMode = sslModeVerifyCA
+				cfg.CAFile = "/certs/ca.crt"
+				cfg.CertFile = "/certs/client.crt"
+				cfg.KeyFile = "/certs/client.key"
+			},
+			expected: "host='localhost' port=5433 user='yugabyte' password='yugabyte' dbname='yugabyte' sslmode='verify-ca' " +
+				"sslrootcert='/certs/ca.crt' sslcert='/certs/client.crt' sslkey='/certs/client.key'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tc.modify(cfg)
+			assert.Equal(t, tc.expected, connectionString(cfg))
+		})
+	}
+}
+
+func TestNewClientAppliesConnectionPool(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ConnectionPool.MaxOpen = 3
+
+	c, err := newYugabyteDBClient(cfg)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	assert.Equal(t, 3, c.(*yugabyteDBClient).db.Stats().MaxOpenConnections)
+}
+
+func TestNewClientUseGlobalView(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	c, err := newYugabyteDBClient(cfg)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+	assert.Equal(t, globalViewQueries, c.(*yugabyteDBClient).queries)
+	assert.Equal(t, nodeInfo{}, c.(*yugabyteDBClient).localNode)
+
+	cfg.UseGlobalView = false
+	cfg.Host = "yb-tserver-0"
+	local, err := newYugabyteDBClient(cfg)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, local.Close())
+	}()
+	assert.Equal(t, localQueries, local.(*yugabyteDBClient).queries)
+	assert.Equal(t, nodeInfo{host: "yb-tserver-0"}, local.(*yugabyteDBClient).localNode)
+}
+
+func TestScanDest(t *testing.T) {
+	var node nodeInfo
+	var count int64
+
+	global := &yugabyteDBClient{useGlobalView: true}
+	assert.Equal(t, []any{&node.host, &node.zone, &node.region, &node.cloud, &count}, global.scanDest(&node, &count))
+
+	local := &yugabyteDBClient{}
+	assert.Equal(t, []any{&count}, local.scanDest(&node, &count))
+}
@@ -1,28 +1,216 @@
-package yugabytedbreceiver
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package yugabytedbreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/yugabytedbreceiver"
 
 import (
-	"go.opentelemetry.io/collector/component"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/scraper/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/yugabytedbreceiver/internal/metadata"
+)
+
+// SSL modes supported by the YSQL driver.
+const (
+	sslModeDisable    = "disable"
+	sslModeRequire    = "require"
+	sslModeVerifyCA   = "verify-ca"
+	sslModeVerifyFull = "verify-full"
+)
+
+var (
+	errNoHost             = errors.New("invalid config: missing host")
+	errInvalidPort        = errors.New("invalid config: port must be between 1 and 65535")
+	errNoUser             = errors.New("invalid config: missing user")
+	errInvalidDocDBPort   = errors.New("invalid config: docdb::tserver_port must be between 1 and 65535")
+	errInvalidDocDBScheme = errors.New(`invalid config: docdb::scheme must be "http" or "https"`)
+	errDocDBEndpoint      = errors.New("invalid config: docdb::endpoint is not supported, the tservers are discovered through yb_servers() and the masters are listed in docdb::masters")
+	errInvalidTopN        = errors.New("invalid config: statement_statistics::top_n must be greater than 0")
+	errInvalidThreshold   = errors.New("invalid config: long_running_transaction_threshold must be greater than 0")
+	errInvalidYCQLPort    = errors.New("invalid config: ycql::port and ycql::metrics_port must be between 1 and 65535")
+	errInvalidSSLMode     = fmt.Errorf("invalid config: sslmode must be one of %q, %q, %q or %q",
+		sslModeDisable, sslModeRequire, sslModeVerifyCA, sslModeVerifyFull)
 )
 
 // Config defines the configuration for the YugabyteDB receiver
 type Config struct {
-	confighttp.ServerConfig `mapstructure:",squash"`
+	scraperhelper.ControllerConfig `mapstructure:",squash"`
 
 	// Connection parameters
-	Host     string `mapstructure:"host"`
-	Port     int    `mapstructure:"port"`
-	User     string `mapstructure:"user"`
-	Password string `mapstructure:"password"`
-	Database string `mapstructure:"database"`
+	Host     string              `mapstructure:"host"`
+	Port     int                 `mapstructure:"port"`
+	User     string              `mapstructure:"user"`
+	Password configopaque.String `mapstructure:"password"`
+	Database string              `mapstructure:"database"`
+
+	// SSLMode is the sslmode used to connect to YSQL: disable, require, verify-ca or verify-full.
+	// Default: require.
+	SSLMode string `mapstructure:"sslmode"`
+
+	// TLS provides the CA, client certificate and key files used by the verify-ca and verify-full modes.
+	configtls.ClientConfig `mapstructure:"tls,omitempty"`
+
+	// UseGlobalView queries the gv$pg_stat_activity global view, which reports the activity of
+	// every tserver of the universe. When disabled, only pg_stat_activity of the node the receiver
+	// connects to is queried. Default: true.
+	UseGlobalView bool `mapstructure:"use_global_view"`
+
+	// ConnectionPool tunes the connection pool kept open across scrapes.
+	ConnectionPool ConnectionPool `mapstructure:"connection_pool,omitempty"`
+
+	// StatementStatistics configures the collection of the pg_stat_statements statistics, which are
+	// reported by the yugabytedb.statement.* metrics and the db.server.top_query events.
+	StatementStatistics StatementStatistics `mapstructure:"statement_statistics"`
+
+	// LongRunningTransactionThreshold is the age after which an open transaction is counted by the
+	// yugabytedb.transaction.long_running.count metric. Default: 1m.
+	LongRunningTransactionThreshold time.Duration `mapstructure:"long_running_transaction_threshold"`
+
+	// DocDB configures the scraping of the Prometheus endpoints of the tservers and masters.
+	DocDB DocDBConfig `mapstructure:"docdb"`
+
+	// YCQL configures the monitoring of the YCQL (Cassandra compatible) API.
+	YCQL YCQLConfig `mapstructure:"ycql"`
+
+	metadata.MetricsBuilderConfig `mapstructure:",squash"`
+	metadata.LogsBuilderConfig    `mapstructure:",squash"`
+}
+
+// ConnectionPool configures the connections kept open to YugabyteDB between scrapes.
+// Zero values keep the defaults of database/sql.
+type ConnectionPool struct {
+	MaxOpen     int           `mapstructure:"max_open,omitempty"`
+	MaxIdle     int           `mapstructure:"max_idle,omitempty"`
+	MaxLifetime time.Duration `mapstructure:"max_lifetime,omitempty"`
+	MaxIdleTime time.Duration `mapstructure:"max_idle_time,omitempty"`
+}
+
+// StatementStatistics configures the collection of the pg_stat_statements statistics.
+type StatementStatistics struct {
+	// TopN is the number of statements with the highest total execution time that are reported
+	// for each node. Default: 100.
+	TopN int `mapstructure:"top_n"`
+}
+
+// DocDBConfig configures the scraping of the /prometheus-metrics endpoints exposed by the web
+// servers of the tservers and masters, which report the DocDB-level metrics.
+type DocDBConfig struct {
+	// Enabled turns on the scraping of the Prometheus endpoints. Default: false.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Scheme is the scheme used to reach the web servers: http or https. Default: http.
+	Scheme string `mapstructure:"scheme"`
+
+	// TServerPort is the web server port of the tservers discovered through yb_servers(). Default: 9000.
+	TServerPort int `mapstructure:"tserver_port"`
+
+	// Masters lists the host:port addresses of the master web servers. yb_servers() only reports
+	// the tservers, so the masters are not scraped unless they are listed.
+	Masters []string `mapstructure:"masters"`
+
+	// ClientConfig configures the HTTP client shared by the scrapes of the web servers: the timeout of
+	// each request, the TLS certificates used when the scheme is https, the headers and the authenticator.
+	// The endpoint is not used, as every server is scraped.
+	confighttp.ClientConfig `mapstructure:",squash"`
+}
+
+// YCQLConfig configures the monitoring of the YCQL API. The keyspaces and the tservers are read
+// from the system tables, and the connection and statement metrics from the /prometheus-metrics
+// endpoint of the YCQL web server of every tserver, reached with the docdb scheme and tls settings.
+type YCQLConfig struct {
+	// Enabled turns on the monitoring of the YCQL API. Default: false.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Host is the host of the YCQL endpoint. Default: the host used to connect to YSQL.
+	Host string `mapstructure:"host"`
+
+	// Port is the port of the YCQL endpoint. Default: 9042.
+	Port int `mapstructure:"port"`
+
+	// User and Password authenticate the receiver when YCQL authentication is enabled.
+	User     string              `mapstructure:"user"`
+	Password configopaque.String `mapstructure:"password"`
+
+	// TLS configures the encryption of the YCQL connection, which is disabled unless tls::insecure is false.
+	TLS configtls.ClientConfig `mapstructure:"tls,omitempty"`
+
+	// MetricsPort is the port of the YCQL web server of the tservers. Default: 12000.
+	MetricsPort int `mapstructure:"metrics_port"`
+}
+
+// Validate checks if the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	var errs []error
+	if cfg.Host == "" {
+		errs = append(errs, errNoHost)
+	}
+	if !validPort(cfg.Port) {
+		errs = append(errs, errInvalidPort)
+	}
+	if cfg.User == "" {
+		errs = append(errs, errNoUser)
+	}
+
+	switch cfg.SSLMode {
+	case sslModeDisable, sslModeRequire, sslModeVerifyCA, sslModeVerifyFull:
+	default:
+		errs = append(errs, errInvalidSSLMode)
+	}
+
+	// The lib/pq module does not support overriding ServerName or specifying supported TLS versions
+	if cfg.ServerName != "" {
+		errs = append(errs, fmt.Errorf("invalid config: field '%s' not supported", "tls::server_name_override"))
+	}
+	if cfg.MinVersion != "" {
+		errs = append(errs, fmt.Errorf("invalid config: field '%s' not supported", "tls::min_version"))
+	}
+	if cfg.MaxVersion != "" {
+		errs = append(errs, fmt.Errorf("invalid config: field '%s' not supported", "tls::max_version"))
+	}
+
+	if cfg.StatementStatistics.TopN <= 0 {
+		errs = append(errs, errInvalidTopN)
+	}
+	if cfg.LongRunningTransactionThreshold <= 0 {
+		errs = append(errs, errInvalidThreshold)
+	}
+
+	if cfg.DocDB.Enabled {
+		errs = append(errs, cfg.DocDB.validate()...)
+	}
+	if cfg.YCQL.Enabled && (!validPort(cfg.YCQL.Port) || !validPort(cfg.YCQL.MetricsPort)) {
+		errs = append(errs, errInvalidYCQLPort)
+	}
+
+	return errors.Join(errs...)
 }
 
-func createDefaultConfig() component.Config {
-	return &Config{
-		Host:     "localhost",
-		Port:     5433,
-		User:     "yugabyte",
-		Password: "yugabyte",
-		Database: "yugabyte",
+func (cfg *DocDBConfig) validate() []error {
+	var errs []error
+	if !validPort(cfg.TServerPort) {
+		errs = append(errs, errInvalidDocDBPort)
+	}
+	if cfg.Scheme != "http" && cfg.Scheme != "https" {
+		errs = append(errs, errInvalidDocDBScheme)
+	}
+	if cfg.Endpoint != "" {
+		errs = append(errs, errDocDBEndpoint)
 	}
+	for _, master := range cfg.Masters {
+		if _, _, err := net.SplitHostPort(master); err != nil {
+			errs = append(errs, fmt.Errorf("invalid config: docdb::masters: %w", err))
+		}
+	}
+	return errs
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
 }
@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package yugabytedbreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/yugabytedbreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	t.Run("default", func(t *testing.T) {
+		cfg := createDefaultConfig()
+		sub, err := cm.Sub(component.NewID(metadata.Type).String())
+		require.NoError(t, err)
+		require.NoError(t, sub.Unmarshal(cfg))
+
+		require.NoError(t, xconfmap.Validate(cfg))
+		assert.Equal(t, createDefaultConfig(), cfg)
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		cfg := createDefaultConfig()
+		sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "tls").String())
+		require.NoError(t, err)
+		require.NoError(t, sub.Unmarshal(cfg))
+		require.NoError(t, xconfmap.Validate(cfg))
+
+		expected := createDefaultConfig().(*Config)
+		expected.CollectionInterval = 30 * time.Second
+		expected.InitialDelay = 5 * time.Second
+		expected.Timeout = 20 * time.Second
+		expected.Host = "yb-tserver-0.yb-tservers"
+		expected.User = "otel"
+		expected.Password = "s3cr3t"
+		expected.SSLMode = sslModeVerifyFull
+		expected.UseGlobalView = false
+		expected.ClientConfig = configtls.ClientConfig{
+			Config: configtls.Config{
+				CAFile:   "/etc/yugabytedb/ca.crt",
+				CertFile: "/etc/yugabytedb/client.crt",
+				KeyFile:  "/etc/yugabytedb/client.key",
+			},
+		}
+		expected.ConnectionPool = ConnectionPool{
+			MaxOpen:     2,
+			MaxIdle:     2,
+			MaxLifetime: 10 * time.Minute,
+		}
+		expected.StatementStatistics.TopN = 20
+		expected.LongRunningTransactionThreshold = 5 * time.Minute
+		assert.Equal(t, expected, cfg)
+	})
+
+	t.Run("docdb", func(t *testing.T) {
+		cfg := createDefaultConfig()
+		sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "docdb").String())
+		require.NoError(t, err)
+		require.NoError(t, sub.Unmarshal(cfg))
+		require.NoError(t, xconfmap.Validate(cfg))
+
+		expected := createDefaultConfig().(*Config)
+		expected.DocDB.Enabled = true
+		expected.DocDB.Scheme = "https"
+		expected.DocDB.TServerPort = 9443
+		expected.DocDB.Masters = []string{"yb-master-0.yb-masters:7000", "yb-master-1.yb-masters:7000"}
+		expected.DocDB.Timeout = 2 * time.Second
+		expected.DocDB.Headers = configopaque.MapList{{Name: "X-Scope", Value: "monitoring"}}
+		expected.DocDB.TLS = configtls.ClientConfig{
+			Config: configtls.Config{
+				CAFile: "/etc/yugabytedb/ca.crt",
+			},
+		}
+		assert.Equal(t, expected, cfg)
+	})
+
+	t.Run("ycql", func(t *testing.T) {
+		cfg := createDefaultConfig()
+		sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "ycql").String())
+		require.NoError(t, err)
+		require.NoError(t, sub.Unmarshal(cfg))
+		require.NoError(t, xconfmap.Validate(cfg))
+
+		expected := createDefaultConfig().(*Config)
+		expected.YCQL.Enabled = true
+		expected.YCQL.Host = "yb-tserver-0.yb-tservers"
+		expected.YCQL.User = "cassandra"
+		expected.YCQL.Password = "s3cr3t"
+		assert.Equal(t, expected, cfg)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		modify   func(cfg *Config)
+		expected []string
+	}{
+		{
+			desc:   "default",
+			modify: func(*Config) {},
+		},
+		{
+			desc: "missing host and user",
+			modify: func(cfg *Config) {
+				cfg.Host = ""
+				cfg.User = ""
+			},
+			expected: []string{errNoHost.Error(), errNoUser.Error()},
+		},
+		{
+			desc: "invalid port",
+			modify: func(cfg *Config) {
+				cfg.Port = 70000
+			},
+			expected: []string{errInvalidPort.Error()},
+		},
+		{
+			desc: "invalid sslmode",
+			modify: func(cfg *Config) {
+				cfg.SSLMode = "prefer"
+			},
+			expected: []string{errInvalidSSLMode.Error()},
+		},
+		{
+			desc: "unsupported tls settings",
+			modify: func(cfg *Config) {
+				cfg.ServerName = "yugabyte"
+				cfg.MinVersion = "1.2"
+			},
+			expected: []string{"tls::server_name_override", "tls::min_version"},
+		},
+		{
+			desc: "invalid statement and transaction settings",
+			modify: func(cfg *Config) {
+				cfg.StatementStatistics.TopN = 0
+				cfg.LongRunningTransactionThreshold = 0
+			},
+			expected: []string{errInvalidTopN.Error(), errInvalidThreshold.Error()},
+		},
+		{
+			desc: "invalid docdb settings",
+			modify: func(cfg *Config) {
+				cfg.DocDB.Enabled = true
+				cfg.DocDB.Scheme = "ftp"
+				cfg.DocDB.TServerPort = 0
+				cfg.DocDB.Masters = []string{"yb-master-0"}
+				cfg.DocDB.Endpoint = "http://yb-tserver-0:9000"
+			},
+			expected: []string{errInvalidDocDBScheme.Error(), errInvalidDocDBPort.Error(), "docdb::masters", errDocDBEndpoint.Error()},
+		},
+		{
+			desc: "invalid ycql ports",
+			modify: func(cfg *Config) {
+				cfg.YCQL.Enabled = true
+				cfg.YCQL.MetricsPort = 0
+			},
+			expected: []string{errInvalidYCQLPort.Error()},
+		},
+		{
+			desc: "docdb settings ignored when disabled",
+			modify: func(cfg *Config) {
+				cfg.DocDB.Scheme = "ftp"
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tc.modify(cfg)
+
+			err := cfg.Validate()
+			if len(tc.expected) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			for _, expected := range tc.expected {
+				assert.ErrorContains(t, err, expected)
+			}
+		})
+	}
+}
+
+func TestPasswordIsRedacted(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Password = "s3cr3t"
+
+	text, err := cfg.Password.MarshalText()
+	require.NoError(t, err)
+	assert.NotContains(t, string(text), "s3cr3t")
+}
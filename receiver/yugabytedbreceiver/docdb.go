@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package yugabytedbreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/yugabytedbreceiver"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/yugabytedbreceiver/internal/metadata"
+)
+
+// Types of the servers whose Prometheus endpoints are scraped
+const (
+	serverTypeTServer = "tserver"
+	serverTypeMaster  = "master"
+)
+
+// prometheusMetricsPath is the path of the Prometheus endpoint exposed by the web servers
+const prometheusMetricsPath = "/prometheus-metrics"
+
+// Names of the Prometheus metrics mapped to the DocDB metrics of the receiver
+const (
+	promIsRaftLeader         = "is_raft_leader"
+	promNumSSTFiles          = "rocksdb_current_version_num_sst_files"
+	promSSTFilesSize         = "rocksdb_current_version_sst_files_size"
+	promCompactReadBytes     = "rocksdb_compact_read_bytes"
+	promCompactWriteBytes    = "rocksdb_compact_write_bytes"
+	promLogAppendLatencyCnt  = "log_append_latency_count"
+	promLogAppendLatencySum  = "log_append_latency_sum"
+	promLogSyncLatencyCnt    = "log_sync_latency_count"
+	promLogSyncLatencySum    = "log_sync_latency_sum"
+	promTSHeartbeatCount     = "handler_latency_yb_master_MasterHeartbeat_TSHeartbeat_count"
+	promMetricTypeLabel      = "metric_type"
+	promMetricTypeTabletName = "tablet"
+)
+
+// docDBServer is a tserver or master whose Prometheus endpoint is scraped
+type docDBServer struct {
+	node       nodeInfo
+	serverType string
+	// address is the host:port of the web server
+	address string
+}
+
+// serverMetrics holds the DocDB metrics reported by a server, summed over its tablets
+type serverMetrics struct {
+	tablets         int64
+	raftLeaders     int64
+	sstFiles        int64
+	sstFilesSize    int64
+	compactionRead  int64
+	compactionWrite int64
+	walAppendCount  int64
+	walAppendTime   int64
+	walSyncCount    int64
+	walSyncTime     int64
+	heartbeats      int64
+}
+
+// newServerMetrics sums the samples of the metric families parsed from a Prometheus endpoint
+func newServerMetrics(families map[string]*dto.MetricFamily) *serverMetrics {
+	sm := &serverMetrics{
+		raftLeaders:     sumFamily(families[promIsRaftLeader]),
+		sstFiles:        sumFamily(families[promNumSSTFiles]),
+		sstFilesSize:    sumFamily(families[promSSTFilesSize]),
+		compactionRead:  sumFamily(families[promCompactReadBytes]),
+		compactionWrite: sumFamily(families[promCompactWriteBytes]),
+		walAppendCount:  sumFamily(families[promLogAppendLatencyCnt]),
+		walAppendTime:   sumFamily(families[promLogAppendLatencySum]),
+		walSyncCount:    sumFamily(families[promLogSyncLatencyCnt]),
+		walSyncTime:     sumFamily(families[promLogSyncLatencySum]),
+		heartbeats:      sumFamily(families[promTSHeartbeatCount]),
+	}
+
+	// Every tablet peer hosted by the server reports whether it is the Raft leader of its tablet
+	if family := families[promIsRaftLeader]; family != nil {
+		for _, m := range family.GetMetric() {
+			if labelValue(m, promMetricTypeLabel) == promMetricTypeTabletName {
+				sm.tablets++
+			}
+		}
+	}
+	return sm
+}
+
+// sumFamily sums the values of the samples of a counter, gauge or untyped metric family
+func sumFamily(family *dto.MetricFamily) int64 {
+	if family == nil {
+		return 0
+	}
+
+	var sum float64
+	for _, m := range family.GetMetric() {
+		switch {
+		case m.GetCounter() != nil:
+			sum += m.GetCounter().GetValue()
+		case m.GetGauge() != nil:
+			sum += m.GetGauge().GetValue()
+		case m.GetUntyped() != nil:
+			sum += m.GetUntyped().GetValue()
+		}
+	}
+	return int64(sum)
+}
+
+func labelValue(m *dto.Metric, name string) string {
+	for _, label := range m.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
+
+// docDBServers returns the tservers discovered through yb_servers() and the configured masters
+func (s *yugabyteDBScraper) docDBServers(ctx context.Context, errs *scrapererror.ScrapeErrors) []docDBServer {
+	var servers []docDBServer
+
+	tservers, err := s.client.getServers(ctx)
+	if err != nil {
+		errs.AddPartial(1, fmt.Errorf("failed to discover the tservers: %w", err))
+	}
+	for _, node := range tservers {
+		servers = append(servers, docDBServer{
+			node:       node,
+			serverType: serverTypeTServer,
+			address:    net.JoinHostPort(node.host, strconv.Itoa(s.config.DocDB.TServerPort)),
+		})
+	}
+
+	for _, master := range s.config.DocDB.Masters {
+		// The address was validated with the configuration
+		host, _, _ := net.SplitHostPort(master)
+		servers = append(servers, docDBServer{
+			node:       nodeInfo{host: host},
+			serverType: serverTypeMaster,
+			address:    master,
+		})
+	}
+	return servers
+}
+
+// scrapeDocDB scrapes the Prometheus endpoint of every tserver and master, and emits their
+// metrics under a resource identifying the server
+func (s *yugabyteDBScraper) scrapeDocDB(ctx context.Context, now pcommon.Timestamp, errs *scrapererror.ScrapeErrors) {
+	for _, server := range s.docDBServers(ctx, errs) {
+		families, err := s.fetchPrometheusMetrics(ctx, server.address)
+		if err != nil {
+			errs.AddPartial(1, fmt.Errorf("failed to scrape the %s %s: %w", server.serverType, server.address, err))
+			s.metricsBuilder.RecordYugabytedbServerUpDataPoint(now, 0)
+		} else {
+			s.metricsBuilder.RecordYugabytedbServerUpDataPoint(now, 1)
+			s.recordServerMetrics(now, server.serverType, newServerMetrics(families))
+		}
+
+		rb := s.metricsBuilder.NewResourceBuilder()
+		setNodeResourceAttributes(rb, server.node)
+		rb.SetYugabytedbServerType(server.serverType)
+		s.metricsBuilder.EmitForResource(metadata.WithResource(rb.Emit()))
+	}
+}
+
+func (s *yugabyteDBScraper) recordServerMetrics(now pcommon.Timestamp, serverType string, sm *serverMetrics) {
+	s.metricsBuilder.RecordYugabytedbTabletCountDataPoint(now, sm.tablets)
+	s.metricsBuilder.RecordYugabytedbRaftLeaderCountDataPoint(now, sm.raftLeaders)
+	s.metricsBuilder.RecordYugabytedbRocksdbSstFileCountDataPoint(now, sm.sstFiles)
+	s.metricsBuilder.RecordYugabytedbRocksdbSstFileSizeDataPoint(now, sm.sstFilesSize)
+	s.metricsBuilder.RecordYugabytedbRocksdbCompactionIoDataPoint(now, sm.compactionRead, metadata.AttributeCompactionDirectionRead)
+	s.metricsBuilder.RecordYugabytedbRocksdbCompactionIoDataPoint(now, sm.compactionWrite, metadata.AttributeCompactionDirectionWrite)
+	s.metricsBuilder.RecordYugabytedbWalOperationCountDataPoint(now, sm.walAppendCount, metadata.AttributeWalOperationAppend)
+	s.metricsBuilder.RecordYugabytedbWalOperationCountDataPoint(now, sm.walSyncCount, metadata.AttributeWalOperationSync)
+	s.metricsBuilder.RecordYugabytedbWalOperationTimeDataPoint(now, sm.walAppendTime, metadata.AttributeWalOperationAppend)
+	s.metricsBuilder.RecordYugabytedbWalOperationTimeDataPoint(now, sm.walSyncTime, metadata.AttributeWalOperationSync)
+	if serverType == serverTypeMaster {
+		s.metricsBuilder.RecordYugabytedbMasterHeartbeatCountDataPoint(now, sm.heartbeats)
+	}
+}
+
+// fetchPrometheusMetrics reads the Prometheus endpoint of the web server listening on address
+func (s *yugabyteDBScraper) fetchPrometheusMetrics(ctx context.Context, address string) (families map[string]*dto.MetricFamily, err error) {
+	u := url.URL{Scheme: s.config.DocDB.Scheme, Host: address, Path: prometheusMetricsPath}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = multierr.Append(err, resp.Body.Close())
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err = parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the metrics: %w", err)
+	}
+	return families, nil
+}
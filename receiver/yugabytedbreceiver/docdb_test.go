@@ -0,0 +1,258 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package yugabytedbreceiver
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/yugabytedbreceiver/internal/metadata"
+)
+
+// newPrometheusServer serves the content of a testdata file on the Prometheus endpoint
+func newPrometheusServer(t *testing.T, file string) *httptest.Server {
+	content, err := os.ReadFile(filepath.Join("testdata", "prometheus", file))
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != prometheusMetricsPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// splitServerURL returns the host and port of a test server
+func splitServerURL(t *testing.T, srv *httptest.Server) (string, int) {
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	host, portStr, err := net.SplitHostPort(u.Host)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+	return host, port
+}
+
+func newDocDBTestScraper(t *testing.T, fc *fakeClient, modify func(cfg *Config)) *yugabyteDBScraper {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DocDB.Enabled = true
+	modify(cfg)
+	require.NoError(t, cfg.Validate())
+
+	s := newYugabyteDBScraper(receivertest.NewNopSettings(metadata.Type), cfg, func(*Config) (client, error) {
+		return fc, nil
+	})
+	require.NoError(t, s.start(t.Context(), componenttest.NewNopHost()))
+	return s
+}
+
+// metricsByServerType indexes the metrics emitted for the DocDB servers by server type and metric name
+func metricsByServerType(metrics pmetric.Metrics) map[string]map[string]pmetric.Metric {
+	result := map[string]map[string]pmetric.Metric{}
+	rms := metrics.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		serverType, ok := rms.At(i).Resource().Attributes().Get("yugabytedb.server.type")
+		if !ok {
+			continue
+		}
+		byName := map[string]pmetric.Metric{}
+		ms := rms.At(i).ScopeMetrics().At(0).Metrics()
+		for j := 0; j < ms.Len(); j++ {
+			byName[ms.At(j).Name()] = ms.At(j)
+		}
+		result[serverType.Str()] = byName
+	}
+	return result
+}
+
+// sumValues indexes the values of a sum by the value of the given attribute
+func sumValues(m pmetric.Metric, attr string) map[string]int64 {
+	values := map[string]int64{}
+	dps := m.Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		v, _ := dps.At(i).Attributes().Get(attr)
+		values[v.Str()] = dps.At(i).IntValue()
+	}
+	return values
+}
+
+func TestScrapeDocDB(t *testing.T) {
+	tserver := newPrometheusServer(t, "tserver.txt")
+	master := newPrometheusServer(t, "master.txt")
+	tserverHost, tserverPort := splitServerURL(t, tserver)
+
+	fc := &fakeClient{
+		servers: []nodeInfo{{host: tserverHost, zone: "us-east-1a", region: "us-east-1", cloud: "aws"}},
+	}
+	s := newDocDBTestScraper(t, fc, func(cfg *Config) {
+		cfg.DocDB.TServerPort = tserverPort
+		cfg.DocDB.Masters = []string{master.Listener.Addr().String()}
+	})
+
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 2, metrics.ResourceMetrics().Len())
+	assert.Equal(t, map[string]any{
+		"yugabytedb.node.host":   tserverHost,
+		"yugabytedb.node.zone":   "us-east-1a",
+		"yugabytedb.node.region": "us-east-1",
+		"yugabytedb.node.cloud":  "aws",
+		"yugabytedb.server.type": "tserver",
+	}, metrics.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]any{
+		"yugabytedb.node.host":   tserverHost,
+		"yugabytedb.server.type": "master",
+	}, metrics.ResourceMetrics().At(1).Resource().Attributes().AsRaw())
+
+	byType := metricsByServerType(metrics)
+
+	ts := byType[serverTypeTServer]
+	assert.Equal(t, int64(1), ts["yugabytedb.server.up"].Gauge().DataPoints().At(0).IntValue())
+	assert.Equal(t, int64(3), ts["yugabytedb.tablet.count"].Gauge().DataPoints().At(0).IntValue())
+	assert.Equal(t, int64(2), ts["yugabytedb.raft.leader.count"].Gauge().DataPoints().At(0).IntValue())
+	assert.Equal(t, int64(6), ts["yugabytedb.rocksdb.sst_file.count"].Gauge().DataPoints().At(0).IntValue())
+	assert.Equal(t, int64(1572864), ts["yugabytedb.rocksdb.sst_file.size"].Gauge().DataPoints().At(0).IntValue())
+	assert.Equal(t, map[string]int64{"read": 3000, "write": 1500},
+		sumValues(ts["yugabytedb.rocksdb.compaction.io"], "compaction.direction"))
+	assert.Equal(t, map[string]int64{"append": 120, "sync": 30},
+		sumValues(ts["yugabytedb.wal.operation.count"], "wal.operation"))
+	assert.Equal(t, map[string]int64{"append": 6000, "sync": 9000},
+		sumValues(ts["yugabytedb.wal.operation.time"], "wal.operation"))
+	assert.NotContains(t, ts, "yugabytedb.master.heartbeat.count")
+
+	ms := byType[serverTypeMaster]
+	assert.Equal(t, int64(1), ms["yugabytedb.server.up"].Gauge().DataPoints().At(0).IntValue())
+	assert.Equal(t, int64(1), ms["yugabytedb.raft.leader.count"].Gauge().DataPoints().At(0).IntValue())
+	assert.Equal(t, int64(4200), ms["yugabytedb.master.heartbeat.count"].Sum().DataPoints().At(0).IntValue())
+}
+
+func TestScrapeDocDBUnreachableServer(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(srv.Close)
+	host, port := splitServerURL(t, srv)
+
+	fc := &fakeClient{servers: []nodeInfo{{host: host}}}
+	s := newDocDBTestScraper(t, fc, func(cfg *Config) {
+		cfg.DocDB.TServerPort = port
+	})
+
+	metrics, err := s.scrape(t.Context())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.ErrorContains(t, err, "404 Not Found")
+
+	ts := metricsByServerType(metrics)[serverTypeTServer]
+	assert.Equal(t, int64(0), ts["yugabytedb.server.up"].Gauge().DataPoints().At(0).IntValue())
+	assert.NotContains(t, ts, "yugabytedb.tablet.count")
+}
+
+func TestScrapeDocDBTimeout(t *testing.T) {
+	hung := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-hung:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(func() {
+		close(hung)
+		srv.Close()
+	})
+	host, port := splitServerURL(t, srv)
+	master := newPrometheusServer(t, "master.txt")
+
+	fc := &fakeClient{servers: []nodeInfo{{host: host}}}
+	s := newDocDBTestScraper(t, fc, func(cfg *Config) {
+		cfg.DocDB.TServerPort = port
+		cfg.DocDB.Masters = []string{master.Listener.Addr().String()}
+		cfg.DocDB.Timeout = 100 * time.Millisecond
+	})
+
+	// The hung tserver doesn't prevent the master from being scraped
+	metrics, err := s.scrape(t.Context())
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "Client.Timeout exceeded")
+
+	byType := metricsByServerType(metrics)
+	assert.Equal(t, int64(0), byType[serverTypeTServer]["yugabytedb.server.up"].Gauge().DataPoints().At(0).IntValue())
+	assert.Equal(t, int64(1), byType[serverTypeMaster]["yugabytedb.server.up"].Gauge().DataPoints().At(0).IntValue())
+}
+
+func TestScrapeDocDBHeaders(t *testing.T) {
+	var header string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Scope")
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	host, port := splitServerURL(t, srv)
+
+	fc := &fakeClient{servers: []nodeInfo{{host: host}}}
+	s := newDocDBTestScraper(t, fc, func(cfg *Config) {
+		cfg.DocDB.TServerPort = port
+		cfg.DocDB.Headers = configopaque.MapList{{Name: "X-Scope", Value: "monitoring"}}
+	})
+
+	_, err := s.scrape(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "monitoring", header)
+}
+
+func TestScrapeDocDBDiscoveryError(t *testing.T) {
+	master := newPrometheusServer(t, "master.txt")
+
+	fc := &fakeClient{err: errors.New("connection refused")}
+	s := newDocDBTestScraper(t, fc, func(cfg *Config) {
+		cfg.DocDB.Masters = []string{master.Listener.Addr().String()}
+	})
+
+	metrics, err := s.scrape(t.Context())
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "failed to discover the tservers")
+
+	// The configured masters are still scraped
+	ms := metricsByServerType(metrics)[serverTypeMaster]
+	assert.Equal(t, int64(1), ms["yugabytedb.server.up"].Gauge().DataPoints().At(0).IntValue())
+}
+
+func TestScrapeDocDBDisabled(t *testing.T) {
+	fc := newFakeClient()
+	fc.servers = []nodeInfo{{host: "127.0.0.1"}}
+	s := newTestScraper(t, fc)
+
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, metricsByServerType(metrics))
+	assert.Nil(t, s.httpClient)
+}
+
+func TestNewServerMetricsIgnoresUnknownMetrics(t *testing.T) {
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(strings.NewReader(
+		`hybrid_clock_skew{metric_id="yb.tabletserver",metric_type="server"} 0` + "\n"))
+	require.NoError(t, err)
+	assert.Equal(t, &serverMetrics{}, newServerMetrics(families))
+}
@@ -1,17 +1,127 @@
-package yugabytedbreceiver
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package yugabytedbreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/yugabytedbreceiver"
 
 import (
+	"context"
+	"time"
+
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/scraper"
+	"go.opentelemetry.io/collector/scraper/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/yugabytedbreceiver/internal/metadata"
 )
 
-var typeStr = component.MustNewType("yugabytedb")
+const (
+	defaultCollectionInterval      = 10 * time.Second
+	defaultTServerWebPort          = 9000
+	defaultDocDBTimeout            = 5 * time.Second
+	defaultYCQLPort                = 9042
+	defaultYCQLWebPort             = 12000
+	defaultStatementsTopN          = 100
+	defaultLongRunningTxnThreshold = time.Minute
+)
 
 // NewFactory creates a new YugabyteDB receiver factory.
 func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
-		typeStr,
+		metadata.Type,
 		createDefaultConfig,
-		receiver.WithMetrics(createMetricsReceiver, component.StabilityLevelAlpha),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	cfg := scraperhelper.NewDefaultControllerConfig()
+	cfg.CollectionInterval = defaultCollectionInterval
+
+	docDBClientConfig := confighttp.NewDefaultClientConfig()
+	docDBClientConfig.Timeout = defaultDocDBTimeout
+
+	return &Config{
+		ControllerConfig: cfg,
+		Host:             "localhost",
+		Port:             5433,
+		User:             "yugabyte",
+		Password:         "yugabyte",
+		Database:         "yugabyte",
+		SSLMode:          sslModeRequire,
+		UseGlobalView:    true,
+		StatementStatistics: StatementStatistics{
+			TopN: defaultStatementsTopN,
+		},
+		LongRunningTransactionThreshold: defaultLongRunningTxnThreshold,
+		DocDB: DocDBConfig{
+			Scheme:       "http",
+			TServerPort:  defaultTServerWebPort,
+			ClientConfig: docDBClientConfig,
+		},
+		YCQL: YCQLConfig{
+			Port: defaultYCQLPort,
+			TLS: configtls.ClientConfig{
+				Insecure: true,
+			},
+			MetricsPort: defaultYCQLWebPort,
+		},
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		LogsBuilderConfig:    metadata.DefaultLogsBuilderConfig(),
+	}
+}
+
+// createMetricsReceiver creates a new YugabyteDB metrics receiver
+func createMetricsReceiver(
+	_ context.Context,
+	params receiver.Settings,
+	rConf component.Config,
+	consumer consumer.Metrics,
+) (receiver.Metrics, error) {
+	cfg := rConf.(*Config)
+
+	ns := newYugabyteDBScraper(params, cfg, newYugabyteDBClient)
+	ns.newYCQLClient = newGocqlClient
+	s, err := scraper.NewMetrics(ns.scrape, scraper.WithStart(ns.start), scraper.WithShutdown(ns.shutdown))
+	if err != nil {
+		return nil, err
+	}
+
+	return scraperhelper.NewMetricsController(
+		&cfg.ControllerConfig, params, consumer,
+		scraperhelper.AddScraper(metadata.Type, s),
+	)
+}
+
+// createLogsReceiver creates a new YugabyteDB logs receiver, which reports the statements with the
+// highest total execution time as db.server.top_query events
+func createLogsReceiver(
+	_ context.Context,
+	params receiver.Settings,
+	rConf component.Config,
+	consumer consumer.Logs,
+) (receiver.Logs, error) {
+	cfg := rConf.(*Config)
+
+	var opts []scraperhelper.ControllerOption
+	if cfg.Events.DbServerTopQuery.Enabled {
+		ns := newYugabyteDBScraper(params, cfg, newYugabyteDBClient)
+		s, err := scraper.NewLogs(ns.scrapeTopQueries, scraper.WithStart(ns.start), scraper.WithShutdown(ns.shutdown))
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, scraperhelper.AddFactoryWithConfig(
+			scraper.NewFactory(metadata.Type, nil,
+				scraper.WithLogs(func(context.Context, scraper.Settings, component.Config) (scraper.Logs, error) {
+					return s, nil
+				}, metadata.LogsStability)), nil))
+	}
+
+	return scraperhelper.NewLogsController(
+		&cfg.ControllerConfig, params, consumer, opts...,
 	)
 }
@@ -4,6 +4,7 @@ package metadata
 
 import (
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/filter"
 )
 
 // MetricConfig provides common config for a particular metric.
@@ -28,9 +29,29 @@ func (ms *MetricConfig) Unmarshal(parser *confmap.Conf) error {
 // MetricsConfig provides config for yugabytedb metrics.
 type MetricsConfig struct {
 	YugabytedbActiveUsersCount                MetricConfig `mapstructure:"yugabytedb.active_users.count"`
+	YugabytedbAPIConnectionCount              MetricConfig `mapstructure:"yugabytedb.api.connection.count"`
+	YugabytedbAPIKeyspaceTableCount           MetricConfig `mapstructure:"yugabytedb.api.keyspace.table.count"`
+	YugabytedbAPIStatementCount               MetricConfig `mapstructure:"yugabytedb.api.statement.count"`
+	YugabytedbAPIStatementTime                MetricConfig `mapstructure:"yugabytedb.api.statement.time"`
 	YugabytedbConnectionCount                 MetricConfig `mapstructure:"yugabytedb.connection.count"`
+	YugabytedbLockWaitingCount                MetricConfig `mapstructure:"yugabytedb.lock.waiting.count"`
+	YugabytedbMasterHeartbeatCount            MetricConfig `mapstructure:"yugabytedb.master.heartbeat.count"`
 	YugabytedbPgStatActivityActiveConnections MetricConfig `mapstructure:"yugabytedb.pg_stat_activity.active_connections"`
 	YugabytedbPgStatActivityRunningQueries    MetricConfig `mapstructure:"yugabytedb.pg_stat_activity.running_queries"`
+	YugabytedbRaftLeaderCount                 MetricConfig `mapstructure:"yugabytedb.raft.leader.count"`
+	YugabytedbRocksdbCompactionIo             MetricConfig `mapstructure:"yugabytedb.rocksdb.compaction.io"`
+	YugabytedbRocksdbSstFileCount             MetricConfig `mapstructure:"yugabytedb.rocksdb.sst_file.count"`
+	YugabytedbRocksdbSstFileSize              MetricConfig `mapstructure:"yugabytedb.rocksdb.sst_file.size"`
+	YugabytedbServerUp                        MetricConfig `mapstructure:"yugabytedb.server.up"`
+	YugabytedbStatementCalls                  MetricConfig `mapstructure:"yugabytedb.statement.calls"`
+	YugabytedbStatementMeanTime               MetricConfig `mapstructure:"yugabytedb.statement.mean_time"`
+	YugabytedbStatementRows                   MetricConfig `mapstructure:"yugabytedb.statement.rows"`
+	YugabytedbStatementTime                   MetricConfig `mapstructure:"yugabytedb.statement.time"`
+	YugabytedbTabletCount                     MetricConfig `mapstructure:"yugabytedb.tablet.count"`
+	YugabytedbTransactionLongRunningCount     MetricConfig `mapstructure:"yugabytedb.transaction.long_running.count"`
+	YugabytedbTransactionOldestAge            MetricConfig `mapstructure:"yugabytedb.transaction.oldest.age"`
+	YugabytedbWalOperationCount               MetricConfig `mapstructure:"yugabytedb.wal.operation.count"`
+	YugabytedbWalOperationTime                MetricConfig `mapstructure:"yugabytedb.wal.operation.time"`
 }
 
 func DefaultMetricsConfig() MetricsConfig {
@@ -38,25 +59,194 @@ func DefaultMetricsConfig() MetricsConfig {
 		YugabytedbActiveUsersCount: MetricConfig{
 			Enabled: true,
 		},
+		YugabytedbAPIConnectionCount: MetricConfig{
+			Enabled: true,
+		},
+		YugabytedbAPIKeyspaceTableCount: MetricConfig{
+			Enabled: true,
+		},
+		YugabytedbAPIStatementCount: MetricConfig{
+			Enabled: true,
+		},
+		YugabytedbAPIStatementTime: MetricConfig{
+			Enabled: true,
+		},
 		YugabytedbConnectionCount: MetricConfig{
 			Enabled: true,
 		},
+		YugabytedbLockWaitingCount: MetricConfig{
+			Enabled: false,
+		},
+		YugabytedbMasterHeartbeatCount: MetricConfig{
+			Enabled: true,
+		},
 		YugabytedbPgStatActivityActiveConnections: MetricConfig{
 			Enabled: true,
 		},
 		YugabytedbPgStatActivityRunningQueries: MetricConfig{
 			Enabled: true,
 		},
+		YugabytedbRaftLeaderCount: MetricConfig{
+			Enabled: true,
+		},
+		YugabytedbRocksdbCompactionIo: MetricConfig{
+			Enabled: true,
+		},
+		YugabytedbRocksdbSstFileCount: MetricConfig{
+			Enabled: true,
+		},
+		YugabytedbRocksdbSstFileSize: MetricConfig{
+			Enabled: true,
+		},
+		YugabytedbServerUp: MetricConfig{
+			Enabled: true,
+		},
+		YugabytedbStatementCalls: MetricConfig{
+			Enabled: false,
+		},
+		YugabytedbStatementMeanTime: MetricConfig{
+			Enabled: false,
+		},
+		YugabytedbStatementRows: MetricConfig{
+			Enabled: false,
+		},
+		YugabytedbStatementTime: MetricConfig{
+			Enabled: false,
+		},
+		YugabytedbTabletCount: MetricConfig{
+			Enabled: true,
+		},
+		YugabytedbTransactionLongRunningCount: MetricConfig{
+			Enabled: true,
+		},
+		YugabytedbTransactionOldestAge: MetricConfig{
+			Enabled: true,
+		},
+		YugabytedbWalOperationCount: MetricConfig{
+			Enabled: true,
+		},
+		YugabytedbWalOperationTime: MetricConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// EventConfig provides common config for a particular event.
+type EventConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	enabledSetByUser bool
+}
+
+func (ec *EventConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(ec)
+	if err != nil {
+		return err
+	}
+	ec.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// EventsConfig provides config for yugabytedb events.
+type EventsConfig struct {
+	DbServerTopQuery EventConfig `mapstructure:"db.server.top_query"`
+}
+
+func DefaultEventsConfig() EventsConfig {
+	return EventsConfig{
+		DbServerTopQuery: EventConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// ResourceAttributeConfig provides common config for a particular resource attribute.
+type ResourceAttributeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Experimental: MetricsInclude defines a list of filters for attribute values.
+	// If the list is not empty, only metrics with matching resource attribute values will be emitted.
+	MetricsInclude []filter.Config `mapstructure:"metrics_include"`
+	// Experimental: MetricsExclude defines a list of filters for attribute values.
+	// If the list is not empty, metrics with matching resource attribute values will not be emitted.
+	// MetricsInclude has higher priority than MetricsExclude.
+	MetricsExclude []filter.Config `mapstructure:"metrics_exclude"`
+	// Experimental: EventsInclude defines a list of filters for attribute values.
+	// If the list is not empty, only events with matching resource attribute values will be emitted.
+	EventsInclude []filter.Config `mapstructure:"events_include"`
+	// Experimental: EventsExclude defines a list of filters for attribute values.
+	// If the list is not empty, events with matching resource attribute values will not be emitted.
+	// EventsInclude has higher priority than EventsExclude.
+	EventsExclude []filter.Config `mapstructure:"events_exclude"`
+
+	enabledSetByUser bool
+}
+
+func (rac *ResourceAttributeConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(rac)
+	if err != nil {
+		return err
+	}
+	rac.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// ResourceAttributesConfig provides config for yugabytedb resource attributes.
+type ResourceAttributesConfig struct {
+	YugabytedbNodeCloud  ResourceAttributeConfig `mapstructure:"yugabytedb.node.cloud"`
+	YugabytedbNodeHost   ResourceAttributeConfig `mapstructure:"yugabytedb.node.host"`
+	YugabytedbNodeRegion ResourceAttributeConfig `mapstructure:"yugabytedb.node.region"`
+	YugabytedbNodeZone   ResourceAttributeConfig `mapstructure:"yugabytedb.node.zone"`
+	YugabytedbServerType ResourceAttributeConfig `mapstructure:"yugabytedb.server.type"`
+}
+
+func DefaultResourceAttributesConfig() ResourceAttributesConfig {
+	return ResourceAttributesConfig{
+		YugabytedbNodeCloud: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		YugabytedbNodeHost: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		YugabytedbNodeRegion: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		YugabytedbNodeZone: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		YugabytedbServerType: ResourceAttributeConfig{
+			Enabled: true,
+		},
 	}
 }
 
 // MetricsBuilderConfig is a configuration for yugabytedb metrics builder.
 type MetricsBuilderConfig struct {
-	Metrics MetricsConfig `mapstructure:"metrics"`
+	Metrics            MetricsConfig            `mapstructure:"metrics"`
+	ResourceAttributes ResourceAttributesConfig `mapstructure:"resource_attributes"`
 }
 
 func DefaultMetricsBuilderConfig() MetricsBuilderConfig {
 	return MetricsBuilderConfig{
-		Metrics: DefaultMetricsConfig(),
+		Metrics:            DefaultMetricsConfig(),
+		ResourceAttributes: DefaultResourceAttributesConfig(),
+	}
+}
+
+// LogsBuilderConfig is a configuration for yugabytedb logs builder.
+type LogsBuilderConfig struct {
+	Events             EventsConfig             `mapstructure:"events"`
+	ResourceAttributes ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+func DefaultLogsBuilderConfig() LogsBuilderConfig {
+	return LogsBuilderConfig{
+		Events:             DefaultEventsConfig(),
+		ResourceAttributes: DefaultResourceAttributesConfig(),
 	}
 }
@@ -28,9 +28,36 @@ func TestMetricsBuilderConfig(t *testing.T) {
 			want: MetricsBuilderConfig{
 				Metrics: MetricsConfig{
 					YugabytedbActiveUsersCount:                MetricConfig{Enabled: true},
+					YugabytedbAPIConnectionCount:              MetricConfig{Enabled: true},
+					YugabytedbAPIKeyspaceTableCount:           MetricConfig{Enabled: true},
+					YugabytedbAPIStatementCount:               MetricConfig{Enabled: true},
+					YugabytedbAPIStatementTime:                MetricConfig{Enabled: true},
 					YugabytedbConnectionCount:                 MetricConfig{Enabled: true},
+					YugabytedbLockWaitingCount:                MetricConfig{Enabled: true},
+					YugabytedbMasterHeartbeatCount:            MetricConfig{Enabled: true},
 					YugabytedbPgStatActivityActiveConnections: MetricConfig{Enabled: true},
 					YugabytedbPgStatActivityRunningQueries:    MetricConfig{Enabled: true},
+					YugabytedbRaftLeaderCount:                 MetricConfig{Enabled: true},
+					YugabytedbRocksdbCompactionIo:             MetricConfig{Enabled: true},
+					YugabytedbRocksdbSstFileCount:             MetricConfig{Enabled: true},
+					YugabytedbRocksdbSstFileSize:              MetricConfig{Enabled: true},
+					YugabytedbServerUp:                        MetricConfig{Enabled: true},
+					YugabytedbStatementCalls:                  MetricConfig{Enabled: true},
+					YugabytedbStatementMeanTime:               MetricConfig{Enabled: true},
+					YugabytedbStatementRows:                   MetricConfig{Enabled: true},
+					YugabytedbStatementTime:                   MetricConfig{Enabled: true},
+					YugabytedbTabletCount:                     MetricConfig{Enabled: true},
+					YugabytedbTransactionLongRunningCount:     MetricConfig{Enabled: true},
+					YugabytedbTransactionOldestAge:            MetricConfig{Enabled: true},
+					YugabytedbWalOperationCount:               MetricConfig{Enabled: true},
+					YugabytedbWalOperationTime:                MetricConfig{Enabled: true},
+				},
+				ResourceAttributes: ResourceAttributesConfig{
+					YugabytedbNodeCloud:  ResourceAttributeConfig{Enabled: true},
+					YugabytedbNodeHost:   ResourceAttributeConfig{Enabled: true},
+					YugabytedbNodeRegion: ResourceAttributeConfig{Enabled: true},
+					YugabytedbNodeZone:   ResourceAttributeConfig{Enabled: true},
+					YugabytedbServerType: ResourceAttributeConfig{Enabled: true},
 				},
 			},
 		},
@@ -39,9 +66,36 @@ func TestMetricsBuilderConfig(t *testing.T) {
 			want: MetricsBuilderConfig{
 				Metrics: MetricsConfig{
 					YugabytedbActiveUsersCount:                MetricConfig{Enabled: false},
+					YugabytedbAPIConnectionCount:              MetricConfig{Enabled: false},
+					YugabytedbAPIKeyspaceTableCount:           MetricConfig{Enabled: false},
+					YugabytedbAPIStatementCount:               MetricConfig{Enabled: false},
+					YugabytedbAPIStatementTime:                MetricConfig{Enabled: false},
 					YugabytedbConnectionCount:                 MetricConfig{Enabled: false},
+					YugabytedbLockWaitingCount:                MetricConfig{Enabled: false},
+					YugabytedbMasterHeartbeatCount:            MetricConfig{Enabled: false},
 					YugabytedbPgStatActivityActiveConnections: MetricConfig{Enabled: false},
 					YugabytedbPgStatActivityRunningQueries:    MetricConfig{Enabled: false},
+					YugabytedbRaftLeaderCount:                 MetricConfig{Enabled: false},
+					YugabytedbRocksdbCompactionIo:             MetricConfig{Enabled: false},
+					YugabytedbRocksdbSstFileCount:             MetricConfig{Enabled: false},
+					YugabytedbRocksdbSstFileSize:              MetricConfig{Enabled: false},
+					YugabytedbServerUp:                        MetricConfig{Enabled: false},
+					YugabytedbStatementCalls:                  MetricConfig{Enabled: false},
+					YugabytedbStatementMeanTime:               MetricConfig{Enabled: false},
+					YugabytedbStatementRows:                   MetricConfig{Enabled: false},
+					YugabytedbStatementTime:                   MetricConfig{Enabled: false},
+					YugabytedbTabletCount:                     MetricConfig{Enabled: false},
+					YugabytedbTransactionLongRunningCount:     MetricConfig{Enabled: false},
+					YugabytedbTransactionOldestAge:            MetricConfig{Enabled: false},
+					YugabytedbWalOperationCount:               MetricConfig{Enabled: false},
+					YugabytedbWalOperationTime:                MetricConfig{Enabled: false},
+				},
+				ResourceAttributes: ResourceAttributesConfig{
+					YugabytedbNodeCloud:  ResourceAttributeConfig{Enabled: false},
+					YugabytedbNodeHost:   ResourceAttributeConfig{Enabled: false},
+					YugabytedbNodeRegion: ResourceAttributeConfig{Enabled: false},
+					YugabytedbNodeZone:   ResourceAttributeConfig{Enabled: false},
+					YugabytedbServerType: ResourceAttributeConfig{Enabled: false},
 				},
 			},
 		},
@@ -49,7 +103,7 @@ func TestMetricsBuilderConfig(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := loadMetricsBuilderConfig(t, tt.name)
-			diff := cmp.Diff(tt.want, cfg, cmpopts.IgnoreUnexported(MetricConfig{}))
+			diff := cmp.Diff(tt.want, cfg, cmpopts.IgnoreUnexported(MetricConfig{}, ResourceAttributeConfig{}))
 			require.Emptyf(t, diff, "Config mismatch (-expected +actual):\n%s", diff)
 		})
 	}
@@ -64,3 +118,64 @@ func loadMetricsBuilderConfig(t *testing.T, name string) MetricsBuilderConfig {
 	require.NoError(t, sub.Unmarshal(&cfg, confmap.WithIgnoreUnused()))
 	return cfg
 }
+
+func loadLogsBuilderConfig(t *testing.T, name string) LogsBuilderConfig {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	sub, err := cm.Sub(name)
+	require.NoError(t, err)
+	cfg := DefaultLogsBuilderConfig()
+	require.NoError(t, sub.Unmarshal(&cfg, confmap.WithIgnoreUnused()))
+	return cfg
+}
+
+func TestResourceAttributesConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		want ResourceAttributesConfig
+	}{
+		{
+			name: "default",
+			want: DefaultResourceAttributesConfig(),
+		},
+		{
+			name: "all_set",
+			want: ResourceAttributesConfig{
+				YugabytedbNodeCloud:  ResourceAttributeConfig{Enabled: true},
+				YugabytedbNodeHost:   ResourceAttributeConfig{Enabled: true},
+				YugabytedbNodeRegion: ResourceAttributeConfig{Enabled: true},
+				YugabytedbNodeZone:   ResourceAttributeConfig{Enabled: true},
+				YugabytedbServerType: ResourceAttributeConfig{Enabled: true},
+			},
+		},
+		{
+			name: "none_set",
+			want: ResourceAttributesConfig{
+				YugabytedbNodeCloud:  ResourceAttributeConfig{Enabled: false},
+				YugabytedbNodeHost:   ResourceAttributeConfig{Enabled: false},
+				YugabytedbNodeRegion: ResourceAttributeConfig{Enabled: false},
+				YugabytedbNodeZone:   ResourceAttributeConfig{Enabled: false},
+				YugabytedbServerType: ResourceAttributeConfig{Enabled: false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := loadResourceAttributesConfig(t, tt.name)
+			diff := cmp.Diff(tt.want, cfg, cmpopts.IgnoreUnexported(ResourceAttributeConfig{}))
+			require.Emptyf(t, diff, "Config mismatch (-expected +actual):\n%s", diff)
+		})
+	}
+}
+
+func loadResourceAttributesConfig(t *testing.T, name string) ResourceAttributesConfig {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	sub, err := cm.Sub(name)
+	require.NoError(t, err)
+	sub, err = sub.Sub("resource_attributes")
+	require.NoError(t, err)
+	cfg := DefaultResourceAttributesConfig()
+	require.NoError(t, sub.Unmarshal(&cfg))
+	return cfg
+}
@@ -0,0 +1,198 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"context"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/filter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type eventDbServerTopQuery struct {
+	data   plog.LogRecordSlice // data buffer for generated log records.
+	config EventConfig         // event config provided by user.
+}
+
+func (e *eventDbServerTopQuery) recordEvent(ctx context.Context, timestamp pcommon.Timestamp, queryIDAttributeValue string, dbQueryTextAttributeValue string, dbNamespaceAttributeValue string, connectionUserAttributeValue string, yugabytedbStatementCallsAttributeValue int64, yugabytedbStatementTotalTimeAttributeValue float64, yugabytedbStatementMeanTimeAttributeValue float64, yugabytedbStatementRowsAttributeValue int64) {
+	if !e.config.Enabled {
+		return
+	}
+	dp := e.data.AppendEmpty()
+	dp.SetEventName("db.server.top_query")
+	dp.SetTimestamp(timestamp)
+
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		dp.SetTraceID(pcommon.TraceID(span.TraceID()))
+		dp.SetSpanID(pcommon.SpanID(span.SpanID()))
+	}
+	dp.Attributes().PutStr("query.id", queryIDAttributeValue)
+	dp.Attributes().PutStr("db.query.text", dbQueryTextAttributeValue)
+	dp.Attributes().PutStr("db.namespace", dbNamespaceAttributeValue)
+	dp.Attributes().PutStr("connection.user", connectionUserAttributeValue)
+	dp.Attributes().PutInt("yugabytedb.statement.calls", yugabytedbStatementCallsAttributeValue)
+	dp.Attributes().PutDouble("yugabytedb.statement.total_time", yugabytedbStatementTotalTimeAttributeValue)
+	dp.Attributes().PutDouble("yugabytedb.statement.mean_time", yugabytedbStatementMeanTimeAttributeValue)
+	dp.Attributes().PutInt("yugabytedb.statement.rows", yugabytedbStatementRowsAttributeValue)
+
+}
+
+// emit appends recorded event data to a events slice and prepares it for recording another set of log records.
+func (e *eventDbServerTopQuery) emit(lrs plog.LogRecordSlice) {
+	if e.config.Enabled && e.data.Len() > 0 {
+		e.data.MoveAndAppendTo(lrs)
+	}
+}
+
+func newEventDbServerTopQuery(cfg EventConfig) eventDbServerTopQuery {
+	e := eventDbServerTopQuery{config: cfg}
+	if cfg.Enabled {
+		e.data = plog.NewLogRecordSlice()
+	}
+	return e
+}
+
+// LogsBuilder provides an interface for scrapers to report logs while taking care of all the transformations
+// required to produce log representation defined in metadata and user config.
+type LogsBuilder struct {
+	config                         LogsBuilderConfig // config of the logs builder.
+	logsBuffer                     plog.Logs
+	logRecordsBuffer               plog.LogRecordSlice
+	buildInfo                      component.BuildInfo // contains version information.
+	resourceAttributeIncludeFilter map[string]filter.Filter
+	resourceAttributeExcludeFilter map[string]filter.Filter
+	eventDbServerTopQuery          eventDbServerTopQuery
+}
+
+// LogBuilderOption applies changes to default logs builder.
+type LogBuilderOption interface {
+	apply(*LogsBuilder)
+}
+
+func NewLogsBuilder(lbc LogsBuilderConfig, settings receiver.Settings) *LogsBuilder {
+	lb := &LogsBuilder{
+		config:                         lbc,
+		logsBuffer:                     plog.NewLogs(),
+		logRecordsBuffer:               plog.NewLogRecordSlice(),
+		buildInfo:                      settings.BuildInfo,
+		eventDbServerTopQuery:          newEventDbServerTopQuery(lbc.Events.DbServerTopQuery),
+		resourceAttributeIncludeFilter: make(map[string]filter.Filter),
+		resourceAttributeExcludeFilter: make(map[string]filter.Filter),
+	}
+	if lbc.ResourceAttributes.YugabytedbNodeCloud.EventsInclude != nil {
+		lb.resourceAttributeIncludeFilter["yugabytedb.node.cloud"] = filter.CreateFilter(lbc.ResourceAttributes.YugabytedbNodeCloud.EventsInclude)
+	}
+	if lbc.ResourceAttributes.YugabytedbNodeCloud.EventsExclude != nil {
+		lb.resourceAttributeExcludeFilter["yugabytedb.node.cloud"] = filter.CreateFilter(lbc.ResourceAttributes.YugabytedbNodeCloud.EventsExclude)
+	}
+	if lbc.ResourceAttributes.YugabytedbNodeHost.EventsInclude != nil {
+		lb.resourceAttributeIncludeFilter["yugabytedb.node.host"] = filter.CreateFilter(lbc.ResourceAttributes.YugabytedbNodeHost.EventsInclude)
+	}
+	if lbc.ResourceAttributes.YugabytedbNodeHost.EventsExclude != nil {
+		lb.resourceAttributeExcludeFilter["yugabytedb.node.host"] = filter.CreateFilter(lbc.ResourceAttributes.YugabytedbNodeHost.EventsExclude)
+	}
+	if lbc.ResourceAttributes.YugabytedbNodeRegion.EventsInclude != nil {
+		lb.resourceAttributeIncludeFilter["yugabytedb.node.region"] = filter.CreateFilter(lbc.ResourceAttributes.YugabytedbNodeRegion.EventsInclude)
+	}
+	if lbc.ResourceAttributes.YugabytedbNodeRegion.EventsExclude != nil {
+		lb.resourceAttributeExcludeFilter["yugabytedb.node.region"] = filter.CreateFilter(lbc.ResourceAttributes.YugabytedbNodeRegion.EventsExclude)
+	}
+	if lbc.ResourceAttributes.YugabytedbNodeZone.EventsInclude != nil {
+		lb.resourceAttributeIncludeFilter["yugabytedb.node.zone"] = filter.CreateFilter(lbc.ResourceAttributes.YugabytedbNodeZone.EventsInclude)
+	}
+	if lbc.ResourceAttributes.YugabytedbNodeZone.EventsExclude != nil {
+		lb.resourceAttributeExcludeFilter["yugabytedb.node.zone"] = filter.CreateFilter(lbc.ResourceAttributes.YugabytedbNodeZone.EventsExclude)
+	}
+	if lbc.ResourceAttributes.YugabytedbServerType.EventsInclude != nil {
+		lb.resourceAttributeIncludeFilter["yugabytedb.server.type"] = filter.CreateFilter(lbc.ResourceAttributes.YugabytedbServerType.EventsInclude)
+	}
+	if lbc.ResourceAttributes.YugabytedbServerType.EventsExclude != nil {
+		lb.resourceAttributeExcludeFilter["yugabytedb.server.type"] = filter.CreateFilter(lbc.ResourceAttributes.YugabytedbServerType.EventsExclude)
+	}
+
+	return lb
+}
+
+// NewResourceBuilder returns a new resource builder that should be used to build a resource associated with for the emitted logs.
+func (lb *LogsBuilder) NewResourceBuilder() *ResourceBuilder {
+	return NewResourceBuilder(lb.config.ResourceAttributes)
+}
+
+// ResourceLogsOption applies changes to provided resource logs.
+type ResourceLogsOption interface {
+	apply(plog.ResourceLogs)
+}
+
+type resourceLogsOptionFunc func(plog.ResourceLogs)
+
+func (rlof resourceLogsOptionFunc) apply(rl plog.ResourceLogs) {
+	rlof(rl)
+}
+
+// WithLogsResource sets the provided resource on the emitted ResourceLogs.
+// It's recommended to use ResourceBuilder to create the resource.
+func WithLogsResource(res pcommon.Resource) ResourceLogsOption {
+	return resourceLogsOptionFunc(func(rl plog.ResourceLogs) {
+		res.CopyTo(rl.Resource())
+	})
+}
+
+// AppendLogRecord adds a log record to the logs builder.
+func (lb *LogsBuilder) AppendLogRecord(lr plog.LogRecord) {
+	lr.MoveTo(lb.logRecordsBuffer.AppendEmpty())
+}
+
+// EmitForResource saves all the generated logs under a new resource and updates the internal state to be ready for
+// recording another set of log records as part of another resource. This function can be helpful when one scraper
+// needs to emit logs from several resources. Otherwise calling this function is not required,
+// just `Emit` function can be called instead.
+// Resource attributes should be provided as ResourceLogsOption arguments.
+func (lb *LogsBuilder) EmitForResource(options ...ResourceLogsOption) {
+	rl := plog.NewResourceLogs()
+	ils := rl.ScopeLogs().AppendEmpty()
+	ils.Scope().SetName(ScopeName)
+	ils.Scope().SetVersion(lb.buildInfo.Version)
+	lb.eventDbServerTopQuery.emit(ils.LogRecords())
+
+	for _, op := range options {
+		op.apply(rl)
+	}
+
+	if lb.logRecordsBuffer.Len() > 0 {
+		lb.logRecordsBuffer.MoveAndAppendTo(ils.LogRecords())
+		lb.logRecordsBuffer = plog.NewLogRecordSlice()
+	}
+
+	for attr, filter := range lb.resourceAttributeIncludeFilter {
+		if val, ok := rl.Resource().Attributes().Get(attr); ok && !filter.Matches(val.AsString()) {
+			return
+		}
+	}
+	for attr, filter := range lb.resourceAttributeExcludeFilter {
+		if val, ok := rl.Resource().Attributes().Get(attr); ok && filter.Matches(val.AsString()) {
+			return
+		}
+	}
+
+	if ils.LogRecords().Len() > 0 {
+		rl.MoveTo(lb.logsBuffer.ResourceLogs().AppendEmpty())
+	}
+}
+
+// Emit returns all the logs accumulated by the logs builder and updates the internal state to be ready for
+// recording another set of logs. This function will be responsible for applying all the transformations required to
+// produce logs representation defined in metadata and user config.
+func (lb *LogsBuilder) Emit(options ...ResourceLogsOption) plog.Logs {
+	lb.EmitForResource(options...)
+	logs := lb.logsBuffer
+	lb.logsBuffer = plog.NewLogs()
+	return logs
+}
+
+// RecordDbServerTopQueryEvent adds a log record of db.server.top_query event.
+func (lb *LogsBuilder) RecordDbServerTopQueryEvent(ctx context.Context, timestamp pcommon.Timestamp, queryIDAttributeValue string, dbQueryTextAttributeValue string, dbNamespaceAttributeValue string, connectionUserAttributeValue string, yugabytedbStatementCallsAttributeValue int64, yugabytedbStatementTotalTimeAttributeValue float64, yugabytedbStatementMeanTimeAttributeValue float64, yugabytedbStatementRowsAttributeValue int64) {
+	lb.eventDbServerTopQuery.recordEvent(ctx, timestamp, queryIDAttributeValue, dbQueryTextAttributeValue, dbNamespaceAttributeValue, connectionUserAttributeValue, yugabytedbStatementCallsAttributeValue, yugabytedbStatementTotalTimeAttributeValue, yugabytedbStatementMeanTimeAttributeValue, yugabytedbStatementRowsAttributeValue)
+}
@@ -6,72 +6,1063 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/filter"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver"
 )
 
+// AttributeCompactionDirection specifies the value compaction.direction attribute.
+type AttributeCompactionDirection int
+
+const (
+	_ AttributeCompactionDirection = iota
+	AttributeCompactionDirectionRead
+	AttributeCompactionDirectionWrite
+)
+
+// String returns the string representation of the AttributeCompactionDirection.
+func (av AttributeCompactionDirection) String() string {
+	switch av {
+	case AttributeCompactionDirectionRead:
+		return "read"
+	case AttributeCompactionDirectionWrite:
+		return "write"
+	}
+	return ""
+}
+
+// MapAttributeCompactionDirection is a helper map of string to AttributeCompactionDirection attribute value.
+var MapAttributeCompactionDirection = map[string]AttributeCompactionDirection{
+	"read":  AttributeCompactionDirectionRead,
+	"write": AttributeCompactionDirectionWrite,
+}
+
+// AttributeStatementType specifies the value statement.type attribute.
+type AttributeStatementType int
+
+const (
+	_ AttributeStatementType = iota
+	AttributeStatementTypeSelect
+	AttributeStatementTypeInsert
+	AttributeStatementTypeUpdate
+	AttributeStatementTypeDelete
+	AttributeStatementTypeOther
+)
+
+// String returns the string representation of the AttributeStatementType.
+func (av AttributeStatementType) String() string {
+	switch av {
+	case AttributeStatementTypeSelect:
+		return "select"
+	case AttributeStatementTypeInsert:
+		return "insert"
+	case AttributeStatementTypeUpdate:
+		return "update"
+	case AttributeStatementTypeDelete:
+		return "delete"
+	case AttributeStatementTypeOther:
+		return "other"
+	}
+	return ""
+}
+
+// MapAttributeStatementType is a helper map of string to AttributeStatementType attribute value.
+var MapAttributeStatementType = map[string]AttributeStatementType{
+	"select": AttributeStatementTypeSelect,
+	"insert": AttributeStatementTypeInsert,
+	"update": AttributeStatementTypeUpdate,
+	"delete": AttributeStatementTypeDelete,
+	"other":  AttributeStatementTypeOther,
+}
+
+// AttributeWalOperation specifies the value wal.operation attribute.
+type AttributeWalOperation int
+
+const (
+	_ AttributeWalOperation = iota
+	AttributeWalOperationAppend
+	AttributeWalOperationSync
+)
+
+// String returns the string representation of the AttributeWalOperation.
+func (av AttributeWalOperation) String() string {
+	switch av {
+	case AttributeWalOperationAppend:
+		return "append"
+	case AttributeWalOperationSync:
+		return "sync"
+	}
+	return ""
+}
+
+// MapAttributeWalOperation is a helper map of string to AttributeWalOperation attribute value.
+var MapAttributeWalOperation = map[string]AttributeWalOperation{
+	"append": AttributeWalOperationAppend,
+	"sync":   AttributeWalOperationSync,
+}
+
 var MetricsInfo = metricsInfo{
 	YugabytedbActiveUsersCount: metricInfo{
 		Name: "yugabytedb.active_users.count",
 	},
+	YugabytedbAPIConnectionCount: metricInfo{
+		Name: "yugabytedb.api.connection.count",
+	},
+	YugabytedbAPIKeyspaceTableCount: metricInfo{
+		Name: "yugabytedb.api.keyspace.table.count",
+	},
+	YugabytedbAPIStatementCount: metricInfo{
+		Name: "yugabytedb.api.statement.count",
+	},
+	YugabytedbAPIStatementTime: metricInfo{
+		Name: "yugabytedb.api.statement.time",
+	},
 	YugabytedbConnectionCount: metricInfo{
 		Name: "yugabytedb.connection.count",
 	},
+	YugabytedbLockWaitingCount: metricInfo{
+		Name: "yugabytedb.lock.waiting.count",
+	},
+	YugabytedbMasterHeartbeatCount: metricInfo{
+		Name: "yugabytedb.master.heartbeat.count",
+	},
 	YugabytedbPgStatActivityActiveConnections: metricInfo{
 		Name: "yugabytedb.pg_stat_activity.active_connections",
 	},
 	YugabytedbPgStatActivityRunningQueries: metricInfo{
 		Name: "yugabytedb.pg_stat_activity.running_queries",
 	},
+	YugabytedbRaftLeaderCount: metricInfo{
+		Name: "yugabytedb.raft.leader.count",
+	},
+	YugabytedbRocksdbCompactionIo: metricInfo{
+		Name: "yugabytedb.rocksdb.compaction.io",
+	},
+	YugabytedbRocksdbSstFileCount: metricInfo{
+		Name: "yugabytedb.rocksdb.sst_file.count",
+	},
+	YugabytedbRocksdbSstFileSize: metricInfo{
+		Name: "yugabytedb.rocksdb.sst_file.size",
+	},
+	YugabytedbServerUp: metricInfo{
+		Name: "yugabytedb.server.up",
+	},
+	YugabytedbStatementCalls: metricInfo{
+		Name: "yugabytedb.statement.calls",
+	},
+	YugabytedbStatementMeanTime: metricInfo{
+		Name: "yugabytedb.statement.mean_time",
+	},
+	YugabytedbStatementRows: metricInfo{
+		Name: "yugabytedb.statement.rows",
+	},
+	YugabytedbStatementTime: metricInfo{
+		Name: "yugabytedb.statement.time",
+	},
+	YugabytedbTabletCount: metricInfo{
+		Name: "yugabytedb.tablet.count",
+	},
+	YugabytedbTransactionLongRunningCount: metricInfo{
+		Name: "yugabytedb.transaction.long_running.count",
+	},
+	YugabytedbTransactionOldestAge: metricInfo{
+		Name: "yugabytedb.transaction.oldest.age",
+	},
+	YugabytedbWalOperationCount: metricInfo{
+		Name: "yugabytedb.wal.operation.count",
+	},
+	YugabytedbWalOperationTime: metricInfo{
+		Name: "yugabytedb.wal.operation.time",
+	},
+}
+
+type metricsInfo struct {
+	YugabytedbActiveUsersCount                metricInfo
+	YugabytedbAPIConnectionCount              metricInfo
+	YugabytedbAPIKeyspaceTableCount           metricInfo
+	YugabytedbAPIStatementCount               metricInfo
+	YugabytedbAPIStatementTime                metricInfo
+	YugabytedbConnectionCount                 metricInfo
+	YugabytedbLockWaitingCount                metricInfo
+	YugabytedbMasterHeartbeatCount            metricInfo
+	YugabytedbPgStatActivityActiveConnections metricInfo
+	YugabytedbPgStatActivityRunningQueries    metricInfo
+	YugabytedbRaftLeaderCount                 metricInfo
+	YugabytedbRocksdbCompactionIo             metricInfo
+	YugabytedbRocksdbSstFileCount             metricInfo
+	YugabytedbRocksdbSstFileSize              metricInfo
+	YugabytedbServerUp                        metricInfo
+	YugabytedbStatementCalls                  metricInfo
+	YugabytedbStatementMeanTime               metricInfo
+	YugabytedbStatementRows                   metricInfo
+	YugabytedbStatementTime                   metricInfo
+	YugabytedbTabletCount                     metricInfo
+	YugabytedbTransactionLongRunningCount     metricInfo
+	YugabytedbTransactionOldestAge            metricInfo
+	YugabytedbWalOperationCount               metricInfo
+	YugabytedbWalOperationTime                metricInfo
+}
+
+type metricInfo struct {
+	Name string
+}
+
+type metricYugabytedbActiveUsersCount struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.active_users.count metric with initial data.
+func (m *metricYugabytedbActiveUsersCount) init() {
+	m.data.SetName("yugabytedb.active_users.count")
+	m.data.SetDescription("The number of unique active users connected to YugabyteDB with active client backend connections.")
+	m.data.SetUnit("{users}")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricYugabytedbActiveUsersCount) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, connectionUserAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("connection.user", connectionUserAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbActiveUsersCount) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbActiveUsersCount) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricYugabytedbActiveUsersCount(cfg MetricConfig) metricYugabytedbActiveUsersCount {
+	m := metricYugabytedbActiveUsersCount{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricYugabytedbAPIConnectionCount struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.api.connection.count metric with initial data.
+func (m *metricYugabytedbAPIConnectionCount) init() {
+	m.data.SetName("yugabytedb.api.connection.count")
+	m.data.SetDescription("The number of client connections open on the API server of the tserver.")
+	m.data.SetUnit("{connections}")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricYugabytedbAPIConnectionCount) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, dbAPIAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("db.api", dbAPIAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbAPIConnectionCount) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbAPIConnectionCount) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricYugabytedbAPIConnectionCount(cfg MetricConfig) metricYugabytedbAPIConnectionCount {
+	m := metricYugabytedbAPIConnectionCount{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricYugabytedbAPIKeyspaceTableCount struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.api.keyspace.table.count metric with initial data.
+func (m *metricYugabytedbAPIKeyspaceTableCount) init() {
+	m.data.SetName("yugabytedb.api.keyspace.table.count")
+	m.data.SetDescription("The number of tables of the keyspace.")
+	m.data.SetUnit("{tables}")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricYugabytedbAPIKeyspaceTableCount) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, dbAPIAttributeValue string, dbNamespaceAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("db.api", dbAPIAttributeValue)
+	dp.Attributes().PutStr("db.namespace", dbNamespaceAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbAPIKeyspaceTableCount) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbAPIKeyspaceTableCount) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricYugabytedbAPIKeyspaceTableCount(cfg MetricConfig) metricYugabytedbAPIKeyspaceTableCount {
+	m := metricYugabytedbAPIKeyspaceTableCount{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricYugabytedbAPIStatementCount struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.api.statement.count metric with initial data.
+func (m *metricYugabytedbAPIStatementCount) init() {
+	m.data.SetName("yugabytedb.api.statement.count")
+	m.data.SetDescription("The number of statements executed by the API server of the tserver.")
+	m.data.SetUnit("{statements}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricYugabytedbAPIStatementCount) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, dbAPIAttributeValue string, statementTypeAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("db.api", dbAPIAttributeValue)
+	dp.Attributes().PutStr("statement.type", statementTypeAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbAPIStatementCount) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbAPIStatementCount) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricYugabytedbAPIStatementCount(cfg MetricConfig) metricYugabytedbAPIStatementCount {
+	m := metricYugabytedbAPIStatementCount{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricYugabytedbAPIStatementTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.api.statement.time metric with initial data.
+func (m *metricYugabytedbAPIStatementTime) init() {
+	m.data.SetName("yugabytedb.api.statement.time")
+	m.data.SetDescription("The total time spent executing statements by the API server of the tserver.")
+	m.data.SetUnit("us")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricYugabytedbAPIStatementTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, dbAPIAttributeValue string, statementTypeAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("db.api", dbAPIAttributeValue)
+	dp.Attributes().PutStr("statement.type", statementTypeAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbAPIStatementTime) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbAPIStatementTime) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricYugabytedbAPIStatementTime(cfg MetricConfig) metricYugabytedbAPIStatementTime {
+	m := metricYugabytedbAPIStatementTime{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricYugabytedbConnectionCount struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.connection.count metric with initial data.
+func (m *metricYugabytedbConnectionCount) init() {
+	m.data.SetName("yugabytedb.connection.count")
+	m.data.SetDescription("The number of database connections by state and user.")
+	m.data.SetUnit("{connections}")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricYugabytedbConnectionCount) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, connectionStateAttributeValue string, connectionUserAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("connection.state", connectionStateAttributeValue)
+	dp.Attributes().PutStr("connection.user", connectionUserAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbConnectionCount) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbConnectionCount) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricYugabytedbConnectionCount(cfg MetricConfig) metricYugabytedbConnectionCount {
+	m := metricYugabytedbConnectionCount{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricYugabytedbLockWaitingCount struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.lock.waiting.count metric with initial data.
+func (m *metricYugabytedbLockWaitingCount) init() {
+	m.data.SetName("yugabytedb.lock.waiting.count")
+	m.data.SetDescription("The number of locks that are waited for, by lock type.")
+	m.data.SetUnit("{locks}")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricYugabytedbLockWaitingCount) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, lockTypeAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("lock.type", lockTypeAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbLockWaitingCount) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbLockWaitingCount) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricYugabytedbLockWaitingCount(cfg MetricConfig) metricYugabytedbLockWaitingCount {
+	m := metricYugabytedbLockWaitingCount{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricYugabytedbMasterHeartbeatCount struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.master.heartbeat.count metric with initial data.
+func (m *metricYugabytedbMasterHeartbeatCount) init() {
+	m.data.SetName("yugabytedb.master.heartbeat.count")
+	m.data.SetDescription("The number of heartbeats received by the master from the tservers.")
+	m.data.SetUnit("{heartbeats}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+}
+
+func (m *metricYugabytedbMasterHeartbeatCount) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbMasterHeartbeatCount) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbMasterHeartbeatCount) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricYugabytedbMasterHeartbeatCount(cfg MetricConfig) metricYugabytedbMasterHeartbeatCount {
+	m := metricYugabytedbMasterHeartbeatCount{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricYugabytedbPgStatActivityActiveConnections struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.pg_stat_activity.active_connections metric with initial data.
+func (m *metricYugabytedbPgStatActivityActiveConnections) init() {
+	m.data.SetName("yugabytedb.pg_stat_activity.active_connections")
+	m.data.SetDescription("The total number of active connections to YugabyteDB.")
+	m.data.SetUnit("{connections}")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricYugabytedbPgStatActivityActiveConnections) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbPgStatActivityActiveConnections) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbPgStatActivityActiveConnections) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricYugabytedbPgStatActivityActiveConnections(cfg MetricConfig) metricYugabytedbPgStatActivityActiveConnections {
+	m := metricYugabytedbPgStatActivityActiveConnections{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricYugabytedbPgStatActivityRunningQueries struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.pg_stat_activity.running_queries metric with initial data.
+func (m *metricYugabytedbPgStatActivityRunningQueries) init() {
+	m.data.SetName("yugabytedb.pg_stat_activity.running_queries")
+	m.data.SetDescription("The number of currently running queries in YugabyteDB.")
+	m.data.SetUnit("{queries}")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricYugabytedbPgStatActivityRunningQueries) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbPgStatActivityRunningQueries) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbPgStatActivityRunningQueries) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricYugabytedbPgStatActivityRunningQueries(cfg MetricConfig) metricYugabytedbPgStatActivityRunningQueries {
+	m := metricYugabytedbPgStatActivityRunningQueries{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricYugabytedbRaftLeaderCount struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.raft.leader.count metric with initial data.
+func (m *metricYugabytedbRaftLeaderCount) init() {
+	m.data.SetName("yugabytedb.raft.leader.count")
+	m.data.SetDescription("The number of tablet peers hosted by the server that are Raft leaders.")
+	m.data.SetUnit("{tablets}")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricYugabytedbRaftLeaderCount) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbRaftLeaderCount) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbRaftLeaderCount) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricYugabytedbRaftLeaderCount(cfg MetricConfig) metricYugabytedbRaftLeaderCount {
+	m := metricYugabytedbRaftLeaderCount{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricYugabytedbRocksdbCompactionIo struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.rocksdb.compaction.io metric with initial data.
+func (m *metricYugabytedbRocksdbCompactionIo) init() {
+	m.data.SetName("yugabytedb.rocksdb.compaction.io")
+	m.data.SetDescription("The number of bytes read and written by RocksDB compactions.")
+	m.data.SetUnit("By")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricYugabytedbRocksdbCompactionIo) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, compactionDirectionAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("compaction.direction", compactionDirectionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbRocksdbCompactionIo) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbRocksdbCompactionIo) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricYugabytedbRocksdbCompactionIo(cfg MetricConfig) metricYugabytedbRocksdbCompactionIo {
+	m := metricYugabytedbRocksdbCompactionIo{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricYugabytedbRocksdbSstFileCount struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.rocksdb.sst_file.count metric with initial data.
+func (m *metricYugabytedbRocksdbSstFileCount) init() {
+	m.data.SetName("yugabytedb.rocksdb.sst_file.count")
+	m.data.SetDescription("The number of SST files of the current RocksDB version, across the tablets of the server.")
+	m.data.SetUnit("{files}")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricYugabytedbRocksdbSstFileCount) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbRocksdbSstFileCount) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbRocksdbSstFileCount) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricYugabytedbRocksdbSstFileCount(cfg MetricConfig) metricYugabytedbRocksdbSstFileCount {
+	m := metricYugabytedbRocksdbSstFileCount{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricYugabytedbRocksdbSstFileSize struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.rocksdb.sst_file.size metric with initial data.
+func (m *metricYugabytedbRocksdbSstFileSize) init() {
+	m.data.SetName("yugabytedb.rocksdb.sst_file.size")
+	m.data.SetDescription("The size of the SST files of the current RocksDB version, across the tablets of the server.")
+	m.data.SetUnit("By")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricYugabytedbRocksdbSstFileSize) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbRocksdbSstFileSize) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbRocksdbSstFileSize) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricYugabytedbRocksdbSstFileSize(cfg MetricConfig) metricYugabytedbRocksdbSstFileSize {
+	m := metricYugabytedbRocksdbSstFileSize{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricYugabytedbServerUp struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.server.up metric with initial data.
+func (m *metricYugabytedbServerUp) init() {
+	m.data.SetName("yugabytedb.server.up")
+	m.data.SetDescription("Whether the Prometheus endpoint of the server could be scraped (1) or not (0).")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricYugabytedbServerUp) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbServerUp) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbServerUp) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricYugabytedbServerUp(cfg MetricConfig) metricYugabytedbServerUp {
+	m := metricYugabytedbServerUp{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricYugabytedbStatementCalls struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.statement.calls metric with initial data.
+func (m *metricYugabytedbStatementCalls) init() {
+	m.data.SetName("yugabytedb.statement.calls")
+	m.data.SetDescription("The number of times the statement was executed, for the statements with the highest total execution time.")
+	m.data.SetUnit("{calls}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricYugabytedbStatementCalls) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, queryIDAttributeValue string, connectionUserAttributeValue string, dbNamespaceAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("query.id", queryIDAttributeValue)
+	dp.Attributes().PutStr("connection.user", connectionUserAttributeValue)
+	dp.Attributes().PutStr("db.namespace", dbNamespaceAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbStatementCalls) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
 }
 
-type metricsInfo struct {
-	YugabytedbActiveUsersCount                metricInfo
-	YugabytedbConnectionCount                 metricInfo
-	YugabytedbPgStatActivityActiveConnections metricInfo
-	YugabytedbPgStatActivityRunningQueries    metricInfo
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbStatementCalls) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
 }
 
-type metricInfo struct {
-	Name string
+func newMetricYugabytedbStatementCalls(cfg MetricConfig) metricYugabytedbStatementCalls {
+	m := metricYugabytedbStatementCalls{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
 }
 
-type metricYugabytedbActiveUsersCount struct {
+type metricYugabytedbStatementMeanTime struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
 	capacity int            // max observed number of data points added to the metric.
 }
 
-// init fills yugabytedb.active_users.count metric with initial data.
-func (m *metricYugabytedbActiveUsersCount) init() {
-	m.data.SetName("yugabytedb.active_users.count")
-	m.data.SetDescription("The number of unique active users connected to YugabyteDB with active client backend connections.")
-	m.data.SetUnit("{users}")
+// init fills yugabytedb.statement.mean_time metric with initial data.
+func (m *metricYugabytedbStatementMeanTime) init() {
+	m.data.SetName("yugabytedb.statement.mean_time")
+	m.data.SetDescription("The mean execution time of the statement, for the statements with the highest total execution time.")
+	m.data.SetUnit("ms")
 	m.data.SetEmptyGauge()
 	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
 }
 
-func (m *metricYugabytedbActiveUsersCount) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, connectionUserAttributeValue string) {
+func (m *metricYugabytedbStatementMeanTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, queryIDAttributeValue string, connectionUserAttributeValue string, dbNamespaceAttributeValue string) {
 	if !m.config.Enabled {
 		return
 	}
 	dp := m.data.Gauge().DataPoints().AppendEmpty()
 	dp.SetStartTimestamp(start)
 	dp.SetTimestamp(ts)
-	dp.SetIntValue(val)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("query.id", queryIDAttributeValue)
 	dp.Attributes().PutStr("connection.user", connectionUserAttributeValue)
+	dp.Attributes().PutStr("db.namespace", dbNamespaceAttributeValue)
 }
 
 // updateCapacity saves max length of data point slices that will be used for the slice capacity.
-func (m *metricYugabytedbActiveUsersCount) updateCapacity() {
+func (m *metricYugabytedbStatementMeanTime) updateCapacity() {
 	if m.data.Gauge().DataPoints().Len() > m.capacity {
 		m.capacity = m.data.Gauge().DataPoints().Len()
 	}
 }
 
 // emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
-func (m *metricYugabytedbActiveUsersCount) emit(metrics pmetric.MetricSlice) {
+func (m *metricYugabytedbStatementMeanTime) emit(metrics pmetric.MetricSlice) {
 	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
 		m.updateCapacity()
 		m.data.MoveTo(metrics.AppendEmpty())
@@ -79,8 +1070,8 @@ func (m *metricYugabytedbActiveUsersCount) emit(metrics pmetric.MetricSlice) {
 	}
 }
 
-func newMetricYugabytedbActiveUsersCount(cfg MetricConfig) metricYugabytedbActiveUsersCount {
-	m := metricYugabytedbActiveUsersCount{config: cfg}
+func newMetricYugabytedbStatementMeanTime(cfg MetricConfig) metricYugabytedbStatementMeanTime {
+	m := metricYugabytedbStatementMeanTime{config: cfg}
 	if cfg.Enabled {
 		m.data = pmetric.NewMetric()
 		m.init()
@@ -88,22 +1079,131 @@ func newMetricYugabytedbActiveUsersCount(cfg MetricConfig) metricYugabytedbActiv
 	return m
 }
 
-type metricYugabytedbConnectionCount struct {
+type metricYugabytedbStatementRows struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
 	capacity int            // max observed number of data points added to the metric.
 }
 
-// init fills yugabytedb.connection.count metric with initial data.
-func (m *metricYugabytedbConnectionCount) init() {
-	m.data.SetName("yugabytedb.connection.count")
-	m.data.SetDescription("The number of database connections by state and user.")
-	m.data.SetUnit("{connections}")
+// init fills yugabytedb.statement.rows metric with initial data.
+func (m *metricYugabytedbStatementRows) init() {
+	m.data.SetName("yugabytedb.statement.rows")
+	m.data.SetDescription("The number of rows retrieved or affected by the statement, for the statements with the highest total execution time.")
+	m.data.SetUnit("{rows}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricYugabytedbStatementRows) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, queryIDAttributeValue string, connectionUserAttributeValue string, dbNamespaceAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("query.id", queryIDAttributeValue)
+	dp.Attributes().PutStr("connection.user", connectionUserAttributeValue)
+	dp.Attributes().PutStr("db.namespace", dbNamespaceAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbStatementRows) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbStatementRows) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricYugabytedbStatementRows(cfg MetricConfig) metricYugabytedbStatementRows {
+	m := metricYugabytedbStatementRows{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricYugabytedbStatementTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.statement.time metric with initial data.
+func (m *metricYugabytedbStatementTime) init() {
+	m.data.SetName("yugabytedb.statement.time")
+	m.data.SetDescription("The total execution time of the statement, for the statements with the highest total execution time.")
+	m.data.SetUnit("ms")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricYugabytedbStatementTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, queryIDAttributeValue string, connectionUserAttributeValue string, dbNamespaceAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("query.id", queryIDAttributeValue)
+	dp.Attributes().PutStr("connection.user", connectionUserAttributeValue)
+	dp.Attributes().PutStr("db.namespace", dbNamespaceAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbStatementTime) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbStatementTime) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricYugabytedbStatementTime(cfg MetricConfig) metricYugabytedbStatementTime {
+	m := metricYugabytedbStatementTime{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricYugabytedbTabletCount struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.tablet.count metric with initial data.
+func (m *metricYugabytedbTabletCount) init() {
+	m.data.SetName("yugabytedb.tablet.count")
+	m.data.SetDescription("The number of tablet peers hosted by the server.")
+	m.data.SetUnit("{tablets}")
 	m.data.SetEmptyGauge()
-	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
 }
 
-func (m *metricYugabytedbConnectionCount) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, connectionStateAttributeValue string, connectionUserAttributeValue string) {
+func (m *metricYugabytedbTabletCount) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
 	if !m.config.Enabled {
 		return
 	}
@@ -111,19 +1211,17 @@ func (m *metricYugabytedbConnectionCount) recordDataPoint(start pcommon.Timestam
 	dp.SetStartTimestamp(start)
 	dp.SetTimestamp(ts)
 	dp.SetIntValue(val)
-	dp.Attributes().PutStr("connection.state", connectionStateAttributeValue)
-	dp.Attributes().PutStr("connection.user", connectionUserAttributeValue)
 }
 
 // updateCapacity saves max length of data point slices that will be used for the slice capacity.
-func (m *metricYugabytedbConnectionCount) updateCapacity() {
+func (m *metricYugabytedbTabletCount) updateCapacity() {
 	if m.data.Gauge().DataPoints().Len() > m.capacity {
 		m.capacity = m.data.Gauge().DataPoints().Len()
 	}
 }
 
 // emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
-func (m *metricYugabytedbConnectionCount) emit(metrics pmetric.MetricSlice) {
+func (m *metricYugabytedbTabletCount) emit(metrics pmetric.MetricSlice) {
 	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
 		m.updateCapacity()
 		m.data.MoveTo(metrics.AppendEmpty())
@@ -131,8 +1229,8 @@ func (m *metricYugabytedbConnectionCount) emit(metrics pmetric.MetricSlice) {
 	}
 }
 
-func newMetricYugabytedbConnectionCount(cfg MetricConfig) metricYugabytedbConnectionCount {
-	m := metricYugabytedbConnectionCount{config: cfg}
+func newMetricYugabytedbTabletCount(cfg MetricConfig) metricYugabytedbTabletCount {
+	m := metricYugabytedbTabletCount{config: cfg}
 	if cfg.Enabled {
 		m.data = pmetric.NewMetric()
 		m.init()
@@ -140,21 +1238,21 @@ func newMetricYugabytedbConnectionCount(cfg MetricConfig) metricYugabytedbConnec
 	return m
 }
 
-type metricYugabytedbPgStatActivityActiveConnections struct {
+type metricYugabytedbTransactionLongRunningCount struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
 	capacity int            // max observed number of data points added to the metric.
 }
 
-// init fills yugabytedb.pg_stat_activity.active_connections metric with initial data.
-func (m *metricYugabytedbPgStatActivityActiveConnections) init() {
-	m.data.SetName("yugabytedb.pg_stat_activity.active_connections")
-	m.data.SetDescription("The total number of active connections to YugabyteDB.")
-	m.data.SetUnit("{connections}")
+// init fills yugabytedb.transaction.long_running.count metric with initial data.
+func (m *metricYugabytedbTransactionLongRunningCount) init() {
+	m.data.SetName("yugabytedb.transaction.long_running.count")
+	m.data.SetDescription("The number of open transactions older than the long_running_transaction_threshold.")
+	m.data.SetUnit("{transactions}")
 	m.data.SetEmptyGauge()
 }
 
-func (m *metricYugabytedbPgStatActivityActiveConnections) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+func (m *metricYugabytedbTransactionLongRunningCount) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
 	if !m.config.Enabled {
 		return
 	}
@@ -165,14 +1263,14 @@ func (m *metricYugabytedbPgStatActivityActiveConnections) recordDataPoint(start
 }
 
 // updateCapacity saves max length of data point slices that will be used for the slice capacity.
-func (m *metricYugabytedbPgStatActivityActiveConnections) updateCapacity() {
+func (m *metricYugabytedbTransactionLongRunningCount) updateCapacity() {
 	if m.data.Gauge().DataPoints().Len() > m.capacity {
 		m.capacity = m.data.Gauge().DataPoints().Len()
 	}
 }
 
 // emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
-func (m *metricYugabytedbPgStatActivityActiveConnections) emit(metrics pmetric.MetricSlice) {
+func (m *metricYugabytedbTransactionLongRunningCount) emit(metrics pmetric.MetricSlice) {
 	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
 		m.updateCapacity()
 		m.data.MoveTo(metrics.AppendEmpty())
@@ -180,8 +1278,8 @@ func (m *metricYugabytedbPgStatActivityActiveConnections) emit(metrics pmetric.M
 	}
 }
 
-func newMetricYugabytedbPgStatActivityActiveConnections(cfg MetricConfig) metricYugabytedbPgStatActivityActiveConnections {
-	m := metricYugabytedbPgStatActivityActiveConnections{config: cfg}
+func newMetricYugabytedbTransactionLongRunningCount(cfg MetricConfig) metricYugabytedbTransactionLongRunningCount {
+	m := metricYugabytedbTransactionLongRunningCount{config: cfg}
 	if cfg.Enabled {
 		m.data = pmetric.NewMetric()
 		m.init()
@@ -189,21 +1287,21 @@ func newMetricYugabytedbPgStatActivityActiveConnections(cfg MetricConfig) metric
 	return m
 }
 
-type metricYugabytedbPgStatActivityRunningQueries struct {
+type metricYugabytedbTransactionOldestAge struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
 	capacity int            // max observed number of data points added to the metric.
 }
 
-// init fills yugabytedb.pg_stat_activity.running_queries metric with initial data.
-func (m *metricYugabytedbPgStatActivityRunningQueries) init() {
-	m.data.SetName("yugabytedb.pg_stat_activity.running_queries")
-	m.data.SetDescription("The number of currently running queries in YugabyteDB.")
-	m.data.SetUnit("{queries}")
+// init fills yugabytedb.transaction.oldest.age metric with initial data.
+func (m *metricYugabytedbTransactionOldestAge) init() {
+	m.data.SetName("yugabytedb.transaction.oldest.age")
+	m.data.SetDescription("The age of the oldest open transaction.")
+	m.data.SetUnit("s")
 	m.data.SetEmptyGauge()
 }
 
-func (m *metricYugabytedbPgStatActivityRunningQueries) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+func (m *metricYugabytedbTransactionOldestAge) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
 	if !m.config.Enabled {
 		return
 	}
@@ -214,14 +1312,14 @@ func (m *metricYugabytedbPgStatActivityRunningQueries) recordDataPoint(start pco
 }
 
 // updateCapacity saves max length of data point slices that will be used for the slice capacity.
-func (m *metricYugabytedbPgStatActivityRunningQueries) updateCapacity() {
+func (m *metricYugabytedbTransactionOldestAge) updateCapacity() {
 	if m.data.Gauge().DataPoints().Len() > m.capacity {
 		m.capacity = m.data.Gauge().DataPoints().Len()
 	}
 }
 
 // emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
-func (m *metricYugabytedbPgStatActivityRunningQueries) emit(metrics pmetric.MetricSlice) {
+func (m *metricYugabytedbTransactionOldestAge) emit(metrics pmetric.MetricSlice) {
 	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
 		m.updateCapacity()
 		m.data.MoveTo(metrics.AppendEmpty())
@@ -229,8 +1327,114 @@ func (m *metricYugabytedbPgStatActivityRunningQueries) emit(metrics pmetric.Metr
 	}
 }
 
-func newMetricYugabytedbPgStatActivityRunningQueries(cfg MetricConfig) metricYugabytedbPgStatActivityRunningQueries {
-	m := metricYugabytedbPgStatActivityRunningQueries{config: cfg}
+func newMetricYugabytedbTransactionOldestAge(cfg MetricConfig) metricYugabytedbTransactionOldestAge {
+	m := metricYugabytedbTransactionOldestAge{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricYugabytedbWalOperationCount struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.wal.operation.count metric with initial data.
+func (m *metricYugabytedbWalOperationCount) init() {
+	m.data.SetName("yugabytedb.wal.operation.count")
+	m.data.SetDescription("The number of write-ahead log operations.")
+	m.data.SetUnit("{operations}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricYugabytedbWalOperationCount) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, walOperationAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("wal.operation", walOperationAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbWalOperationCount) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbWalOperationCount) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricYugabytedbWalOperationCount(cfg MetricConfig) metricYugabytedbWalOperationCount {
+	m := metricYugabytedbWalOperationCount{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricYugabytedbWalOperationTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills yugabytedb.wal.operation.time metric with initial data.
+func (m *metricYugabytedbWalOperationTime) init() {
+	m.data.SetName("yugabytedb.wal.operation.time")
+	m.data.SetDescription("The total time spent in write-ahead log operations.")
+	m.data.SetUnit("us")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricYugabytedbWalOperationTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, walOperationAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("wal.operation", walOperationAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricYugabytedbWalOperationTime) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricYugabytedbWalOperationTime) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricYugabytedbWalOperationTime(cfg MetricConfig) metricYugabytedbWalOperationTime {
+	m := metricYugabytedbWalOperationTime{config: cfg}
 	if cfg.Enabled {
 		m.data = pmetric.NewMetric()
 		m.init()
@@ -246,10 +1450,32 @@ type MetricsBuilder struct {
 	metricsCapacity                                 int                  // maximum observed number of metrics per resource.
 	metricsBuffer                                   pmetric.Metrics      // accumulates metrics data before emitting.
 	buildInfo                                       component.BuildInfo  // contains version information.
+	resourceAttributeIncludeFilter                  map[string]filter.Filter
+	resourceAttributeExcludeFilter                  map[string]filter.Filter
 	metricYugabytedbActiveUsersCount                metricYugabytedbActiveUsersCount
+	metricYugabytedbAPIConnectionCount              metricYugabytedbAPIConnectionCount
+	metricYugabytedbAPIKeyspaceTableCount           metricYugabytedbAPIKeyspaceTableCount
+	metricYugabytedbAPIStatementCount               metricYugabytedbAPIStatementCount
+	metricYugabytedbAPIStatementTime                metricYugabytedbAPIStatementTime
 	metricYugabytedbConnectionCount                 metricYugabytedbConnectionCount
+	metricYugabytedbLockWaitingCount                metricYugabytedbLockWaitingCount
+	metricYugabytedbMasterHeartbeatCount            metricYugabytedbMasterHeartbeatCount
 	metricYugabytedbPgStatActivityActiveConnections metricYugabytedbPgStatActivityActiveConnections
 	metricYugabytedbPgStatActivityRunningQueries    metricYugabytedbPgStatActivityRunningQueries
+	metricYugabytedbRaftLeaderCount                 metricYugabytedbRaftLeaderCount
+	metricYugabytedbRocksdbCompactionIo             metricYugabytedbRocksdbCompactionIo
+	metricYugabytedbRocksdbSstFileCount             metricYugabytedbRocksdbSstFileCount
+	metricYugabytedbRocksdbSstFileSize              metricYugabytedbRocksdbSstFileSize
+	metricYugabytedbServerUp                        metricYugabytedbServerUp
+	metricYugabytedbStatementCalls                  metricYugabytedbStatementCalls
+	metricYugabytedbStatementMeanTime               metricYugabytedbStatementMeanTime
+	metricYugabytedbStatementRows                   metricYugabytedbStatementRows
+	metricYugabytedbStatementTime                   metricYugabytedbStatementTime
+	metricYugabytedbTabletCount                     metricYugabytedbTabletCount
+	metricYugabytedbTransactionLongRunningCount     metricYugabytedbTransactionLongRunningCount
+	metricYugabytedbTransactionOldestAge            metricYugabytedbTransactionOldestAge
+	metricYugabytedbWalOperationCount               metricYugabytedbWalOperationCount
+	metricYugabytedbWalOperationTime                metricYugabytedbWalOperationTime
 }
 
 // MetricBuilderOption applies changes to default metrics builder.
@@ -271,14 +1497,66 @@ func WithStartTime(startTime pcommon.Timestamp) MetricBuilderOption {
 }
 func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.Settings, options ...MetricBuilderOption) *MetricsBuilder {
 	mb := &MetricsBuilder{
-		config:                           mbc,
-		startTime:                        pcommon.NewTimestampFromTime(time.Now()),
-		metricsBuffer:                    pmetric.NewMetrics(),
-		buildInfo:                        settings.BuildInfo,
-		metricYugabytedbActiveUsersCount: newMetricYugabytedbActiveUsersCount(mbc.Metrics.YugabytedbActiveUsersCount),
-		metricYugabytedbConnectionCount:  newMetricYugabytedbConnectionCount(mbc.Metrics.YugabytedbConnectionCount),
+		config:                                          mbc,
+		startTime:                                       pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                                   pmetric.NewMetrics(),
+		buildInfo:                                       settings.BuildInfo,
+		metricYugabytedbActiveUsersCount:                newMetricYugabytedbActiveUsersCount(mbc.Metrics.YugabytedbActiveUsersCount),
+		metricYugabytedbAPIConnectionCount:              newMetricYugabytedbAPIConnectionCount(mbc.Metrics.YugabytedbAPIConnectionCount),
+		metricYugabytedbAPIKeyspaceTableCount:           newMetricYugabytedbAPIKeyspaceTableCount(mbc.Metrics.YugabytedbAPIKeyspaceTableCount),
+		metricYugabytedbAPIStatementCount:               newMetricYugabytedbAPIStatementCount(mbc.Metrics.YugabytedbAPIStatementCount),
+		metricYugabytedbAPIStatementTime:                newMetricYugabytedbAPIStatementTime(mbc.Metrics.YugabytedbAPIStatementTime),
+		metricYugabytedbConnectionCount:                 newMetricYugabytedbConnectionCount(mbc.Metrics.YugabytedbConnectionCount),
+		metricYugabytedbLockWaitingCount:                newMetricYugabytedbLockWaitingCount(mbc.Metrics.YugabytedbLockWaitingCount),
+		metricYugabytedbMasterHeartbeatCount:            newMetricYugabytedbMasterHeartbeatCount(mbc.Metrics.YugabytedbMasterHeartbeatCount),
 		metricYugabytedbPgStatActivityActiveConnections: newMetricYugabytedbPgStatActivityActiveConnections(mbc.Metrics.YugabytedbPgStatActivityActiveConnections),
 		metricYugabytedbPgStatActivityRunningQueries:    newMetricYugabytedbPgStatActivityRunningQueries(mbc.Metrics.YugabytedbPgStatActivityRunningQueries),
+		metricYugabytedbRaftLeaderCount:                 newMetricYugabytedbRaftLeaderCount(mbc.Metrics.YugabytedbRaftLeaderCount),
+		metricYugabytedbRocksdbCompactionIo:             newMetricYugabytedbRocksdbCompactionIo(mbc.Metrics.YugabytedbRocksdbCompactionIo),
+		metricYugabytedbRocksdbSstFileCount:             newMetricYugabytedbRocksdbSstFileCount(mbc.Metrics.YugabytedbRocksdbSstFileCount),
+		metricYugabytedbRocksdbSstFileSize:              newMetricYugabytedbRocksdbSstFileSize(mbc.Metrics.YugabytedbRocksdbSstFileSize),
+		metricYugabytedbServerUp:                        newMetricYugabytedbServerUp(mbc.Metrics.YugabytedbServerUp),
+		metricYugabytedbStatementCalls:                  newMetricYugabytedbStatementCalls(mbc.Metrics.YugabytedbStatementCalls),
+		metricYugabytedbStatementMeanTime:               newMetricYugabytedbStatementMeanTime(mbc.Metrics.YugabytedbStatementMeanTime),
+		metricYugabytedbStatementRows:                   newMetricYugabytedbStatementRows(mbc.Metrics.YugabytedbStatementRows),
+		metricYugabytedbStatementTime:                   newMetricYugabytedbStatementTime(mbc.Metrics.YugabytedbStatementTime),
+		metricYugabytedbTabletCount:                     newMetricYugabytedbTabletCount(mbc.Metrics.YugabytedbTabletCount),
+		metricYugabytedbTransactionLongRunningCount:     newMetricYugabytedbTransactionLongRunningCount(mbc.Metrics.YugabytedbTransactionLongRunningCount),
+		metricYugabytedbTransactionOldestAge:            newMetricYugabytedbTransactionOldestAge(mbc.Metrics.YugabytedbTransactionOldestAge),
+		metricYugabytedbWalOperationCount:               newMetricYugabytedbWalOperationCount(mbc.Metrics.YugabytedbWalOperationCount),
+		metricYugabytedbWalOperationTime:                newMetricYugabytedbWalOperationTime(mbc.Metrics.YugabytedbWalOperationTime),
+		resourceAttributeIncludeFilter:                  make(map[string]filter.Filter),
+		resourceAttributeExcludeFilter:                  make(map[string]filter.Filter),
+	}
+	if mbc.ResourceAttributes.YugabytedbNodeCloud.MetricsInclude != nil {
+		mb.resourceAttributeIncludeFilter["yugabytedb.node.cloud"] = filter.CreateFilter(mbc.ResourceAttributes.YugabytedbNodeCloud.MetricsInclude)
+	}
+	if mbc.ResourceAttributes.YugabytedbNodeCloud.MetricsExclude != nil {
+		mb.resourceAttributeExcludeFilter["yugabytedb.node.cloud"] = filter.CreateFilter(mbc.ResourceAttributes.YugabytedbNodeCloud.MetricsExclude)
+	}
+	if mbc.ResourceAttributes.YugabytedbNodeHost.MetricsInclude != nil {
+		mb.resourceAttributeIncludeFilter["yugabytedb.node.host"] = filter.CreateFilter(mbc.ResourceAttributes.YugabytedbNodeHost.MetricsInclude)
+	}
+	if mbc.ResourceAttributes.YugabytedbNodeHost.MetricsExclude != nil {
+		mb.resourceAttributeExcludeFilter["yugabytedb.node.host"] = filter.CreateFilter(mbc.ResourceAttributes.YugabytedbNodeHost.MetricsExclude)
+	}
+	if mbc.ResourceAttributes.YugabytedbNodeRegion.MetricsInclude != nil {
+		mb.resourceAttributeIncludeFilter["yugabytedb.node.region"] = filter.CreateFilter(mbc.ResourceAttributes.YugabytedbNodeRegion.MetricsInclude)
+	}
+	if mbc.ResourceAttributes.YugabytedbNodeRegion.MetricsExclude != nil {
+		mb.resourceAttributeExcludeFilter["yugabytedb.node.region"] = filter.CreateFilter(mbc.ResourceAttributes.YugabytedbNodeRegion.MetricsExclude)
+	}
+	if mbc.ResourceAttributes.YugabytedbNodeZone.MetricsInclude != nil {
+		mb.resourceAttributeIncludeFilter["yugabytedb.node.zone"] = filter.CreateFilter(mbc.ResourceAttributes.YugabytedbNodeZone.MetricsInclude)
+	}
+	if mbc.ResourceAttributes.YugabytedbNodeZone.MetricsExclude != nil {
+		mb.resourceAttributeExcludeFilter["yugabytedb.node.zone"] = filter.CreateFilter(mbc.ResourceAttributes.YugabytedbNodeZone.MetricsExclude)
+	}
+	if mbc.ResourceAttributes.YugabytedbServerType.MetricsInclude != nil {
+		mb.resourceAttributeIncludeFilter["yugabytedb.server.type"] = filter.CreateFilter(mbc.ResourceAttributes.YugabytedbServerType.MetricsInclude)
+	}
+	if mbc.ResourceAttributes.YugabytedbServerType.MetricsExclude != nil {
+		mb.resourceAttributeExcludeFilter["yugabytedb.server.type"] = filter.CreateFilter(mbc.ResourceAttributes.YugabytedbServerType.MetricsExclude)
 	}
 
 	for _, op := range options {
@@ -287,6 +1565,11 @@ func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.Settings, opt
 	return mb
 }
 
+// NewResourceBuilder returns a new resource builder that should be used to build a resource associated with for the emitted metrics.
+func (mb *MetricsBuilder) NewResourceBuilder() *ResourceBuilder {
+	return NewResourceBuilder(mb.config.ResourceAttributes)
+}
+
 // updateCapacity updates max length of metrics and resource attributes that will be used for the slice capacity.
 func (mb *MetricsBuilder) updateCapacity(rm pmetric.ResourceMetrics) {
 	if mb.metricsCapacity < rm.ScopeMetrics().At(0).Metrics().Len() {
@@ -345,13 +1628,43 @@ func (mb *MetricsBuilder) EmitForResource(options ...ResourceMetricsOption) {
 	ils.Scope().SetVersion(mb.buildInfo.Version)
 	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
 	mb.metricYugabytedbActiveUsersCount.emit(ils.Metrics())
+	mb.metricYugabytedbAPIConnectionCount.emit(ils.Metrics())
+	mb.metricYugabytedbAPIKeyspaceTableCount.emit(ils.Metrics())
+	mb.metricYugabytedbAPIStatementCount.emit(ils.Metrics())
+	mb.metricYugabytedbAPIStatementTime.emit(ils.Metrics())
 	mb.metricYugabytedbConnectionCount.emit(ils.Metrics())
+	mb.metricYugabytedbLockWaitingCount.emit(ils.Metrics())
+	mb.metricYugabytedbMasterHeartbeatCount.emit(ils.Metrics())
 	mb.metricYugabytedbPgStatActivityActiveConnections.emit(ils.Metrics())
 	mb.metricYugabytedbPgStatActivityRunningQueries.emit(ils.Metrics())
+	mb.metricYugabytedbRaftLeaderCount.emit(ils.Metrics())
+	mb.metricYugabytedbRocksdbCompactionIo.emit(ils.Metrics())
+	mb.metricYugabytedbRocksdbSstFileCount.emit(ils.Metrics())
+	mb.metricYugabytedbRocksdbSstFileSize.emit(ils.Metrics())
+	mb.metricYugabytedbServerUp.emit(ils.Metrics())
+	mb.metricYugabytedbStatementCalls.emit(ils.Metrics())
+	mb.metricYugabytedbStatementMeanTime.emit(ils.Metrics())
+	mb.metricYugabytedbStatementRows.emit(ils.Metrics())
+	mb.metricYugabytedbStatementTime.emit(ils.Metrics())
+	mb.metricYugabytedbTabletCount.emit(ils.Metrics())
+	mb.metricYugabytedbTransactionLongRunningCount.emit(ils.Metrics())
+	mb.metricYugabytedbTransactionOldestAge.emit(ils.Metrics())
+	mb.metricYugabytedbWalOperationCount.emit(ils.Metrics())
+	mb.metricYugabytedbWalOperationTime.emit(ils.Metrics())
 
 	for _, op := range options {
 		op.apply(rm)
 	}
+	for attr, filter := range mb.resourceAttributeIncludeFilter {
+		if val, ok := rm.Resource().Attributes().Get(attr); ok && !filter.Matches(val.AsString()) {
+			return
+		}
+	}
+	for attr, filter := range mb.resourceAttributeExcludeFilter {
+		if val, ok := rm.Resource().Attributes().Get(attr); ok && filter.Matches(val.AsString()) {
+			return
+		}
+	}
 
 	if ils.Metrics().Len() > 0 {
 		mb.updateCapacity(rm)
@@ -374,11 +1687,41 @@ func (mb *MetricsBuilder) RecordYugabytedbActiveUsersCountDataPoint(ts pcommon.T
 	mb.metricYugabytedbActiveUsersCount.recordDataPoint(mb.startTime, ts, val, connectionUserAttributeValue)
 }
 
+// RecordYugabytedbAPIConnectionCountDataPoint adds a data point to yugabytedb.api.connection.count metric.
+func (mb *MetricsBuilder) RecordYugabytedbAPIConnectionCountDataPoint(ts pcommon.Timestamp, val int64, dbAPIAttributeValue string) {
+	mb.metricYugabytedbAPIConnectionCount.recordDataPoint(mb.startTime, ts, val, dbAPIAttributeValue)
+}
+
+// RecordYugabytedbAPIKeyspaceTableCountDataPoint adds a data point to yugabytedb.api.keyspace.table.count metric.
+func (mb *MetricsBuilder) RecordYugabytedbAPIKeyspaceTableCountDataPoint(ts pcommon.Timestamp, val int64, dbAPIAttributeValue string, dbNamespaceAttributeValue string) {
+	mb.metricYugabytedbAPIKeyspaceTableCount.recordDataPoint(mb.startTime, ts, val, dbAPIAttributeValue, dbNamespaceAttributeValue)
+}
+
+// RecordYugabytedbAPIStatementCountDataPoint adds a data point to yugabytedb.api.statement.count metric.
+func (mb *MetricsBuilder) RecordYugabytedbAPIStatementCountDataPoint(ts pcommon.Timestamp, val int64, dbAPIAttributeValue string, statementTypeAttributeValue AttributeStatementType) {
+	mb.metricYugabytedbAPIStatementCount.recordDataPoint(mb.startTime, ts, val, dbAPIAttributeValue, statementTypeAttributeValue.String())
+}
+
+// RecordYugabytedbAPIStatementTimeDataPoint adds a data point to yugabytedb.api.statement.time metric.
+func (mb *MetricsBuilder) RecordYugabytedbAPIStatementTimeDataPoint(ts pcommon.Timestamp, val int64, dbAPIAttributeValue string, statementTypeAttributeValue AttributeStatementType) {
+	mb.metricYugabytedbAPIStatementTime.recordDataPoint(mb.startTime, ts, val, dbAPIAttributeValue, statementTypeAttributeValue.String())
+}
+
 // RecordYugabytedbConnectionCountDataPoint adds a data point to yugabytedb.connection.count metric.
 func (mb *MetricsBuilder) RecordYugabytedbConnectionCountDataPoint(ts pcommon.Timestamp, val int64, connectionStateAttributeValue string, connectionUserAttributeValue string) {
 	mb.metricYugabytedbConnectionCount.recordDataPoint(mb.startTime, ts, val, connectionStateAttributeValue, connectionUserAttributeValue)
 }
 
+// RecordYugabytedbLockWaitingCountDataPoint adds a data point to yugabytedb.lock.waiting.count metric.
+func (mb *MetricsBuilder) RecordYugabytedbLockWaitingCountDataPoint(ts pcommon.Timestamp, val int64, lockTypeAttributeValue string) {
+	mb.metricYugabytedbLockWaitingCount.recordDataPoint(mb.startTime, ts, val, lockTypeAttributeValue)
+}
+
+// RecordYugabytedbMasterHeartbeatCountDataPoint adds a data point to yugabytedb.master.heartbeat.count metric.
+func (mb *MetricsBuilder) RecordYugabytedbMasterHeartbeatCountDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricYugabytedbMasterHeartbeatCount.recordDataPoint(mb.startTime, ts, val)
+}
+
 // RecordYugabytedbPgStatActivityActiveConnectionsDataPoint adds a data point to yugabytedb.pg_stat_activity.active_connections metric.
 func (mb *MetricsBuilder) RecordYugabytedbPgStatActivityActiveConnectionsDataPoint(ts pcommon.Timestamp, val int64) {
 	mb.metricYugabytedbPgStatActivityActiveConnections.recordDataPoint(mb.startTime, ts, val)
@@ -389,6 +1732,76 @@ func (mb *MetricsBuilder) RecordYugabytedbPgStatActivityRunningQueriesDataPoint(
 	mb.metricYugabytedbPgStatActivityRunningQueries.recordDataPoint(mb.startTime, ts, val)
 }
 
+// RecordYugabytedbRaftLeaderCountDataPoint adds a data point to yugabytedb.raft.leader.count metric.
+func (mb *MetricsBuilder) RecordYugabytedbRaftLeaderCountDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricYugabytedbRaftLeaderCount.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordYugabytedbRocksdbCompactionIoDataPoint adds a data point to yugabytedb.rocksdb.compaction.io metric.
+func (mb *MetricsBuilder) RecordYugabytedbRocksdbCompactionIoDataPoint(ts pcommon.Timestamp, val int64, compactionDirectionAttributeValue AttributeCompactionDirection) {
+	mb.metricYugabytedbRocksdbCompactionIo.recordDataPoint(mb.startTime, ts, val, compactionDirectionAttributeValue.String())
+}
+
+// RecordYugabytedbRocksdbSstFileCountDataPoint adds a data point to yugabytedb.rocksdb.sst_file.count metric.
+func (mb *MetricsBuilder) RecordYugabytedbRocksdbSstFileCountDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricYugabytedbRocksdbSstFileCount.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordYugabytedbRocksdbSstFileSizeDataPoint adds a data point to yugabytedb.rocksdb.sst_file.size metric.
+func (mb *MetricsBuilder) RecordYugabytedbRocksdbSstFileSizeDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricYugabytedbRocksdbSstFileSize.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordYugabytedbServerUpDataPoint adds a data point to yugabytedb.server.up metric.
+func (mb *MetricsBuilder) RecordYugabytedbServerUpDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricYugabytedbServerUp.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordYugabytedbStatementCallsDataPoint adds a data point to yugabytedb.statement.calls metric.
+func (mb *MetricsBuilder) RecordYugabytedbStatementCallsDataPoint(ts pcommon.Timestamp, val int64, queryIDAttributeValue string, connectionUserAttributeValue string, dbNamespaceAttributeValue string) {
+	mb.metricYugabytedbStatementCalls.recordDataPoint(mb.startTime, ts, val, queryIDAttributeValue, connectionUserAttributeValue, dbNamespaceAttributeValue)
+}
+
+// RecordYugabytedbStatementMeanTimeDataPoint adds a data point to yugabytedb.statement.mean_time metric.
+func (mb *MetricsBuilder) RecordYugabytedbStatementMeanTimeDataPoint(ts pcommon.Timestamp, val float64, queryIDAttributeValue string, connectionUserAttributeValue string, dbNamespaceAttributeValue string) {
+	mb.metricYugabytedbStatementMeanTime.recordDataPoint(mb.startTime, ts, val, queryIDAttributeValue, connectionUserAttributeValue, dbNamespaceAttributeValue)
+}
+
+// RecordYugabytedbStatementRowsDataPoint adds a data point to yugabytedb.statement.rows metric.
+func (mb *MetricsBuilder) RecordYugabytedbStatementRowsDataPoint(ts pcommon.Timestamp, val int64, queryIDAttributeValue string, connectionUserAttributeValue string, dbNamespaceAttributeValue string) {
+	mb.metricYugabytedbStatementRows.recordDataPoint(mb.startTime, ts, val, queryIDAttributeValue, connectionUserAttributeValue, dbNamespaceAttributeValue)
+}
+
+// RecordYugabytedbStatementTimeDataPoint adds a data point to yugabytedb.statement.time metric.
+func (mb *MetricsBuilder) RecordYugabytedbStatementTimeDataPoint(ts pcommon.Timestamp, val float64, queryIDAttributeValue string, connectionUserAttributeValue string, dbNamespaceAttributeValue string) {
+	mb.metricYugabytedbStatementTime.recordDataPoint(mb.startTime, ts, val, queryIDAttributeValue, connectionUserAttributeValue, dbNamespaceAttributeValue)
+}
+
+// RecordYugabytedbTabletCountDataPoint adds a data point to yugabytedb.tablet.count metric.
+func (mb *MetricsBuilder) RecordYugabytedbTabletCountDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricYugabytedbTabletCount.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordYugabytedbTransactionLongRunningCountDataPoint adds a data point to yugabytedb.transaction.long_running.count metric.
+func (mb *MetricsBuilder) RecordYugabytedbTransactionLongRunningCountDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricYugabytedbTransactionLongRunningCount.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordYugabytedbTransactionOldestAgeDataPoint adds a data point to yugabytedb.transaction.oldest.age metric.
+func (mb *MetricsBuilder) RecordYugabytedbTransactionOldestAgeDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricYugabytedbTransactionOldestAge.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordYugabytedbWalOperationCountDataPoint adds a data point to yugabytedb.wal.operation.count metric.
+func (mb *MetricsBuilder) RecordYugabytedbWalOperationCountDataPoint(ts pcommon.Timestamp, val int64, walOperationAttributeValue AttributeWalOperation) {
+	mb.metricYugabytedbWalOperationCount.recordDataPoint(mb.startTime, ts, val, walOperationAttributeValue.String())
+}
+
+// RecordYugabytedbWalOperationTimeDataPoint adds a data point to yugabytedb.wal.operation.time metric.
+func (mb *MetricsBuilder) RecordYugabytedbWalOperationTimeDataPoint(ts pcommon.Timestamp, val int64, walOperationAttributeValue AttributeWalOperation) {
+	mb.metricYugabytedbWalOperationTime.recordDataPoint(mb.startTime, ts, val, walOperationAttributeValue.String())
+}
+
 // Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
 // and metrics builder should update its startTime and reset it's internal state accordingly.
 func (mb *MetricsBuilder) Reset(options ...MetricBuilderOption) {
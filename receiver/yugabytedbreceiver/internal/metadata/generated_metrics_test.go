@@ -42,6 +42,15 @@ func TestMetricsBuilder(t *testing.T) {
 			resAttrsSet: testDataSetNone,
 			expectEmpty: true,
 		},
+		{
+			name:        "filter_set_include",
+			resAttrsSet: testDataSetAll,
+		},
+		{
+			name:        "filter_set_exclude",
+			resAttrsSet: testDataSetAll,
+			expectEmpty: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -63,10 +72,33 @@ func TestMetricsBuilder(t *testing.T) {
 			allMetricsCount++
 			mb.RecordYugabytedbActiveUsersCountDataPoint(ts, 1, "connection.user-val")
 
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordYugabytedbAPIConnectionCountDataPoint(ts, 1, "db.api-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordYugabytedbAPIKeyspaceTableCountDataPoint(ts, 1, "db.api-val", "db.namespace-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordYugabytedbAPIStatementCountDataPoint(ts, 1, "db.api-val", AttributeStatementTypeSelect)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordYugabytedbAPIStatementTimeDataPoint(ts, 1, "db.api-val", AttributeStatementTypeSelect)
+
 			defaultMetricsCount++
 			allMetricsCount++
 			mb.RecordYugabytedbConnectionCountDataPoint(ts, 1, "connection.state-val", "connection.user-val")
 
+			allMetricsCount++
+			mb.RecordYugabytedbLockWaitingCountDataPoint(ts, 1, "lock.type-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordYugabytedbMasterHeartbeatCountDataPoint(ts, 1)
+
 			defaultMetricsCount++
 			allMetricsCount++
 			mb.RecordYugabytedbPgStatActivityActiveConnectionsDataPoint(ts, 1)
@@ -75,7 +107,65 @@ func TestMetricsBuilder(t *testing.T) {
 			allMetricsCount++
 			mb.RecordYugabytedbPgStatActivityRunningQueriesDataPoint(ts, 1)
 
-			res := pcommon.NewResource()
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordYugabytedbRaftLeaderCountDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordYugabytedbRocksdbCompactionIoDataPoint(ts, 1, AttributeCompactionDirectionRead)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordYugabytedbRocksdbSstFileCountDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordYugabytedbRocksdbSstFileSizeDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordYugabytedbServerUpDataPoint(ts, 1)
+
+			allMetricsCount++
+			mb.RecordYugabytedbStatementCallsDataPoint(ts, 1, "query.id-val", "connection.user-val", "db.namespace-val")
+
+			allMetricsCount++
+			mb.RecordYugabytedbStatementMeanTimeDataPoint(ts, 1, "query.id-val", "connection.user-val", "db.namespace-val")
+
+			allMetricsCount++
+			mb.RecordYugabytedbStatementRowsDataPoint(ts, 1, "query.id-val", "connection.user-val", "db.namespace-val")
+
+			allMetricsCount++
+			mb.RecordYugabytedbStatementTimeDataPoint(ts, 1, "query.id-val", "connection.user-val", "db.namespace-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordYugabytedbTabletCountDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordYugabytedbTransactionLongRunningCountDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordYugabytedbTransactionOldestAgeDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordYugabytedbWalOperationCountDataPoint(ts, 1, AttributeWalOperationAppend)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordYugabytedbWalOperationTimeDataPoint(ts, 1, AttributeWalOperationAppend)
+
+			rb := mb.NewResourceBuilder()
+			rb.SetYugabytedbNodeCloud("yugabytedb.node.cloud-val")
+			rb.SetYugabytedbNodeHost("yugabytedb.node.host-val")
+			rb.SetYugabytedbNodeRegion("yugabytedb.node.region-val")
+			rb.SetYugabytedbNodeZone("yugabytedb.node.zone-val")
+			rb.SetYugabytedbServerType("yugabytedb.server.type-val")
+			res := rb.Emit()
 			metrics := mb.Emit(WithResource(res))
 
 			if tt.expectEmpty {
@@ -112,6 +202,79 @@ func TestMetricsBuilder(t *testing.T) {
 					attrVal, ok := dp.Attributes().Get("connection.user")
 					assert.True(t, ok)
 					assert.Equal(t, "connection.user-val", attrVal.Str())
+				case "yugabytedb.api.connection.count":
+					assert.False(t, validatedMetrics["yugabytedb.api.connection.count"], "Found a duplicate in the metrics slice: yugabytedb.api.connection.count")
+					validatedMetrics["yugabytedb.api.connection.count"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The number of client connections open on the API server of the tserver.", ms.At(i).Description())
+					assert.Equal(t, "{connections}", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("db.api")
+					assert.True(t, ok)
+					assert.Equal(t, "db.api-val", attrVal.Str())
+				case "yugabytedb.api.keyspace.table.count":
+					assert.False(t, validatedMetrics["yugabytedb.api.keyspace.table.count"], "Found a duplicate in the metrics slice: yugabytedb.api.keyspace.table.count")
+					validatedMetrics["yugabytedb.api.keyspace.table.count"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The number of tables of the keyspace.", ms.At(i).Description())
+					assert.Equal(t, "{tables}", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("db.api")
+					assert.True(t, ok)
+					assert.Equal(t, "db.api-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("db.namespace")
+					assert.True(t, ok)
+					assert.Equal(t, "db.namespace-val", attrVal.Str())
+				case "yugabytedb.api.statement.count":
+					assert.False(t, validatedMetrics["yugabytedb.api.statement.count"], "Found a duplicate in the metrics slice: yugabytedb.api.statement.count")
+					validatedMetrics["yugabytedb.api.statement.count"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of statements executed by the API server of the tserver.", ms.At(i).Description())
+					assert.Equal(t, "{statements}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("db.api")
+					assert.True(t, ok)
+					assert.Equal(t, "db.api-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("statement.type")
+					assert.True(t, ok)
+					assert.Equal(t, "select", attrVal.Str())
+				case "yugabytedb.api.statement.time":
+					assert.False(t, validatedMetrics["yugabytedb.api.statement.time"], "Found a duplicate in the metrics slice: yugabytedb.api.statement.time")
+					validatedMetrics["yugabytedb.api.statement.time"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The total time spent executing statements by the API server of the tserver.", ms.At(i).Description())
+					assert.Equal(t, "us", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("db.api")
+					assert.True(t, ok)
+					assert.Equal(t, "db.api-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("statement.type")
+					assert.True(t, ok)
+					assert.Equal(t, "select", attrVal.Str())
 				case "yugabytedb.connection.count":
 					assert.False(t, validatedMetrics["yugabytedb.connection.count"], "Found a duplicate in the metrics slice: yugabytedb.connection.count")
 					validatedMetrics["yugabytedb.connection.count"] = true
@@ -130,6 +293,35 @@ func TestMetricsBuilder(t *testing.T) {
 					attrVal, ok = dp.Attributes().Get("connection.user")
 					assert.True(t, ok)
 					assert.Equal(t, "connection.user-val", attrVal.Str())
+				case "yugabytedb.lock.waiting.count":
+					assert.False(t, validatedMetrics["yugabytedb.lock.waiting.count"], "Found a duplicate in the metrics slice: yugabytedb.lock.waiting.count")
+					validatedMetrics["yugabytedb.lock.waiting.count"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The number of locks that are waited for, by lock type.", ms.At(i).Description())
+					assert.Equal(t, "{locks}", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("lock.type")
+					assert.True(t, ok)
+					assert.Equal(t, "lock.type-val", attrVal.Str())
+				case "yugabytedb.master.heartbeat.count":
+					assert.False(t, validatedMetrics["yugabytedb.master.heartbeat.count"], "Found a duplicate in the metrics slice: yugabytedb.master.heartbeat.count")
+					validatedMetrics["yugabytedb.master.heartbeat.count"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of heartbeats received by the master from the tservers.", ms.At(i).Description())
+					assert.Equal(t, "{heartbeats}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
 				case "yugabytedb.pg_stat_activity.active_connections":
 					assert.False(t, validatedMetrics["yugabytedb.pg_stat_activity.active_connections"], "Found a duplicate in the metrics slice: yugabytedb.pg_stat_activity.active_connections")
 					validatedMetrics["yugabytedb.pg_stat_activity.active_connections"] = true
@@ -154,6 +346,231 @@ func TestMetricsBuilder(t *testing.T) {
 					assert.Equal(t, ts, dp.Timestamp())
 					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
 					assert.Equal(t, int64(1), dp.IntValue())
+				case "yugabytedb.raft.leader.count":
+					assert.False(t, validatedMetrics["yugabytedb.raft.leader.count"], "Found a duplicate in the metrics slice: yugabytedb.raft.leader.count")
+					validatedMetrics["yugabytedb.raft.leader.count"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The number of tablet peers hosted by the server that are Raft leaders.", ms.At(i).Description())
+					assert.Equal(t, "{tablets}", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "yugabytedb.rocksdb.compaction.io":
+					assert.False(t, validatedMetrics["yugabytedb.rocksdb.compaction.io"], "Found a duplicate in the metrics slice: yugabytedb.rocksdb.compaction.io")
+					validatedMetrics["yugabytedb.rocksdb.compaction.io"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of bytes read and written by RocksDB compactions.", ms.At(i).Description())
+					assert.Equal(t, "By", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("compaction.direction")
+					assert.True(t, ok)
+					assert.Equal(t, "read", attrVal.Str())
+				case "yugabytedb.rocksdb.sst_file.count":
+					assert.False(t, validatedMetrics["yugabytedb.rocksdb.sst_file.count"], "Found a duplicate in the metrics slice: yugabytedb.rocksdb.sst_file.count")
+					validatedMetrics["yugabytedb.rocksdb.sst_file.count"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The number of SST files of the current RocksDB version, across the tablets of the server.", ms.At(i).Description())
+					assert.Equal(t, "{files}", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "yugabytedb.rocksdb.sst_file.size":
+					assert.False(t, validatedMetrics["yugabytedb.rocksdb.sst_file.size"], "Found a duplicate in the metrics slice: yugabytedb.rocksdb.sst_file.size")
+					validatedMetrics["yugabytedb.rocksdb.sst_file.size"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The size of the SST files of the current RocksDB version, across the tablets of the server.", ms.At(i).Description())
+					assert.Equal(t, "By", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "yugabytedb.server.up":
+					assert.False(t, validatedMetrics["yugabytedb.server.up"], "Found a duplicate in the metrics slice: yugabytedb.server.up")
+					validatedMetrics["yugabytedb.server.up"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Whether the Prometheus endpoint of the server could be scraped (1) or not (0).", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "yugabytedb.statement.calls":
+					assert.False(t, validatedMetrics["yugabytedb.statement.calls"], "Found a duplicate in the metrics slice: yugabytedb.statement.calls")
+					validatedMetrics["yugabytedb.statement.calls"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of times the statement was executed, for the statements with the highest total execution time.", ms.At(i).Description())
+					assert.Equal(t, "{calls}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("query.id")
+					assert.True(t, ok)
+					assert.Equal(t, "query.id-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("connection.user")
+					assert.True(t, ok)
+					assert.Equal(t, "connection.user-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("db.namespace")
+					assert.True(t, ok)
+					assert.Equal(t, "db.namespace-val", attrVal.Str())
+				case "yugabytedb.statement.mean_time":
+					assert.False(t, validatedMetrics["yugabytedb.statement.mean_time"], "Found a duplicate in the metrics slice: yugabytedb.statement.mean_time")
+					validatedMetrics["yugabytedb.statement.mean_time"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The mean execution time of the statement, for the statements with the highest total execution time.", ms.At(i).Description())
+					assert.Equal(t, "ms", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("query.id")
+					assert.True(t, ok)
+					assert.Equal(t, "query.id-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("connection.user")
+					assert.True(t, ok)
+					assert.Equal(t, "connection.user-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("db.namespace")
+					assert.True(t, ok)
+					assert.Equal(t, "db.namespace-val", attrVal.Str())
+				case "yugabytedb.statement.rows":
+					assert.False(t, validatedMetrics["yugabytedb.statement.rows"], "Found a duplicate in the metrics slice: yugabytedb.statement.rows")
+					validatedMetrics["yugabytedb.statement.rows"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of rows retrieved or affected by the statement, for the statements with the highest total execution time.", ms.At(i).Description())
+					assert.Equal(t, "{rows}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("query.id")
+					assert.True(t, ok)
+					assert.Equal(t, "query.id-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("connection.user")
+					assert.True(t, ok)
+					assert.Equal(t, "connection.user-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("db.namespace")
+					assert.True(t, ok)
+					assert.Equal(t, "db.namespace-val", attrVal.Str())
+				case "yugabytedb.statement.time":
+					assert.False(t, validatedMetrics["yugabytedb.statement.time"], "Found a duplicate in the metrics slice: yugabytedb.statement.time")
+					validatedMetrics["yugabytedb.statement.time"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The total execution time of the statement, for the statements with the highest total execution time.", ms.At(i).Description())
+					assert.Equal(t, "ms", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("query.id")
+					assert.True(t, ok)
+					assert.Equal(t, "query.id-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("connection.user")
+					assert.True(t, ok)
+					assert.Equal(t, "connection.user-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("db.namespace")
+					assert.True(t, ok)
+					assert.Equal(t, "db.namespace-val", attrVal.Str())
+				case "yugabytedb.tablet.count":
+					assert.False(t, validatedMetrics["yugabytedb.tablet.count"], "Found a duplicate in the metrics slice: yugabytedb.tablet.count")
+					validatedMetrics["yugabytedb.tablet.count"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The number of tablet peers hosted by the server.", ms.At(i).Description())
+					assert.Equal(t, "{tablets}", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "yugabytedb.transaction.long_running.count":
+					assert.False(t, validatedMetrics["yugabytedb.transaction.long_running.count"], "Found a duplicate in the metrics slice: yugabytedb.transaction.long_running.count")
+					validatedMetrics["yugabytedb.transaction.long_running.count"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The number of open transactions older than the long_running_transaction_threshold.", ms.At(i).Description())
+					assert.Equal(t, "{transactions}", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "yugabytedb.transaction.oldest.age":
+					assert.False(t, validatedMetrics["yugabytedb.transaction.oldest.age"], "Found a duplicate in the metrics slice: yugabytedb.transaction.oldest.age")
+					validatedMetrics["yugabytedb.transaction.oldest.age"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The age of the oldest open transaction.", ms.At(i).Description())
+					assert.Equal(t, "s", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "yugabytedb.wal.operation.count":
+					assert.False(t, validatedMetrics["yugabytedb.wal.operation.count"], "Found a duplicate in the metrics slice: yugabytedb.wal.operation.count")
+					validatedMetrics["yugabytedb.wal.operation.count"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of write-ahead log operations.", ms.At(i).Description())
+					assert.Equal(t, "{operations}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("wal.operation")
+					assert.True(t, ok)
+					assert.Equal(t, "append", attrVal.Str())
+				case "yugabytedb.wal.operation.time":
+					assert.False(t, validatedMetrics["yugabytedb.wal.operation.time"], "Found a duplicate in the metrics slice: yugabytedb.wal.operation.time")
+					validatedMetrics["yugabytedb.wal.operation.time"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The total time spent in write-ahead log operations.", ms.At(i).Description())
+					assert.Equal(t, "us", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("wal.operation")
+					assert.True(t, ok)
+					assert.Equal(t, "append", attrVal.Str())
 				}
 			}
 		})
@@ -29,11 +29,50 @@ const (
 	// activeUserCountQuery counts unique active users with client backend connections
 	activeUserCountQuery = `
 		SELECT
-			COALESCE(usename, 'unknown') as usename
+			COALESCE(usename, 'unknown') as usename,
+			COUNT(*) as user_session_count
 		FROM pg_stat_activity
 		WHERE state = 'active'
 		AND backend_type = 'client backend'
 		GROUP BY usename`
+
+	// statementsQuery retrieves the $1 statements with the highest total execution time. The
+	// execution times were renamed from total_time and mean_time in the PostgreSQL 13 based
+	// releases, so the row is read as JSON to support both.
+	statementsQuery = `
+		SELECT
+			s.queryid::text as queryid,
+			COALESCE(r.rolname, 'unknown') as rolname,
+			COALESCE(d.datname, 'unknown') as datname,
+			s.query,
+			s.calls,
+			COALESCE(to_jsonb(s)->>'total_exec_time', to_jsonb(s)->>'total_time')::float8 as total_time,
+			COALESCE(to_jsonb(s)->>'mean_exec_time', to_jsonb(s)->>'mean_time')::float8 as mean_time,
+			s.rows
+		FROM pg_stat_statements s
+		LEFT JOIN pg_roles r ON r.oid = s.userid
+		LEFT JOIN pg_database d ON d.oid = s.dbid
+		ORDER BY total_time DESC
+		LIMIT $1`
+
+	// lockWaitsQuery counts the locks that are waited for by lock type
+	lockWaitsQuery = `
+		SELECT
+			locktype,
+			count(*) as count
+		FROM pg_locks
+		WHERE NOT granted
+		GROUP BY locktype`
+
+	// transactionsQuery retrieves the age in seconds of the oldest open transaction, and counts the
+	// open transactions older than $1 seconds
+	transactionsQuery = `
+		SELECT
+			COALESCE(EXTRACT(EPOCH FROM max(now() - xact_start)), 0)::bigint as oldest_age,
+			count(*) FILTER (WHERE now() - xact_start > $1 * interval '1 second') as long_running
+		FROM pg_stat_activity
+		WHERE xact_start IS NOT NULL
+		AND backend_type = 'client backend'`
 )
 
 // ============================================================================
@@ -92,4 +131,88 @@ const (
 		WHERE state = 'active'
 		AND backend_type = 'client backend'
 		GROUP BY "gv$host", "gv$zone", "gv$region", "gv$cloud", usename`
+
+	// globalViewStatementsQuery retrieves the $1 statements with the highest total execution time
+	// of each node
+	globalViewStatementsQuery = `
+		SELECT
+			"gv$host",
+			"gv$zone",
+			"gv$region",
+			"gv$cloud",
+			queryid,
+			rolname,
+			datname,
+			query,
+			calls,
+			total_time,
+			mean_time,
+			rows
+		FROM (
+			SELECT
+				s."gv$host",
+				s."gv$zone",
+				s."gv$region",
+				s."gv$cloud",
+				s.queryid::text as queryid,
+				COALESCE(r.rolname, 'unknown') as rolname,
+				COALESCE(d.datname, 'unknown') as datname,
+				s.query,
+				s.calls,
+				COALESCE(to_jsonb(s)->>'total_exec_time', to_jsonb(s)->>'total_time')::float8 as total_time,
+				COALESCE(to_jsonb(s)->>'mean_exec_time', to_jsonb(s)->>'mean_time')::float8 as mean_time,
+				s.rows,
+				row_number() OVER (
+					PARTITION BY s."gv$host"
+					ORDER BY COALESCE(to_jsonb(s)->>'total_exec_time', to_jsonb(s)->>'total_time')::float8 DESC
+				) as rank
+			FROM gv_history."gv$pg_stat_statements" s
+			LEFT JOIN pg_roles r ON r.oid = s.userid
+			LEFT JOIN pg_database d ON d.oid = s.dbid
+		) ranked
+		WHERE rank <= $1`
+
+	// globalViewLockWaitsQuery counts the locks that are waited for by node and lock type
+	globalViewLockWaitsQuery = `
+		SELECT
+			"gv$host",
+			"gv$zone",
+			"gv$region",
+			"gv$cloud",
+			locktype,
+			count(*) as count
+		FROM gv_history."gv$pg_locks"
+		WHERE NOT granted
+		GROUP BY "gv$host", "gv$zone", "gv$region", "gv$cloud", locktype`
+
+	// globalViewTransactionsQuery retrieves the age in seconds of the oldest open transaction of
+	// each node, and counts the open transactions older than $1 seconds
+	globalViewTransactionsQuery = `
+		SELECT
+			"gv$host",
+			"gv$zone",
+			"gv$region",
+			"gv$cloud",
+			COALESCE(EXTRACT(EPOCH FROM max(now() - xact_start)), 0)::bigint as oldest_age,
+			count(*) FILTER (WHERE now() - xact_start > $1 * interval '1 second') as long_running
+		FROM gv_history."gv$pg_stat_activity"
+		WHERE xact_start IS NOT NULL
+		AND backend_type = 'client backend'
+		GROUP BY "gv$host", "gv$zone", "gv$region", "gv$cloud"`
+)
+
+// ============================================================================
+// Server Discovery Queries (docdb.enabled: true)
+// yb_servers() lists the tservers of the universe with their placement
+// ============================================================================
+
+const (
+	// serversQuery lists the tservers whose Prometheus endpoints are scraped
+	serversQuery = `
+		SELECT
+			host,
+			COALESCE(zone, '') as zone,
+			COALESCE(region, '') as region,
+			COALESCE(cloud, '') as cloud
+		FROM yb_servers()`
 )
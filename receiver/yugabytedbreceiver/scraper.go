@@ -0,0 +1,394 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package yugabytedbreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/yugabytedbreceiver"
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/yugabytedbreceiver/internal/metadata"
+)
+
+// yugabyteDBScraper collects metrics from YugabyteDB
+type yugabyteDBScraper struct {
+	config         *Config
+	logger         *zap.Logger
+	settings       component.TelemetrySettings
+	metricsBuilder *metadata.MetricsBuilder
+	logsBuilder    *metadata.LogsBuilder
+	newClient      newClientFunc
+	client         client
+	// httpClient scrapes the Prometheus endpoints of the tservers and masters when docdb is enabled,
+	// and of the YCQL servers when ycql is enabled
+	httpClient    *http.Client
+	newYCQLClient newYCQLClientFunc
+	ycqlClient    ycqlClient
+}
+
+func newYugabyteDBScraper(settings receiver.Settings, cfg *Config, newClient newClientFunc) *yugabyteDBScraper {
+	return &yugabyteDBScraper{
+		config:         cfg,
+		logger:         settings.Logger,
+		settings:       settings.TelemetrySettings,
+		metricsBuilder: metadata.NewMetricsBuilder(cfg.MetricsBuilderConfig, settings),
+		logsBuilder:    metadata.NewLogsBuilder(cfg.LogsBuilderConfig, settings),
+		newClient:      newClient,
+	}
+}
+
+// start opens the connection pool that is kept open across scrapes
+func (s *yugabyteDBScraper) start(ctx context.Context, host component.Host) error {
+	if s.config.DocDB.Enabled || s.config.YCQL.Enabled {
+		// The client is shared by the scrapes of the tservers, masters and YCQL servers
+		httpClient, err := s.config.DocDB.ToClient(ctx, host.GetExtensions(), s.settings)
+		if err != nil {
+			return fmt.Errorf("failed to create the DocDB HTTP client: %w", err)
+		}
+		s.httpClient = httpClient
+	}
+	if s.config.YCQL.Enabled && s.newYCQLClient != nil {
+		ycql, err := s.newYCQLClient(ctx, s.config)
+		if err != nil {
+			return fmt.Errorf("failed to create the YCQL client: %w", err)
+		}
+		s.ycqlClient = ycql
+	}
+
+	c, err := s.newClient(s.config)
+	if err != nil {
+		return fmt.Errorf("failed to create the YugabyteDB client: %w", err)
+	}
+	s.client = c
+	return nil
+}
+
+// shutdown closes the connection pool and the YCQL session
+func (s *yugabyteDBScraper) shutdown(context.Context) error {
+	var err error
+	if s.ycqlClient != nil {
+		err = multierr.Append(err, s.ycqlClient.Close())
+	}
+	if s.client != nil {
+		err = multierr.Append(err, s.client.Close())
+	}
+	return err
+}
+
+// scrape gathers all metrics from YugabyteDB and emits them for each node that reported them
+func (s *yugabyteDBScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	if s.client == nil {
+		return pmetric.NewMetrics(), errors.New("failed to connect to YugabyteDB: client not initialized")
+	}
+
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	var errs scrapererror.ScrapeErrors
+	sc := newNodeScrape()
+	s.collectRunningQueries(ctx, sc, &errs)
+	s.collectActiveConnections(ctx, sc, &errs)
+	s.collectConnectionsByStateAndUser(ctx, sc, &errs)
+	s.collectActiveUserCount(ctx, sc, &errs)
+	s.collectStatements(ctx, sc, &errs)
+	s.collectLockWaits(ctx, sc, &errs)
+	s.collectTransactions(ctx, sc, &errs)
+
+	for _, node := range sc.sortedNodes() {
+		s.recordNodeMetrics(now, sc, node)
+	}
+
+	if s.config.DocDB.Enabled {
+		s.scrapeDocDB(ctx, now, &errs)
+	}
+	if s.ycqlClient != nil {
+		s.scrapeYCQL(ctx, now, &errs)
+	}
+
+	return s.metricsBuilder.Emit(), errs.Combine()
+}
+
+// nodeMetrics holds the values reported by a single node during a scrape
+type nodeMetrics struct {
+	runningQueries    int64
+	activeConnections int64
+	connections       map[string]map[string]int64 // state -> user -> count
+	activeUsers       map[string]int64            // username -> session count
+	statements        []statementStats
+	lockWaits         map[string]int64 // lock type -> count
+	oldestTransaction int64            // seconds
+	longRunning       int64
+}
+
+// nodeScrape groups the results of the queries of a scrape by node
+type nodeScrape struct {
+	nodes map[nodeInfo]*nodeMetrics
+	// runningQueries and activeConnections are set when the corresponding query succeeded, in
+	// which case a value is recorded for every node, including the nodes without a matching row
+	runningQueries    bool
+	activeConnections bool
+	transactions      bool
+}
+
+func newNodeScrape() *nodeScrape {
+	return &nodeScrape{nodes: map[nodeInfo]*nodeMetrics{}}
+}
+
+func (sc *nodeScrape) node(node nodeInfo) *nodeMetrics {
+	nm, ok := sc.nodes[node]
+	if !ok {
+		nm = &nodeMetrics{
+			connections: map[string]map[string]int64{},
+			activeUsers: map[string]int64{},
+			lockWaits:   map[string]int64{},
+		}
+		sc.nodes[node] = nm
+	}
+	return nm
+}
+
+// sortedNodes returns the nodes in a stable order, so that resources are emitted deterministically
+func (sc *nodeScrape) sortedNodes() []nodeInfo {
+	nodes := make([]nodeInfo, 0, len(sc.nodes))
+	for node := range sc.nodes {
+		nodes = append(nodes, node)
+	}
+	slices.SortFunc(nodes, func(a, b nodeInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.host, b.host),
+			cmp.Compare(a.zone, b.zone),
+			cmp.Compare(a.region, b.region),
+			cmp.Compare(a.cloud, b.cloud),
+		)
+	})
+	return nodes
+}
+
+// recordNodeMetrics records the metrics of a node and emits them under a resource identifying the node
+func (s *yugabyteDBScraper) recordNodeMetrics(now pcommon.Timestamp, sc *nodeScrape, node nodeInfo) {
+	nm := sc.nodes[node]
+	if sc.runningQueries {
+		s.metricsBuilder.RecordYugabytedbPgStatActivityRunningQueriesDataPoint(now, nm.runningQueries)
+	}
+	if sc.activeConnections {
+		s.metricsBuilder.RecordYugabytedbPgStatActivityActiveConnectionsDataPoint(now, nm.activeConnections)
+	}
+	for state, users := range nm.connections {
+		for user, count := range users {
+			s.metricsBuilder.RecordYugabytedbConnectionCountDataPoint(now, count, state, user)
+		}
+	}
+	for user, count := range nm.activeUsers {
+		s.metricsBuilder.RecordYugabytedbActiveUsersCountDataPoint(now, count, user)
+	}
+	for _, ss := range nm.statements {
+		s.metricsBuilder.RecordYugabytedbStatementCallsDataPoint(now, ss.calls, ss.queryID, ss.user, ss.database)
+		s.metricsBuilder.RecordYugabytedbStatementTimeDataPoint(now, ss.totalTime, ss.queryID, ss.user, ss.database)
+		s.metricsBuilder.RecordYugabytedbStatementMeanTimeDataPoint(now, ss.meanTime, ss.queryID, ss.user, ss.database)
+		s.metricsBuilder.RecordYugabytedbStatementRowsDataPoint(now, ss.rows, ss.queryID, ss.user, ss.database)
+	}
+	for lockType, count := range nm.lockWaits {
+		s.metricsBuilder.RecordYugabytedbLockWaitingCountDataPoint(now, count, lockType)
+	}
+	if sc.transactions {
+		s.metricsBuilder.RecordYugabytedbTransactionOldestAgeDataPoint(now, nm.oldestTransaction)
+		s.metricsBuilder.RecordYugabytedbTransactionLongRunningCountDataPoint(now, nm.longRunning)
+	}
+
+	rb := s.metricsBuilder.NewResourceBuilder()
+	setNodeResourceAttributes(rb, node)
+	s.metricsBuilder.EmitForResource(metadata.WithResource(rb.Emit()))
+}
+
+// setNodeResourceAttributes sets the resource attributes identifying the node
+func setNodeResourceAttributes(rb *metadata.ResourceBuilder, node nodeInfo) {
+	if node.host != "" {
+		rb.SetYugabytedbNodeHost(node.host)
+	}
+	if node.zone != "" {
+		rb.SetYugabytedbNodeZone(node.zone)
+	}
+	if node.region != "" {
+		rb.SetYugabytedbNodeRegion(node.region)
+	}
+	if node.cloud != "" {
+		rb.SetYugabytedbNodeCloud(node.cloud)
+	}
+}
+
+// normalizeConnectionState normalizes PostgreSQL connection states to our metric format
+func normalizeConnectionState(state string) string {
+	switch state {
+	case "idle in transaction", "idle in transaction (aborted)":
+		return "idle_in_transaction"
+	case "":
+		return "unknown"
+	default:
+		return state
+	}
+}
+
+// collectRunningQueries collects the running queries count of each node
+func (s *yugabyteDBScraper) collectRunningQueries(ctx context.Context, sc *nodeScrape, errs *scrapererror.ScrapeErrors) {
+	counts, err := s.client.getRunningQueries(ctx)
+	if err != nil {
+		errs.AddPartial(1, fmt.Errorf("failed to query running queries: %w", err))
+		return
+	}
+
+	sc.runningQueries = true
+	for _, nc := range counts {
+		sc.node(nc.node).runningQueries += nc.count
+	}
+}
+
+// collectActiveConnections collects the active connections count of each node
+func (s *yugabyteDBScraper) collectActiveConnections(ctx context.Context, sc *nodeScrape, errs *scrapererror.ScrapeErrors) {
+	counts, err := s.client.getActiveConnections(ctx)
+	if err != nil {
+		errs.AddPartial(1, fmt.Errorf("failed to query active connections: %w", err))
+		return
+	}
+
+	sc.activeConnections = true
+	for _, nc := range counts {
+		sc.node(nc.node).activeConnections += nc.count
+	}
+}
+
+// collectConnectionsByStateAndUser collects the connection counts of each node by state and user
+func (s *yugabyteDBScraper) collectConnectionsByStateAndUser(ctx context.Context, sc *nodeScrape, errs *scrapererror.ScrapeErrors) {
+	connections, err := s.client.getConnectionsByStateAndUser(ctx)
+	if err != nil {
+		errs.AddPartial(1, fmt.Errorf("failed to query connections by state and user: %w", err))
+		return
+	}
+
+	// Several states are normalized to the same value, so the counts are aggregated per node
+	for _, cm := range connections {
+		nm := sc.node(cm.node)
+		state := normalizeConnectionState(cm.state)
+		if nm.connections[state] == nil {
+			nm.connections[state] = make(map[string]int64)
+		}
+		nm.connections[state][cm.user] += cm.count
+	}
+}
+
+// collectActiveUserCount collects the active sessions of each node by user
+func (s *yugabyteDBScraper) collectActiveUserCount(ctx context.Context, sc *nodeScrape, errs *scrapererror.ScrapeErrors) {
+	sessions, err := s.client.getActiveUsers(ctx)
+	if err != nil {
+		errs.AddPartial(1, fmt.Errorf("failed to query active user count: %w", err))
+		return
+	}
+
+	for _, us := range sessions {
+		sc.node(us.node).activeUsers[us.user] += us.count
+	}
+}
+
+// collectStatements collects the statistics of the statements with the highest total execution
+// time of each node, when one of the statement metrics is enabled
+func (s *yugabyteDBScraper) collectStatements(ctx context.Context, sc *nodeScrape, errs *scrapererror.ScrapeErrors) {
+	metrics := s.config.Metrics
+	if !metrics.YugabytedbStatementCalls.Enabled && !metrics.YugabytedbStatementTime.Enabled &&
+		!metrics.YugabytedbStatementMeanTime.Enabled && !metrics.YugabytedbStatementRows.Enabled {
+		return
+	}
+
+	statements, err := s.client.getStatements(ctx, s.config.StatementStatistics.TopN)
+	if err != nil {
+		errs.AddPartial(4, fmt.Errorf("failed to query statement statistics: %w", err))
+		return
+	}
+
+	for _, ss := range statements {
+		nm := sc.node(ss.node)
+		nm.statements = append(nm.statements, ss)
+	}
+}
+
+// collectLockWaits collects the locks that are waited for on each node by lock type
+func (s *yugabyteDBScraper) collectLockWaits(ctx context.Context, sc *nodeScrape, errs *scrapererror.ScrapeErrors) {
+	if !s.config.Metrics.YugabytedbLockWaitingCount.Enabled {
+		return
+	}
+
+	locks, err := s.client.getLockWaits(ctx)
+	if err != nil {
+		errs.AddPartial(1, fmt.Errorf("failed to query lock waits: %w", err))
+		return
+	}
+
+	for _, lw := range locks {
+		sc.node(lw.node).lockWaits[lw.lockType] += lw.count
+	}
+}
+
+// collectTransactions collects the age of the oldest open transaction and the number of long
+// running transactions of each node
+func (s *yugabyteDBScraper) collectTransactions(ctx context.Context, sc *nodeScrape, errs *scrapererror.ScrapeErrors) {
+	metrics := s.config.Metrics
+	if !metrics.YugabytedbTransactionOldestAge.Enabled && !metrics.YugabytedbTransactionLongRunningCount.Enabled {
+		return
+	}
+
+	transactions, err := s.client.getTransactions(ctx, s.config.LongRunningTransactionThreshold)
+	if err != nil {
+		errs.AddPartial(2, fmt.Errorf("failed to query open transactions: %w", err))
+		return
+	}
+
+	sc.transactions = true
+	for _, ts := range transactions {
+		nm := sc.node(ts.node)
+		nm.oldestTransaction = max(nm.oldestTransaction, ts.oldestAge)
+		nm.longRunning += ts.longRunning
+	}
+}
+
+// scrapeTopQueries reports the statements with the highest total execution time of each node as
+// db.server.top_query events, under a resource identifying the node
+func (s *yugabyteDBScraper) scrapeTopQueries(ctx context.Context) (plog.Logs, error) {
+	if s.client == nil {
+		return plog.NewLogs(), errors.New("failed to connect to YugabyteDB: client not initialized")
+	}
+
+	statements, err := s.client.getStatements(ctx, s.config.StatementStatistics.TopN)
+	if err != nil {
+		return plog.NewLogs(), fmt.Errorf("failed to query statement statistics: %w", err)
+	}
+
+	now := pcommon.NewTimestampFromTime(time.Now())
+	sc := newNodeScrape()
+	for _, ss := range statements {
+		nm := sc.node(ss.node)
+		nm.statements = append(nm.statements, ss)
+	}
+
+	for _, node := range sc.sortedNodes() {
+		for _, ss := range sc.nodes[node].statements {
+			s.logsBuilder.RecordDbServerTopQueryEvent(ctx, now, ss.queryID, ss.query, ss.database, ss.user,
+				ss.calls, ss.totalTime, ss.meanTime, ss.rows)
+		}
+
+		rb := s.logsBuilder.NewResourceBuilder()
+		setNodeResourceAttributes(rb, node)
+		s.logsBuilder.EmitForResource(metadata.WithLogsResource(rb.Emit()))
+	}
+	return s.logsBuilder.Emit(), nil
+}
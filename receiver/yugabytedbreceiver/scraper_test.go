@@ -0,0 +1,403 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package yugabytedbreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/yugabytedbreceiver/internal/metadata"
+)
+
+type fakeClient struct {
+	runningQueries    []nodeCount
+	activeConnections []nodeCount
+	connections       []connectionMetric
+	activeUsers       []userSessions
+	statements        []statementStats
+	lockWaits         []lockWaits
+	transactions      []transactionStats
+	servers           []nodeInfo
+	err               error
+	topN              int
+	threshold         time.Duration
+	closed            bool
+}
+
+var _ client = (*fakeClient)(nil)
+
+func (c *fakeClient) getRunningQueries(context.Context) ([]nodeCount, error) {
+	return c.runningQueries, c.err
+}
+
+func (c *fakeClient) getActiveConnections(context.Context) ([]nodeCount, error) {
+	return c.activeConnections, c.err
+}
+
+func (c *fakeClient) getConnectionsByStateAndUser(context.Context) ([]connectionMetric, error) {
+	return c.connections, c.err
+}
+
+func (c *fakeClient) getActiveUsers(context.Context) ([]userSessions, error) {
+	return c.activeUsers, c.err
+}
+
+func (c *fakeClient) getStatements(_ context.Context, topN int) ([]statementStats, error) {
+	c.topN = topN
+	return c.statements, c.err
+}
+
+func (c *fakeClient) getLockWaits(context.Context) ([]lockWaits, error) {
+	return c.lockWaits, c.err
+}
+
+func (c *fakeClient) getTransactions(_ context.Context, longRunningThreshold time.Duration) ([]transactionStats, error) {
+	c.threshold = longRunningThreshold
+	return c.transactions, c.err
+}
+
+func (c *fakeClient) getServers(context.Context) ([]nodeInfo, error) {
+	return c.servers, c.err
+}
+
+func (c *fakeClient) Close() error {
+	c.closed = true
+	return nil
+}
+
+var (
+	node1 = nodeInfo{host: "10.0.0.1", zone: "us-east-1a", region: "us-east-1", cloud: "aws"}
+	node2 = nodeInfo{host: "10.0.0.2", zone: "us-east-1b", region: "us-east-1", cloud: "aws"}
+)
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		runningQueries: []nodeCount{
+			{node: node1, count: 2},
+			{node: node2, count: 3},
+		},
+		activeConnections: []nodeCount{
+			{node: node1, count: 10},
+			{node: node2, count: 5},
+		},
+		connections: []connectionMetric{
+			{node: node1, state: "active", user: "yugabyte", count: 2},
+			{node: node2, state: "active", user: "yugabyte", count: 3},
+			{node: node1, state: "idle in transaction", user: "app", count: 1},
+		},
+		activeUsers: []userSessions{
+			{node: node1, user: "yugabyte", count: 2},
+			{node: node2, user: "yugabyte", count: 3},
+		},
+		statements: []statementStats{
+			{
+				node: node1, queryID: "-4611686018427387904", user: "app", database: "orders",
+				query: "SELECT * FROM orders WHERE id = $1", calls: 100, totalTime: 250.5, meanTime: 2.505, rows: 100,
+			},
+			{
+				node: node2, queryID: "123456789", user: "app", database: "orders",
+				query: "UPDATE orders SET status = $1 WHERE id = $2", calls: 10, totalTime: 40, meanTime: 4, rows: 10,
+			},
+		},
+		lockWaits: []lockWaits{
+			{node: node1, lockType: "transactionid", count: 2},
+			{node: node1, lockType: "relation", count: 1},
+		},
+		transactions: []transactionStats{
+			{node: node1, oldestAge: 600, longRunning: 2},
+		},
+	}
+}
+
+func newTestScraper(t *testing.T, fc *fakeClient) *yugabyteDBScraper {
+	cfg := createDefaultConfig().(*Config)
+	s := newYugabyteDBScraper(receivertest.NewNopSettings(metadata.Type), cfg, func(*Config) (client, error) {
+		return fc, nil
+	})
+	require.NoError(t, s.start(t.Context(), componenttest.NewNopHost()))
+	return s
+}
+
+// findNodeMetric returns the metric emitted under the resource of the node with the given host
+func findNodeMetric(t *testing.T, metrics pmetric.Metrics, host, name string) pmetric.Metric {
+	rms := metrics.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		if h, _ := rms.At(i).Resource().Attributes().Get("yugabytedb.node.host"); h.Str() != host {
+			continue
+		}
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				if ms.At(k).Name() == name {
+					return ms.At(k)
+				}
+			}
+		}
+	}
+	require.Failf(t, "metric not found", "metric %q wasn't emitted for node %q", name, host)
+	return pmetric.NewMetric()
+}
+
+func TestScrape(t *testing.T) {
+	fc := newFakeClient()
+	s := newTestScraper(t, fc)
+
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+
+	rms := metrics.ResourceMetrics()
+	require.Equal(t, 2, rms.Len())
+	for i, node := range []nodeInfo{node1, node2} {
+		assert.Equal(t, map[string]any{
+			"yugabytedb.node.host":   node.host,
+			"yugabytedb.node.zone":   node.zone,
+			"yugabytedb.node.region": node.region,
+			"yugabytedb.node.cloud":  node.cloud,
+		}, rms.At(i).Resource().Attributes().AsRaw())
+	}
+
+	testCases := []struct {
+		host        string
+		running     int64
+		active      int64
+		connections map[string]int64
+		users       int64
+	}{
+		{
+			host:        node1.host,
+			running:     2,
+			active:      10,
+			connections: map[string]int64{"active/yugabyte": 2, "idle_in_transaction/app": 1},
+			users:       2,
+		},
+		{
+			host:        node2.host,
+			running:     3,
+			active:      5,
+			connections: map[string]int64{"active/yugabyte": 3},
+			users:       3,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.host, func(t *testing.T) {
+			running := findNodeMetric(t, metrics, tc.host, "yugabytedb.pg_stat_activity.running_queries")
+			assert.Equal(t, tc.running, running.Gauge().DataPoints().At(0).IntValue())
+
+			active := findNodeMetric(t, metrics, tc.host, "yugabytedb.pg_stat_activity.active_connections")
+			assert.Equal(t, tc.active, active.Gauge().DataPoints().At(0).IntValue())
+
+			connections := findNodeMetric(t, metrics, tc.host, "yugabytedb.connection.count")
+			counts := map[string]int64{}
+			dps := connections.Gauge().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				state, _ := dps.At(i).Attributes().Get("connection.state")
+				user, _ := dps.At(i).Attributes().Get("connection.user")
+				counts[state.Str()+"/"+user.Str()] = dps.At(i).IntValue()
+			}
+			assert.Equal(t, tc.connections, counts)
+
+			users := findNodeMetric(t, metrics, tc.host, "yugabytedb.active_users.count")
+			assert.Equal(t, tc.users, users.Gauge().DataPoints().At(0).IntValue())
+		})
+	}
+
+	require.NoError(t, s.shutdown(t.Context()))
+	assert.True(t, fc.closed)
+}
+
+func TestScrapeNodeWithoutActiveQueries(t *testing.T) {
+	fc := newFakeClient()
+	fc.runningQueries = fc.runningQueries[:1]
+	s := newTestScraper(t, fc)
+
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+
+	// node2 reported connections but no running queries, which is recorded as zero
+	running := findNodeMetric(t, metrics, node2.host, "yugabytedb.pg_stat_activity.running_queries")
+	assert.Equal(t, int64(0), running.Gauge().DataPoints().At(0).IntValue())
+}
+
+func TestScrapeLocalNode(t *testing.T) {
+	local := nodeInfo{host: "localhost"}
+	fc := &fakeClient{
+		runningQueries:    []nodeCount{{node: local, count: 1}},
+		activeConnections: []nodeCount{{node: local, count: 4}},
+	}
+	s := newTestScraper(t, fc)
+
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	assert.Equal(t, map[string]any{"yugabytedb.node.host": "localhost"},
+		metrics.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+	active := findNodeMetric(t, metrics, "localhost", "yugabytedb.pg_stat_activity.active_connections")
+	assert.Equal(t, int64(4), active.Gauge().DataPoints().At(0).IntValue())
+}
+
+func TestScrapeReusesClient(t *testing.T) {
+	created := 0
+	fc := newFakeClient()
+	cfg := createDefaultConfig().(*Config)
+	s := newYugabyteDBScraper(receivertest.NewNopSettings(metadata.Type), cfg, func(*Config) (client, error) {
+		created++
+		return fc, nil
+	})
+	require.NoError(t, s.start(t.Context(), componenttest.NewNopHost()))
+
+	for range 3 {
+		_, err := s.scrape(t.Context())
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, created)
+	require.NoError(t, s.shutdown(t.Context()))
+}
+
+func TestScrapeErrors(t *testing.T) {
+	fc := newFakeClient()
+	fc.err = errors.New("connection refused")
+	s := newTestScraper(t, fc)
+
+	_, err := s.scrape(t.Context())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestScrapeWithoutStart(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	s := newYugabyteDBScraper(receivertest.NewNopSettings(metadata.Type), cfg, newYugabyteDBClient)
+
+	_, err := s.scrape(t.Context())
+	assert.Error(t, err)
+	assert.NoError(t, s.shutdown(t.Context()))
+}
+
+func TestNormalizeConnectionState(t *testing.T) {
+	assert.Equal(t, "idle_in_transaction", normalizeConnectionState("idle in transaction"))
+	assert.Equal(t, "idle_in_transaction", normalizeConnectionState("idle in transaction (aborted)"))
+	assert.Equal(t, "unknown", normalizeConnectionState(""))
+	assert.Equal(t, "active", normalizeConnectionState("active"))
+}
+
+func TestScrapeStatementsAndLocks(t *testing.T) {
+	fc := newFakeClient()
+	cfg := createDefaultConfig().(*Config)
+	cfg.StatementStatistics.TopN = 5
+	cfg.LongRunningTransactionThreshold = 5 * time.Minute
+	cfg.Metrics.YugabytedbStatementCalls.Enabled = true
+	cfg.Metrics.YugabytedbStatementTime.Enabled = true
+	cfg.Metrics.YugabytedbStatementMeanTime.Enabled = true
+	cfg.Metrics.YugabytedbStatementRows.Enabled = true
+	cfg.Metrics.YugabytedbLockWaitingCount.Enabled = true
+	s := newYugabyteDBScraper(receivertest.NewNopSettings(metadata.Type), cfg, func(*Config) (client, error) {
+		return fc, nil
+	})
+	require.NoError(t, s.start(t.Context(), componenttest.NewNopHost()))
+
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 5, fc.topN)
+	assert.Equal(t, 5*time.Minute, fc.threshold)
+
+	calls := findNodeMetric(t, metrics, node1.host, "yugabytedb.statement.calls")
+	dp := calls.Sum().DataPoints().At(0)
+	assert.Equal(t, int64(100), dp.IntValue())
+	assert.Equal(t, map[string]any{
+		"query.id":        "-4611686018427387904",
+		"connection.user": "app",
+		"db.namespace":    "orders",
+	}, dp.Attributes().AsRaw())
+
+	totalTime := findNodeMetric(t, metrics, node1.host, "yugabytedb.statement.time")
+	assert.InDelta(t, 250.5, totalTime.Sum().DataPoints().At(0).DoubleValue(), 0.001)
+	meanTime := findNodeMetric(t, metrics, node2.host, "yugabytedb.statement.mean_time")
+	assert.InDelta(t, 4.0, meanTime.Gauge().DataPoints().At(0).DoubleValue(), 0.001)
+	rows := findNodeMetric(t, metrics, node2.host, "yugabytedb.statement.rows")
+	assert.Equal(t, int64(10), rows.Sum().DataPoints().At(0).IntValue())
+
+	locks := findNodeMetric(t, metrics, node1.host, "yugabytedb.lock.waiting.count")
+	waits := map[string]int64{}
+	for i := 0; i < locks.Gauge().DataPoints().Len(); i++ {
+		lockType, _ := locks.Gauge().DataPoints().At(i).Attributes().Get("lock.type")
+		waits[lockType.Str()] = locks.Gauge().DataPoints().At(i).IntValue()
+	}
+	assert.Equal(t, map[string]int64{"transactionid": 2, "relation": 1}, waits)
+
+	oldest := findNodeMetric(t, metrics, node1.host, "yugabytedb.transaction.oldest.age")
+	assert.Equal(t, int64(600), oldest.Gauge().DataPoints().At(0).IntValue())
+	longRunning := findNodeMetric(t, metrics, node1.host, "yugabytedb.transaction.long_running.count")
+	assert.Equal(t, int64(2), longRunning.Gauge().DataPoints().At(0).IntValue())
+
+	// node2 has no open transaction, which is recorded as zero
+	oldest = findNodeMetric(t, metrics, node2.host, "yugabytedb.transaction.oldest.age")
+	assert.Equal(t, int64(0), oldest.Gauge().DataPoints().At(0).IntValue())
+}
+
+func TestScrapeSkipsDisabledQueries(t *testing.T) {
+	fc := newFakeClient()
+	s := newTestScraper(t, fc)
+
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+
+	// The statement and lock metrics are disabled by default, so they are not queried
+	assert.Zero(t, fc.topN)
+	rms := metrics.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ms := rms.At(i).ScopeMetrics().At(0).Metrics()
+		for j := 0; j < ms.Len(); j++ {
+			assert.NotContains(t, []string{"yugabytedb.statement.calls", "yugabytedb.lock.waiting.count"}, ms.At(j).Name())
+		}
+	}
+}
+
+func TestScrapeTopQueries(t *testing.T) {
+	fc := newFakeClient()
+	s := newTestScraper(t, fc)
+
+	logs, err := s.scrapeTopQueries(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, defaultStatementsTopN, fc.topN)
+
+	rls := logs.ResourceLogs()
+	require.Equal(t, 2, rls.Len())
+	host, _ := rls.At(0).Resource().Attributes().Get("yugabytedb.node.host")
+	assert.Equal(t, node1.host, host.Str())
+
+	lrs := rls.At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 1, lrs.Len())
+	assert.Equal(t, "db.server.top_query", lrs.At(0).EventName())
+	assert.Equal(t, map[string]any{
+		"query.id":                        "-4611686018427387904",
+		"db.query.text":                   "SELECT * FROM orders WHERE id = $1",
+		"db.namespace":                    "orders",
+		"connection.user":                 "app",
+		"yugabytedb.statement.calls":      int64(100),
+		"yugabytedb.statement.total_time": 250.5,
+		"yugabytedb.statement.mean_time":  2.505,
+		"yugabytedb.statement.rows":       int64(100),
+	}, lrs.At(0).Attributes().AsRaw())
+}
+
+func TestScrapeTopQueriesError(t *testing.T) {
+	fc := newFakeClient()
+	fc.err = errors.New("relation \"pg_stat_statements\" does not exist")
+	s := newTestScraper(t, fc)
+
+	_, err := s.scrapeTopQueries(t.Context())
+	assert.ErrorContains(t, err, "pg_stat_statements")
+}
@@ -80,7 +80,7 @@ BEGIN
     FOR host IN SELECT s.host FROM yb_servers() s LOOP
         EXECUTE format('
             IMPORT FOREIGN SCHEMA "pg_catalog"
-            LIMIT TO ("pg_stat_activity", "pg_stat_statements", "pg_stat_database")
+            LIMIT TO ("pg_stat_activity", "pg_stat_statements", "pg_stat_database", "pg_locks")
             FROM SERVER "gv$%1$s" INTO "gv$%1$s"
         ', host);
 
@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package yugabytedbreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/yugabytedbreceiver"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gocql/gocql"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/yugabytedbreceiver/internal/metadata"
+)
+
+// dbAPIYCQL is the value of the db.api attribute of the YCQL metrics
+const dbAPIYCQL = "ycql"
+
+// Names of the Prometheus metrics of the YCQL web server
+const (
+	promYCQLConnections = "rpc_connections_alive"
+	promYCQLStmtPrefix  = "handler_latency_yb_cqlserver_SQLProcessor_"
+	promYCQLCountSuffix = "_count"
+	promYCQLSumSuffix   = "_sum"
+)
+
+// Queries of the YCQL system tables. YugabyteDB reports the region of a node as its data center,
+// and its zone as its rack.
+const (
+	ycqlTablesQuery    = `SELECT keyspace_name, table_name FROM system_schema.tables`
+	ycqlLocalNodeQuery = `SELECT rpc_address, data_center, rack FROM system.local`
+	ycqlPeerNodesQuery = `SELECT rpc_address, data_center, rack FROM system.peers`
+)
+
+// ycqlStatementTypes maps the YCQL statement handlers to the statement.type attribute
+var ycqlStatementTypes = []struct {
+	handler       string
+	statementType metadata.AttributeStatementType
+}{
+	{"SelectStmt", metadata.AttributeStatementTypeSelect},
+	{"InsertStmt", metadata.AttributeStatementTypeInsert},
+	{"UpdateStmt", metadata.AttributeStatementTypeUpdate},
+	{"DeleteStmt", metadata.AttributeStatementTypeDelete},
+	{"OtherStmts", metadata.AttributeStatementTypeOther},
+}
+
+// ycqlClient is the interface used by the scraper to query the YCQL system tables
+type ycqlClient interface {
+	// getNodes returns the tservers serving YCQL, as reported by system.local and system.peers
+	getNodes(ctx context.Context) ([]nodeInfo, error)
+	// getKeyspaceTables returns the number of tables of each user keyspace
+	getKeyspaceTables(ctx context.Context) (map[string]int64, error)
+	Close() error
+}
+
+// newYCQLClientFunc creates the YCQL client used by the scraper, allowing tests to replace it
+type newYCQLClientFunc func(ctx context.Context, cfg *Config) (ycqlClient, error)
+
+// gocqlClient queries the YCQL system tables. The session is created on the first scrape, so that
+// an unavailable YCQL endpoint doesn't prevent the receiver from starting.
+type gocqlClient struct {
+	cluster *gocql.ClusterConfig
+
+	mu      sync.Mutex
+	session *gocql.Session
+}
+
+var _ ycqlClient = (*gocqlClient)(nil)
+
+func newGocqlClient(ctx context.Context, cfg *Config) (ycqlClient, error) {
+	host := cfg.YCQL.Host
+	if host == "" {
+		host = cfg.Host
+	}
+
+	cluster := gocql.NewCluster(host)
+	cluster.Port = cfg.YCQL.Port
+	cluster.Consistency = gocql.One
+	if cfg.YCQL.User != "" {
+		cluster.Authenticator = gocql.PasswordAuthenticator{
+			Username: cfg.YCQL.User,
+			Password: string(cfg.YCQL.Password),
+		}
+	}
+	if !cfg.YCQL.TLS.Insecure {
+		tlsCfg, err := cfg.YCQL.TLS.LoadTLSConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the YCQL TLS configuration: %w", err)
+		}
+		cluster.SslOpts = &gocql.SslOptions{Config: tlsCfg}
+	}
+	return &gocqlClient{cluster: cluster}, nil
+}
+
+// getSession returns the session, creating it if needed
+func (c *gocqlClient) getSession() (*gocql.Session, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.session == nil {
+		session, err := c.cluster.CreateSession()
+		if err != nil {
+			return nil, err
+		}
+		c.session = session
+	}
+	return c.session, nil
+}
+
+func (c *gocqlClient) getNodes(ctx context.Context) ([]nodeInfo, error) {
+	session, err := c.getSession()
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []nodeInfo
+	for _, query := range []string{ycqlLocalNodeQuery, ycqlPeerNodesQuery} {
+		iter := session.Query(query).WithContext(ctx).Iter()
+		var node nodeInfo
+		for iter.Scan(&node.host, &node.region, &node.zone) {
+			nodes = append(nodes, node)
+		}
+		if err := iter.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func (c *gocqlClient) getKeyspaceTables(ctx context.Context) (map[string]int64, error) {
+	session, err := c.getSession()
+	if err != nil {
+		return nil, err
+	}
+
+	tables := map[string]int64{}
+	iter := session.Query(ycqlTablesQuery).WithContext(ctx).Iter()
+	var keyspace, table string
+	for iter.Scan(&keyspace, &table) {
+		if isSystemKeyspace(keyspace) {
+			continue
+		}
+		tables[keyspace]++
+	}
+	return tables, iter.Close()
+}
+
+// isSystemKeyspace reports whether the keyspace is one of the system, system_schema or system_auth keyspaces
+func isSystemKeyspace(keyspace string) bool {
+	return keyspace == "system" || strings.HasPrefix(keyspace, "system_")
+}
+
+func (c *gocqlClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.session != nil {
+		c.session.Close()
+		c.session = nil
+	}
+	return nil
+}
+
+// scrapeYCQL collects the keyspace table counts, and the connection and statement metrics of the
+// YCQL web server of every tserver
+func (s *yugabyteDBScraper) scrapeYCQL(ctx context.Context, now pcommon.Timestamp, errs *scrapererror.ScrapeErrors) {
+	tables, err := s.ycqlClient.getKeyspaceTables(ctx)
+	if err != nil {
+		errs.AddPartial(1, fmt.Errorf("failed to query the YCQL keyspaces: %w", err))
+	} else {
+		// The tables are defined at the universe level, so they are not attached to a node
+		keyspaces := make([]string, 0, len(tables))
+		for keyspace := range tables {
+			keyspaces = append(keyspaces, keyspace)
+		}
+		slices.Sort(keyspaces)
+		for _, keyspace := range keyspaces {
+			s.metricsBuilder.RecordYugabytedbAPIKeyspaceTableCountDataPoint(now, tables[keyspace], dbAPIYCQL, keyspace)
+		}
+		s.metricsBuilder.EmitForResource()
+	}
+
+	nodes, err := s.ycqlClient.getNodes(ctx)
+	if err != nil {
+		errs.AddPartial(3, fmt.Errorf("failed to discover the YCQL servers: %w", err))
+		return
+	}
+	for _, node := range nodes {
+		address := net.JoinHostPort(node.host, strconv.Itoa(s.config.YCQL.MetricsPort))
+		families, err := s.fetchPrometheusMetrics(ctx, address)
+		if err != nil {
+			errs.AddPartial(3, fmt.Errorf("failed to scrape the YCQL server %s: %w", address, err))
+			continue
+		}
+
+		s.metricsBuilder.RecordYugabytedbAPIConnectionCountDataPoint(now, sumFamily(families[promYCQLConnections]), dbAPIYCQL)
+		for _, st := range ycqlStatementTypes {
+			prefix := promYCQLStmtPrefix + st.handler
+			s.metricsBuilder.RecordYugabytedbAPIStatementCountDataPoint(now,
+				sumFamily(families[prefix+promYCQLCountSuffix]), dbAPIYCQL, st.statementType)
+			s.metricsBuilder.RecordYugabytedbAPIStatementTimeDataPoint(now,
+				sumFamily(families[prefix+promYCQLSumSuffix]), dbAPIYCQL, st.statementType)
+		}
+
+		rb := s.metricsBuilder.NewResourceBuilder()
+		setNodeResourceAttributes(rb, node)
+		s.metricsBuilder.EmitForResource(metadata.WithResource(rb.Emit()))
+	}
+}
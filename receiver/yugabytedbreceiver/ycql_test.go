@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package yugabytedbreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/yugabytedbreceiver/internal/metadata"
+)
+
+type fakeYCQLClient struct {
+	nodes  []nodeInfo
+	tables map[string]int64
+	err    error
+	closed bool
+}
+
+var _ ycqlClient = (*fakeYCQLClient)(nil)
+
+func (c *fakeYCQLClient) getNodes(context.Context) ([]nodeInfo, error) {
+	return c.nodes, c.err
+}
+
+func (c *fakeYCQLClient) getKeyspaceTables(context.Context) (map[string]int64, error) {
+	return c.tables, c.err
+}
+
+func (c *fakeYCQLClient) Close() error {
+	c.closed = true
+	return nil
+}
+
+func newYCQLTestScraper(t *testing.T, fc *fakeYCQLClient, metricsPort int) *yugabyteDBScraper {
+	cfg := createDefaultConfig().(*Config)
+	cfg.YCQL.Enabled = true
+	cfg.YCQL.MetricsPort = metricsPort
+	require.NoError(t, cfg.Validate())
+
+	s := newYugabyteDBScraper(receivertest.NewNopSettings(metadata.Type), cfg, func(*Config) (client, error) {
+		return newFakeClient(), nil
+	})
+	s.newYCQLClient = func(context.Context, *Config) (ycqlClient, error) {
+		return fc, nil
+	}
+	require.NoError(t, s.start(t.Context(), componenttest.NewNopHost()))
+	return s
+}
+
+// ycqlMetrics indexes the metrics carrying the ycql db.api attribute by name, and returns the
+// resource attributes under which they were emitted
+func ycqlMetrics(metrics pmetric.Metrics) (map[string]pmetric.Metric, []map[string]any) {
+	byName := map[string]pmetric.Metric{}
+	var resources []map[string]any
+	rms := metrics.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ms := rms.At(i).ScopeMetrics().At(0).Metrics()
+		found := false
+		for j := 0; j < ms.Len(); j++ {
+			if _, ok := byName[ms.At(j).Name()]; ok || !hasYCQLDataPoint(ms.At(j)) {
+				continue
+			}
+			byName[ms.At(j).Name()] = ms.At(j)
+			found = true
+		}
+		if found {
+			resources = append(resources, rms.At(i).Resource().Attributes().AsRaw())
+		}
+	}
+	return byName, resources
+}
+
+func hasYCQLDataPoint(m pmetric.Metric) bool {
+	var attrs []map[string]any
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < m.Gauge().DataPoints().Len(); i++ {
+			attrs = append(attrs, m.Gauge().DataPoints().At(i).Attributes().AsRaw())
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < m.Sum().DataPoints().Len(); i++ {
+			attrs = append(attrs, m.Sum().DataPoints().At(i).Attributes().AsRaw())
+		}
+	}
+	for _, a := range attrs {
+		if a["db.api"] == dbAPIYCQL {
+			return true
+		}
+	}
+	return false
+}
+
+func TestScrapeYCQL(t *testing.T) {
+	srv := newPrometheusServer(t, "ycql.txt")
+	host, port := splitServerURL(t, srv)
+
+	fc := &fakeYCQLClient{
+		nodes:  []nodeInfo{{host: host, zone: "us-east-1a", region: "us-east-1"}},
+		tables: map[string]int64{"orders": 3, "users": 1},
+	}
+	s := newYCQLTestScraper(t, fc, port)
+
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+
+	byName, resources := ycqlMetrics(metrics)
+	assert.Equal(t, []map[string]any{
+		{},
+		{
+			"yugabytedb.node.host":   host,
+			"yugabytedb.node.zone":   "us-east-1a",
+			"yugabytedb.node.region": "us-east-1",
+		},
+	}, resources)
+
+	tables := byName["yugabytedb.api.keyspace.table.count"].Gauge().DataPoints()
+	require.Equal(t, 2, tables.Len())
+	assert.Equal(t, map[string]any{"db.api": "ycql", "db.namespace": "orders"}, tables.At(0).Attributes().AsRaw())
+	assert.Equal(t, int64(3), tables.At(0).IntValue())
+	assert.Equal(t, int64(1), tables.At(1).IntValue())
+
+	assert.Equal(t, int64(12), byName["yugabytedb.api.connection.count"].Gauge().DataPoints().At(0).IntValue())
+	assert.Equal(t, map[string]int64{"select": 900, "insert": 300, "update": 50, "delete": 0, "other": 7},
+		sumValues(byName["yugabytedb.api.statement.count"], "statement.type"))
+	assert.Equal(t, map[string]int64{"select": 45000, "insert": 24000, "update": 5000, "delete": 0, "other": 700},
+		sumValues(byName["yugabytedb.api.statement.time"], "statement.type"))
+
+	require.NoError(t, s.shutdown(t.Context()))
+	assert.True(t, fc.closed)
+}
+
+func TestScrapeYCQLError(t *testing.T) {
+	fc := &fakeYCQLClient{err: errors.New("no hosts available in the pool")}
+	s := newYCQLTestScraper(t, fc, defaultYCQLWebPort)
+
+	metrics, err := s.scrape(t.Context())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.ErrorContains(t, err, "failed to query the YCQL keyspaces")
+	assert.ErrorContains(t, err, "failed to discover the YCQL servers")
+
+	// The YSQL metrics are still reported
+	byName, _ := ycqlMetrics(metrics)
+	assert.Empty(t, byName)
+	assert.Positive(t, metrics.MetricCount())
+}
+
+func TestIsSystemKeyspace(t *testing.T) {
+	assert.True(t, isSystemKeyspace("system"))
+	assert.True(t, isSystemKeyspace("system_schema"))
+	assert.True(t, isSystemKeyspace("system_auth"))
+	assert.False(t, isSystemKeyspace("systems"))
+	assert.False(t, isSystemKeyspace("orders"))
+}